@@ -2,7 +2,7 @@ import { Agent } from '@mastra/core/agent';
 import { Memory } from '@mastra/memory';
 import { LibSQLStore } from '@mastra/libsql';
 import { MockLanguageModelV1 } from 'ai/test';
-import { anthropic } from '@ai-sdk/anthropic';
+import { anthropic } from '../lib/anthropic';
 import { TDDValidator } from './tdd-validator';
 import * as features from '../lib/features';
 
@@ -1,5 +1,5 @@
 import { Metric, MetricResult } from "@mastra/core";
-import { anthropic } from "@ai-sdk/anthropic";
+import { anthropic } from "../lib/anthropic";
 import { generateText } from "ai";
 
 // Design Quality Evaluation Metric
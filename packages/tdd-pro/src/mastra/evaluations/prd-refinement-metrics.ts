@@ -1,5 +1,5 @@
 import { Metric, MetricResult } from "@mastra/core";
-import { anthropic } from "@ai-sdk/anthropic";
+import { anthropic } from "../lib/anthropic";
 import { generateText } from "ai";
 
 // Helper function for test environment scoring
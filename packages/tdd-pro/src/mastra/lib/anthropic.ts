@@ -0,0 +1,14 @@
+import { createAnthropic } from "@ai-sdk/anthropic";
+
+// Resolve the Anthropic API base URL from ANTHROPIC_BASE_URL so Claude traffic
+// can go through an enterprise gateway. Like the Anthropic SDKs the variable
+// excludes the /v1 path, which the AI SDK provider expects to be included.
+export function anthropicBaseURL(env: Record<string, string | undefined> = process.env): string | undefined {
+  const base = env.ANTHROPIC_BASE_URL?.trim().replace(/\/+$/, "");
+  if (!base) return undefined;
+  return base.endsWith("/v1") ? base : `${base}/v1`;
+}
+
+// Anthropic provider honoring ANTHROPIC_BASE_URL; use it instead of the
+// default `anthropic` export of @ai-sdk/anthropic
+export const anthropic = createAnthropic({ baseURL: anthropicBaseURL() });
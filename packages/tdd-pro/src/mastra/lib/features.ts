@@ -53,6 +53,49 @@ interface FeatureItem {
   id: string;
   name: string;
   description: string;
+  dependencies?: string[]; // IDs of features this one depends on
+  created_at?: string; // ISO 8601, when recorded in index.yml
+  updated_at?: string;
+}
+
+/**
+ * The current time as an ISO 8601 string. js-yaml quotes it on dump, so it
+ * loads back as a string rather than a Date.
+ */
+function timestamp(): string {
+  return new Date().toISOString();
+}
+
+/**
+ * A recorded timestamp as an ISO 8601 string. One written unquoted by hand
+ * loads as a Date.
+ */
+function timestampString(value: unknown): string | undefined {
+  if (value instanceof Date) return value.toISOString();
+  return typeof value === 'string' && value ? value : undefined;
+}
+
+/**
+ * Stamp a feature's updated_at in index.yml, e.g. after its PRD is saved.
+ * Features missing from the index are left alone.
+ */
+async function touchFeature(root: string, featureId: string, fsMod: any) {
+  const indexPath = path.join(root, ".tdd-pro", "features", "index.yml");
+  let data: any;
+  try {
+    data = yaml.load(await fsMod.readFile(indexPath, "utf8"));
+  } catch {
+    return;
+  }
+  if (typeof data !== 'object' || data === null) return;
+  for (const key of ["approved", "planned", "refinement", "backlog"]) {
+    const item = Array.isArray(data[key]) ? data[key].find((f: any) => f?.id === featureId) : undefined;
+    if (item) {
+      item.updated_at = timestamp();
+      await fsMod.writeFile(indexPath, yaml.dump(data), "utf8");
+      return;
+    }
+  }
 }
 
 interface FeaturesData {
@@ -103,7 +146,8 @@ export async function createFeature(cwd: string, featureId: string, name: string
     typeof item === 'string' ? { id: item, name: item, description: '' } : item
   );
   
-  const featureItem: FeatureItem = { id: featureId, name, description };
+  const now = timestamp();
+  const featureItem: FeatureItem = { id: featureId, name, description, created_at: now, updated_at: now };
   
   if (status === "backlog") {
     data.backlog.push(featureItem);
@@ -295,6 +339,13 @@ export async function getFeatures(cwd: string, fsMod: any = fs) {
     Array.isArray((data as any).refinement) &&
     Array.isArray((data as any).backlog)
   ) {
+    // Clients get timestamps as strings, even ones written unquoted by hand
+    for (const item of [...data.approved, ...data.planned, ...data.refinement, ...data.backlog]) {
+      if (item && typeof item === 'object') {
+        if (item.created_at !== undefined) item.created_at = timestampString(item.created_at);
+        if (item.updated_at !== undefined) item.updated_at = timestampString(item.updated_at);
+      }
+    }
     return data as FeaturesData;
   }
   return { approved: [], planned: [], refinement: [], backlog: [] };
@@ -303,7 +354,7 @@ export async function getFeatures(cwd: string, fsMod: any = fs) {
 export async function updateFeature(
   cwd: string, 
   featureId: string, 
-  updates: { id?: string; name?: string; description?: string }, 
+  updates: { id?: string; name?: string; description?: string; dependencies?: string[] }, 
   fsMod: any = fs
 ) {
   // Find .tdd-pro directory
@@ -348,11 +399,22 @@ export async function updateFeature(
     return items.map(item => {
       if (item.id === featureId) {
         found = true;
-        return {
+        const updated: FeatureItem = {
           id: updates.id || item.id,
           name: updates.name || item.name,
           description: updates.description || item.description
         };
+        const createdAt = timestampString(item.created_at);
+        if (createdAt) {
+          updated.created_at = createdAt;
+        }
+        updated.updated_at = timestamp();
+        // An empty list removes every link
+        const dependencies = updates.dependencies ?? item.dependencies;
+        if (dependencies && dependencies.length > 0) {
+          updated.dependencies = dependencies;
+        }
+        return updated;
       }
       return item;
     });
@@ -436,6 +498,59 @@ export async function promoteFeature(cwd: string, feature: string, fromStatus: "
   return data as FeaturesData;
 }
 
+export type FeatureStatus = "approved" | "planned" | "refinement" | "backlog";
+
+/**
+ * Move a feature to any status group, in either direction (unlike promoteFeature).
+ * The feature is appended to the end of its new group.
+ */
+export async function updateFeatureStatus(cwd: string, featureId: string, status: FeatureStatus, fsMod: any = fs) {
+  // Find .tdd-pro directory
+  const rootResult = await findTddProRoot(cwd, fsMod);
+  if (!rootResult.success) {
+    throw new Error(rootResult.error);
+  }
+
+  const indexPath = path.join(rootResult.root, ".tdd-pro", "features", "index.yml");
+  let data: any = { approved: [], planned: [], refinement: [], backlog: [] };
+  try {
+    const file = await fsMod.readFile(indexPath, "utf8");
+    const loaded = yaml.load(file);
+    if (typeof loaded === 'object' && loaded !== null) {
+      data = loaded;
+    }
+  } catch (e) {
+    throw new Error("Features index not found");
+  }
+
+  // Ensure arrays exist and convert old format
+  const statuses: FeatureStatus[] = ["approved", "planned", "refinement", "backlog"];
+  for (const s of statuses) {
+    if (!Array.isArray(data[s])) data[s] = [];
+    data[s] = data[s].map((item: any) =>
+      typeof item === 'string' ? { id: item, name: item, description: '' } : item
+    );
+  }
+
+  let item: FeatureItem | undefined;
+  for (const s of statuses) {
+    const found = data[s].find((f: FeatureItem) => f.id === featureId);
+    if (found) {
+      if (s === status) return data as FeaturesData;
+      item = found;
+      data[s] = data[s].filter((f: FeatureItem) => f.id !== featureId);
+    }
+  }
+  if (!item) {
+    throw new Error(`Feature ${featureId} not found`);
+  }
+  item.updated_at = timestamp();
+  data[status].push(item);
+
+  await fsMod.writeFile(indexPath, yaml.dump(data), "utf8");
+  return data as FeaturesData;
+}
+
 /**
  * Get all details for a specific feature, including index.yml, prd.md, and tasks.yml.
  * @param cwd Project root or subdirectory
@@ -493,6 +608,7 @@ export async function refineFeature(cwd: string, featureId: string, markdown: st
   const prdPath = path.join(rootResult.root, ".tdd-pro", "features", featureId, "prd.md");
   await fsMod.mkdir(path.dirname(prdPath), { recursive: true });
   await fsMod.writeFile(prdPath, markdown, "utf8");
+  await touchFeature(rootResult.root, featureId, fsMod);
   return { success: true };
 }
 
@@ -613,6 +729,7 @@ export async function updateFeatureDocument(cwd: string, featureId: string, cont
   
   // Write the content
   await fsMod.writeFile(prdPath, content, 'utf8');
+  await touchFeature(tddProResult.root, featureId, fsMod);
 }
 
 /**
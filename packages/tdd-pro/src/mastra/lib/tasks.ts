@@ -11,6 +11,15 @@ export const TASK_STATUS = {
 
 export type TaskStatus = typeof TASK_STATUS[keyof typeof TASK_STATUS];
 
+// Test outcome of one acceptance criterion
+export const CRITERION_STATUS = {
+  PENDING: "pending",
+  PASS: "pass",
+  FAIL: "fail"
+} as const;
+
+export type CriterionStatus = typeof CRITERION_STATUS[keyof typeof CRITERION_STATUS];
+
 // Task schema (for reference, not enforced here)
 export const TaskSchema = {
   id: "string",
@@ -18,11 +27,13 @@ export const TaskSchema = {
   status: "string", // Should be one of: pending, in-progress, completed
   description: "string?",
   acceptance_criteria: "string[]?",
+  criteria_results: "string[]?", // pending, pass or fail per acceptance criterion, in order
   owner: "string?",
   created: "string?",
   updated: "string?",
   dependencies: "string[]?",
   notes: "string?",
+  estimate: "number?", // story points
 };
 
 // Helper: get tasks file path
@@ -126,6 +137,23 @@ export async function moveTask(cwd: string, featureId: string, taskId: string, n
   return { success: true };
 }
 
+// getTaskTestResults: Get the test outcome of each acceptance criterion, in
+// order. Criteria without a recorded result are pending.
+export async function getTaskTestResults(cwd: string, featureId: string, taskId: string, fsMod: any = fs): Promise<CriterionStatus[]> {
+  const task = await getTask(cwd, featureId, taskId, fsMod);
+  const criteria: unknown[] = Array.isArray(task.acceptance_criteria) ? task.acceptance_criteria : [];
+  const results: unknown[] = Array.isArray(task.criteria_results) ? task.criteria_results : [];
+  const valid = Object.values(CRITERION_STATUS) as string[];
+  return criteria.map((_, i) =>
+    valid.includes(results[i] as string) ? (results[i] as CriterionStatus) : CRITERION_STATUS.PENDING
+  );
+}
+
+// setTaskTestResults: Record the test outcome of each acceptance criterion, in order
+export async function setTaskTestResults(cwd: string, featureId: string, taskId: string, results: CriterionStatus[], fsMod: any = fs) {
+  return updateTask(cwd, featureId, taskId, { criteria_results: results }, fsMod);
+}
+
 // getNextActiveTask: Get the next incomplete task
 export async function getNextActiveTask(cwd: string, featureId: string, fsMod: any = fs) {
   const tasks = await readTasks(cwd, featureId, fsMod);
@@ -7,6 +7,9 @@ const FeatureItemSchema = z.object({
   id: z.string(),
   name: z.string(),
   description: z.string(),
+  dependencies: z.array(z.string()).optional(),
+  created_at: z.string().optional(),
+  updated_at: z.string().optional(),
 });
 
 // Features Data Schema
@@ -21,7 +24,7 @@ const FeaturesDataSchema = z.object({
 });
 
 // TDD-Pro MCP Tools: Persona Usage Guide
-// - Planner/Refiner: Use createFeature, refineFeature, refineFeatureTasks, promoteFeature, updateFeature (for PRD/requirements only)
+// - Planner/Refiner: Use createFeature, refineFeature, refineFeatureTasks, promoteFeature, updateFeatureStatus, updateFeature (for PRD/requirements only)
 // - Implementation Developer: Use task tools (get-task, update-task, set-tasks, create-task, delete-task, move-task, etc.) to manage and mark tasks complete. Do NOT use updateFeature to mark tasks complete or update task status.
 
 // Create Feature Tool
@@ -98,6 +101,25 @@ export const promoteFeature = createTool({
   },
 });
 
+// Update Feature Status Tool
+export const updateFeatureStatus = createTool({
+  id: "update-feature-status",
+  description: "For Planner/Refiner persona: Move a feature to any status (approved, planned, refinement, backlog), including back to an earlier one. Use promote-feature for the normal refinement -> planned -> approved flow.",
+  inputSchema: z.object({
+    cwd: z.string().describe("Current working directory"),
+    featureId: z.string().describe("Feature ID to move"),
+    status: z.enum(["approved", "planned", "refinement", "backlog"]).describe("Target status"),
+  }),
+  outputSchema: z.object({
+    success: z.boolean(),
+    data: FeaturesDataSchema,
+  }),
+  execute: async ({ context }) => {
+    const result = await features.updateFeatureStatus(context.cwd, context.featureId, context.status);
+    return { success: true, data: result };
+  },
+});
+
 // Update Feature Tool
 export const updateFeature = createTool({
   id: "update-feature",
@@ -109,6 +131,7 @@ export const updateFeature = createTool({
       id: z.string().optional().describe("New feature ID (will rename folder)"),
       name: z.string().optional().describe("New feature name"),
       description: z.string().optional().describe("New feature description"),
+      dependencies: z.array(z.string()).optional().describe("IDs of the features this one depends on (replaces the current list)"),
     }).describe("Fields to update"),
   }),
   outputSchema: z.object({
@@ -346,6 +369,7 @@ export const featureTools = {
   listFeatures,
   updateFeature,
   promoteFeature,
+  updateFeatureStatus,
   deleteFeature,
   getFeature,
   refineFeature,
@@ -12,6 +12,9 @@ export const TASK_STATUS = tasks.TASK_STATUS;
 // Task status enum for zod validation
 const TaskStatusEnum = z.enum(["pending", "in-progress", "completed"]);
 
+// Acceptance criterion test outcome for zod validation
+const CriterionStatusEnum = z.enum(["pending", "pass", "fail"]);
+
 
 // Task schema for zod
 const TaskSchema = z.object({
@@ -20,11 +23,13 @@ const TaskSchema = z.object({
   status: TaskStatusEnum,
   description: z.string().optional(),
   acceptance_criteria: z.array(z.string()).optional(),
+  criteria_results: z.array(CriterionStatusEnum).optional(),
   owner: z.string().optional(),
   created: z.string().optional(),
   updated: z.string().optional(),
   dependencies: z.array(z.string()).optional(),
   notes: z.string().optional(),
+  estimate: z.number().optional(),
 });
 
 // getTasks: For Implementation Developer persona: List all tasks for a feature, returning only high-level info (id, name, status). Use this to get an overview of the work breakdown.
@@ -151,6 +156,37 @@ export const getNextActiveTask = createTool({
   },
 });
 
+// getTaskTestResults: For all personas: Get the test outcome (pending, pass or fail) of each acceptance criterion of a task, in order.
+export const getTaskTestResults = createTool({
+  id: "get-task-test-results",
+  description: "Get the test outcome (pending, pass or fail) of each acceptance criterion of a task, in order.",
+  inputSchema: z.object({
+    cwd: z.string().describe("Current working directory"),
+    featureId: z.string().describe("Feature ID (kebab-case)"),
+    taskId: z.string().describe("Task ID"),
+  }),
+  outputSchema: z.object({ results: z.array(CriterionStatusEnum) }),
+  execute: async ({ context }) => {
+    return { results: await tasks.getTaskTestResults(context.cwd, context.featureId, context.taskId) };
+  },
+});
+
+// setTaskTestResults: For Implementation Developer persona: Record the test outcome of each acceptance criterion after running the tests, one entry per criterion in order.
+export const setTaskTestResults = createTool({
+  id: "set-task-test-results",
+  description: "Record the test outcome (pending, pass or fail) of each acceptance criterion of a task after running its tests, one entry per criterion in order.",
+  inputSchema: z.object({
+    cwd: z.string().describe("Current working directory"),
+    featureId: z.string().describe("Feature ID (kebab-case)"),
+    taskId: z.string().describe("Task ID"),
+    results: z.array(CriterionStatusEnum).describe("Outcome of each acceptance criterion, in order."),
+  }),
+  outputSchema: z.object({ success: z.boolean() }),
+  execute: async ({ context }) => {
+    return await tasks.setTaskTestResults(context.cwd, context.featureId, context.taskId, context.results);
+  },
+});
+
 export const taskTools = {
   getTasks,
   getTask,
@@ -160,4 +196,6 @@ export const taskTools = {
   deleteTask,
   moveTask,
   getNextActiveTask,
+  getTaskTestResults,
+  setTaskTestResults,
 }; 
@@ -0,0 +1,13 @@
+import { test, expect } from "vitest";
+
+import { anthropicBaseURL } from "@/lib/anthropic";
+
+test("anthropicBaseURL is undefined without ANTHROPIC_BASE_URL", () => {
+  expect(anthropicBaseURL({})).toBeUndefined();
+  expect(anthropicBaseURL({ ANTHROPIC_BASE_URL: "  " })).toBeUndefined();
+});
+
+test("anthropicBaseURL appends the /v1 path to the gateway URL", () => {
+  expect(anthropicBaseURL({ ANTHROPIC_BASE_URL: "https://gateway.example.com/anthropic/" })).toBe("https://gateway.example.com/anthropic/v1");
+  expect(anthropicBaseURL({ ANTHROPIC_BASE_URL: "https://gateway.example.com/v1" })).toBe("https://gateway.example.com/v1");
+});
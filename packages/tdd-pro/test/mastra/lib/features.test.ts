@@ -1,6 +1,6 @@
 import { vol, fs as memfs } from "memfs";
 import yaml from "js-yaml";
-import { test, expect, beforeEach } from "vitest";
+import { test, expect, beforeEach, afterEach, vi } from "vitest";
 
 import * as features from "@/lib/features";
 
@@ -8,6 +8,10 @@ beforeEach(() => {
   vol.reset();
 });
 
+afterEach(() => {
+  vi.useRealTimers();
+});
+
 test("createFeature adds a refinement feature with proper validation", async () => {
   vol.fromJSON({
     "/project/.tdd-pro/features/index.yml": yaml.dump({ 
@@ -33,7 +37,9 @@ test("createFeature adds a refinement feature with proper validation", async ()
   expect(result.refinement[0]).toEqual({
     id: "user-auth",
     name: "User Authentication System", 
-    description: longDescription
+    description: longDescription,
+    created_at: expect.any(String),
+    updated_at: expect.any(String)
   });
 });
 
@@ -62,7 +68,9 @@ test("createFeature adds a backlog feature with proper validation", async () =>
   expect(result.backlog[0]).toEqual({
     id: "analytics",
     name: "Analytics Dashboard", 
-    description: longDescription
+    description: longDescription,
+    created_at: expect.any(String),
+    updated_at: expect.any(String)
   });
 });
 
@@ -142,6 +150,27 @@ test("promoteFeature moves feature from planned to approved", async () => {
   expect(result.approved.find(f => f.id === "test-feature")).toBeDefined();
 });
 
+test("updateFeatureStatus moves a feature back to an earlier status", async () => {
+  vol.fromJSON({
+    "/project/.tdd-pro/features/index.yml": yaml.dump({
+      approved: [{ id: "test-feature", name: "Test Feature", description: "Description" }],
+      planned: [],
+      refinement: [],
+      backlog: []
+    })
+  });
+  const result = await features.updateFeatureStatus("/project", "test-feature", "backlog", memfs.promises);
+  expect(result.approved).toHaveLength(0);
+  expect(result.backlog.find(f => f.id === "test-feature")?.name).toBe("Test Feature");
+});
+
+test("updateFeatureStatus throws for an unknown feature", async () => {
+  vol.fromJSON({
+    "/project/.tdd-pro/features/index.yml": yaml.dump({ approved: [], planned: [], refinement: [], backlog: [] })
+  });
+  await expect(features.updateFeatureStatus("/project", "missing", "planned", memfs.promises)).rejects.toThrow("not found");
+});
+
 test("updateFeature updates feature name and description", async () => {
   vol.fromJSON({
     "/project/.tdd-pro/features/index.yml": yaml.dump({ 
@@ -161,6 +190,80 @@ test("updateFeature updates feature name and description", async () => {
   expect(updatedFeature?.description).toBe("New description that is longer and more comprehensive");
 });
 
+test("updateFeature sets and clears dependencies", async () => {
+  vol.fromJSON({
+    "/project/.tdd-pro/features/index.yml": yaml.dump({
+      approved: [{ id: "auth", name: "Auth", description: "Description" }],
+      planned: [],
+      refinement: [{ id: "test-feature", name: "Test", description: "Description" }],
+      backlog: []
+    })
+  });
+  let result = await features.updateFeature("/project", "test-feature", { dependencies: ["auth"] }, memfs.promises);
+  expect(result.refinement.find(f => f.id === "test-feature")?.dependencies).toEqual(["auth"]);
+
+  // Other updates keep the links
+  result = await features.updateFeature("/project", "test-feature", { name: "Renamed" }, memfs.promises);
+  expect(result.refinement.find(f => f.id === "test-feature")?.dependencies).toEqual(["auth"]);
+
+  result = await features.updateFeature("/project", "test-feature", { dependencies: [] }, memfs.promises);
+  expect(result.refinement.find(f => f.id === "test-feature")?.dependencies).toBeUndefined();
+});
+
+test("updateFeature keeps created_at and bumps updated_at", async () => {
+  vi.useFakeTimers({ toFake: ["Date"] });
+  vi.setSystemTime(new Date("2025-03-04T05:06:07.000Z"));
+  vol.fromJSON({
+    "/project/.tdd-pro/features/index.yml": yaml.dump({
+      approved: [],
+      planned: [],
+      refinement: [{ id: "test-feature", name: "Test", description: "Description", created_at: "2025-01-02T03:04:05.000Z", updated_at: "2025-02-03T04:05:06.000Z" }],
+      backlog: []
+    })
+  });
+  const result = await features.updateFeature("/project", "test-feature", { name: "Renamed" }, memfs.promises);
+  const updated = result.refinement.find(f => f.id === "test-feature");
+  expect(updated?.created_at).toBe("2025-01-02T03:04:05.000Z");
+  expect(updated?.updated_at).toBe("2025-03-04T05:06:07.000Z");
+});
+
+test("features are stamped when created, edited, moved and their PRD is saved", async () => {
+  vi.useFakeTimers({ toFake: ["Date"] });
+  vol.fromJSON({
+    "/project/.tdd-pro/features/index.yml": yaml.dump({ approved: [], planned: [], refinement: [], backlog: [] })
+  });
+  const description = "A feature description long enough to pass the fifty character minimum.";
+  const stampAt = async (time: string, change: () => Promise<unknown>) => {
+    vi.setSystemTime(new Date(time));
+    await change();
+    const data = await features.getFeatures("/project", memfs.promises);
+    return [...data.refinement, ...data.planned].find(f => f.id === "search");
+  };
+
+  let feature = await stampAt("2025-01-01T00:00:00.000Z", () => features.createFeature("/project", "search", "Search", description, "refinement", memfs.promises));
+  expect(feature).toMatchObject({ created_at: "2025-01-01T00:00:00.000Z", updated_at: "2025-01-01T00:00:00.000Z" });
+  // Quoted, so js-yaml loads them back as strings rather than Dates
+  const index = (await memfs.promises.readFile("/project/.tdd-pro/features/index.yml", "utf8")).toString();
+  expect(index).toContain("created_at: '2025-01-01T00:00:00.000Z'");
+
+  feature = await stampAt("2025-01-02T00:00:00.000Z", () => features.updateFeature("/project", "search", { name: "Site Search" }, memfs.promises));
+  expect(feature).toMatchObject({ created_at: "2025-01-01T00:00:00.000Z", updated_at: "2025-01-02T00:00:00.000Z" });
+  feature = await stampAt("2025-01-03T00:00:00.000Z", () => features.updateFeatureStatus("/project", "search", "planned", memfs.promises));
+  expect(feature?.updated_at).toBe("2025-01-03T00:00:00.000Z");
+  feature = await stampAt("2025-01-04T00:00:00.000Z", () => features.updateFeatureDocument("/project", "search", "# Search", memfs.promises));
+  expect(feature?.updated_at).toBe("2025-01-04T00:00:00.000Z");
+  feature = await stampAt("2025-01-05T00:00:00.000Z", () => features.refineFeature("/project", "search", "# Search v2", memfs.promises));
+  expect(feature).toMatchObject({ created_at: "2025-01-01T00:00:00.000Z", updated_at: "2025-01-05T00:00:00.000Z" });
+});
+
+test("getFeatures returns hand-written unquoted timestamps as strings", async () => {
+  vol.fromJSON({
+    "/project/.tdd-pro/features/index.yml": "approved: []\nplanned: []\nrefinement:\n  - id: search\n    name: Search\n    description: Find things\n    created_at: 2025-01-02T03:04:05.000Z\nbacklog: []\n"
+  });
+  const data = await features.getFeatures("/project", memfs.promises);
+  expect(data.refinement[0].created_at).toBe("2025-01-02T03:04:05.000Z");
+});
+
 test("updateFeature can rename feature ID", async () => {
   vol.fromJSON({
     "/project/.tdd-pro/features/index.yml": yaml.dump({ 
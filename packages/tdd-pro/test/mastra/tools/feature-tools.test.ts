@@ -54,7 +54,9 @@ test("createFeature tool creates a feature in refinement", async () => {
   expect(result.refinement[0]).toEqual({
     id: "user-auth",
     name: "User Authentication System",
-    description: expect.stringContaining("comprehensive feature")
+    description: expect.stringContaining("comprehensive feature"),
+    created_at: expect.any(String),
+    updated_at: expect.any(String)
   });
 });
 
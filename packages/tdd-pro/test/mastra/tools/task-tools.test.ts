@@ -4,6 +4,7 @@ import { vol, fs as memfs } from "memfs";
 import yaml from "js-yaml";
 import { test, expect, beforeEach } from "vitest";
 import * as tasks from "@/lib/tasks";
+import { createTask as createTaskTool, getTask as getTaskTool, updateTask as updateTaskTool } from "@/tools/task-tools";
 
 const cwd = "/project";
 const featureId = "feature-1";
@@ -142,4 +143,24 @@ test("updateTask can change status between all valid values", async () => {
   await tasks.updateTask(cwd, featureId, "status-test", { status: "completed" }, memfs.promises);
   result = await tasks.getTask(cwd, featureId, "status-test", memfs.promises);
   expect(result.status).toBe("completed");
-}); 
\ No newline at end of file
+}); 
+test("getTaskTestResults reports each criterion, pending until recorded", async () => {
+  const task = { id: "t", name: "T", status: "pending", acceptance_criteria: ["one", "two", "three"] };
+  await tasks.setTasks(cwd, featureId, [task], memfs.promises);
+  expect(await tasks.getTaskTestResults(cwd, featureId, "t", memfs.promises)).toEqual(["pending", "pending", "pending"]);
+
+  await tasks.setTaskTestResults(cwd, featureId, "t", ["pass", "fail"], memfs.promises);
+  expect(await tasks.getTaskTestResults(cwd, featureId, "t", memfs.promises)).toEqual(["pass", "fail", "pending"]);
+});
+
+test("task estimates survive the tool schemas and tasks.yml", async () => {
+  await tasks.setTasks(cwd, featureId, [], memfs.promises);
+  const created = createTaskTool.inputSchema!.parse({ cwd, featureId, task: { id: "est", name: "Estimated", status: "pending", estimate: 3 } });
+  await tasks.createTask(cwd, featureId, created.task, memfs.promises);
+  expect(getTaskTool.outputSchema!.parse(await tasks.getTask(cwd, featureId, "est", memfs.promises)).estimate).toBe(3);
+
+  const updated = updateTaskTool.inputSchema!.parse({ cwd, featureId, taskId: "est", updates: { estimate: 5 } });
+  await tasks.updateTask(cwd, featureId, "est", updated.updates, memfs.promises);
+  const file = yaml.load((await memfs.promises.readFile(tasksPath, "utf8")).toString()) as any;
+  expect(file[0].estimate).toBe(5);
+});
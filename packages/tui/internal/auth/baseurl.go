@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the Anthropic API used when no gateway is configured
+const DefaultBaseURL = "https://api.anthropic.com"
+
+// anthropicVersion is the API version header sent with key verification
+const anthropicVersion = "2023-06-01"
+
+// ErrInvalidAPIKey is returned by VerifyAPIKey when the API rejects the key
+var ErrInvalidAPIKey = errors.New("API key was rejected")
+
+// configuredBaseURL is anthropic_base_url from config.yml, set at startup
+var configuredBaseURL string
+
+// verifyClient honors HTTPS_PROXY/HTTP_PROXY through the default transport
+var verifyClient = &http.Client{Timeout: 15 * time.Second}
+
+// SetConfiguredBaseURL records the anthropic_base_url config value so
+// ResolveBaseURL can fall back to it
+func SetConfiguredBaseURL(url string) {
+	configuredBaseURL = url
+}
+
+// ResolveBaseURL returns the Anthropic API base URL and where it came from,
+// for routing Claude traffic through an enterprise gateway. Precedence:
+// ANTHROPIC_BASE_URL environment variable > anthropic_base_url in config.yml
+// > DefaultBaseURL. Like the Anthropic SDKs, the URL excludes the /v1 path.
+func ResolveBaseURL() (string, string) {
+	if url := strings.TrimSpace(os.Getenv("ANTHROPIC_BASE_URL")); url != "" {
+		return strings.TrimRight(url, "/"), "ANTHROPIC_BASE_URL"
+	}
+	if url := strings.TrimSpace(configuredBaseURL); url != "" {
+		return strings.TrimRight(url, "/"), "config.yml"
+	}
+	return DefaultBaseURL, "default"
+}
+
+// BaseURL returns the Anthropic API base URL, see ResolveBaseURL
+func BaseURL() string {
+	url, _ := ResolveBaseURL()
+	return url
+}
+
+// VerifyAPIKey checks apiKey with a models listing against baseURL. A key the
+// API refuses yields ErrInvalidAPIKey; other errors mean it couldn't be checked.
+func VerifyAPIKey(ctx context.Context, baseURL, apiKey string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/v1/models?limit=1", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-api-key", apiKey)
+	req.Header.Set("anthropic-version", anthropicVersion)
+	resp, err := verifyClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return ErrInvalidAPIKey
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("unexpected status %s from %s", resp.Status, baseURL)
+	}
+	return nil
+}
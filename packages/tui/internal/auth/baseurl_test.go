@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolveBaseURL_Precedence(t *testing.T) {
+	defer SetConfiguredBaseURL("")
+	t.Setenv("ANTHROPIC_BASE_URL", "")
+
+	if url, source := ResolveBaseURL(); url != DefaultBaseURL || source != "default" {
+		t.Errorf("Expected the default base URL, got %q from %s", url, source)
+	}
+	SetConfiguredBaseURL("https://config.example.com/anthropic/")
+	if url, source := ResolveBaseURL(); url != "https://config.example.com/anthropic" || source != "config.yml" {
+		t.Errorf("Expected the config.yml base URL, got %q from %s", url, source)
+	}
+	t.Setenv("ANTHROPIC_BASE_URL", "https://gateway.example.com")
+	if url, source := ResolveBaseURL(); url != "https://gateway.example.com" || source != "ANTHROPIC_BASE_URL" {
+		t.Errorf("Expected ANTHROPIC_BASE_URL to win, got %q from %s", url, source)
+	}
+}
+
+func TestVerifyAPIKey_UsesBaseURL(t *testing.T) {
+	var gotPath, gotKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotKey = r.URL.Path, r.Header.Get("x-api-key")
+		if gotKey != "sk-ant-good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+	t.Setenv("ANTHROPIC_BASE_URL", server.URL+"/")
+
+	if err := VerifyAPIKey(context.Background(), BaseURL(), "sk-ant-good"); err != nil {
+		t.Fatalf("Expected the key to verify, got %v", err)
+	}
+	if gotPath != "/v1/models" || gotKey != "sk-ant-good" {
+		t.Errorf("Expected a models request with the key, got path %q key %q", gotPath, gotKey)
+	}
+	if err := VerifyAPIKey(context.Background(), BaseURL(), "sk-ant-bad"); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("Expected ErrInvalidAPIKey, got %v", err)
+	}
+}
@@ -1,7 +1,6 @@
 package auth
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -32,33 +31,19 @@ func GetClaudeAPIKey() (string, error) {
 	return creds.ClaudeAPIKey, nil
 }
 
-// LoadCredentials loads authentication credentials from the auth file
-func LoadCredentials() (*Credentials, error) {
-	configDir, err := getConfigDir()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get config directory: %w", err)
-	}
-	
-	authPath := filepath.Join(configDir, "auth.json")
-	
-	// Check if auth file exists
-	if _, err := os.Stat(authPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("no credentials file found at %s", authPath)
-	}
-	
-	// Read auth file
-	data, err := os.ReadFile(authPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read auth file: %w", err)
-	}
-	
-	// Parse credentials
-	var creds Credentials
-	if err := json.Unmarshal(data, &creds); err != nil {
-		return nil, fmt.Errorf("failed to parse auth file: %w", err)
+// ResolveAPIKey returns the Claude API key and where it came from: the
+// ANTHROPIC_API_KEY environment variable or the configured credential store
+func ResolveAPIKey() (string, string, error) {
+	if apiKey := os.Getenv("ANTHROPIC_API_KEY"); apiKey != "" {
+		return apiKey, "ANTHROPIC_API_KEY", nil
 	}
-	
-	return &creds, nil
+	apiKey, err := GetClaudeAPIKey()
+	return apiKey, CredentialLocation(), err
+}
+
+// LoadCredentials loads authentication credentials from the configured store
+func LoadCredentials() (*Credentials, error) {
+	return credentialStore.Load()
 }
 
 // HasCredentials checks if valid credentials exist
@@ -103,7 +88,5 @@ func GetAuthStatus() string {
 		return "Not authenticated - no API key configured"
 	}
 	
-	configDir, _ := getConfigDir()
-	authPath := filepath.Join(configDir, "auth.json")
-	return fmt.Sprintf("Authenticated via stored credentials (%s)", authPath)
+	return fmt.Sprintf("Authenticated via stored credentials (%s)", CredentialLocation())
 }
\ No newline at end of file
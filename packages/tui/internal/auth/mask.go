@@ -0,0 +1,25 @@
+package auth
+
+import "strings"
+
+// apiKeyPrefix starts every Anthropic API key
+const apiKeyPrefix = "sk-ant-"
+
+// minMaskedKeyLen is the shortest key whose last four characters can be
+// shown without giving most of it away
+const minMaskedKeyLen = 16
+
+// MaskAPIKey shows enough of key to tell keys apart, e.g. "sk-ant-...abcd".
+// Short or malformed keys are masked entirely.
+func MaskAPIKey(key string) string {
+	key = strings.TrimSpace(key)
+	runes := []rune(key)
+	if len(runes) < minMaskedKeyLen {
+		return "****"
+	}
+	prefix := ""
+	if strings.HasPrefix(key, apiKeyPrefix) {
+		prefix = apiKeyPrefix
+	}
+	return prefix + "..." + string(runes[len(runes)-4:])
+}
@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+)
+
+// CredentialStore is where the Claude API key saved with /auth is kept
+type CredentialStore interface {
+	Load() (*Credentials, error)
+	Save(creds *Credentials) error
+	// Location describes where credentials are kept, for status messages
+	Location() string
+}
+
+// Store names accepted by credential_store in config.yml
+const (
+	StoreFile     = "file"
+	StoreKeychain = "keychain"
+)
+
+// Keychain entry holding the API key
+const (
+	keychainService = "tdd-pro"
+	keychainUser    = "claude_api_key"
+)
+
+// credentialStore is selected by credential_store in config.yml at startup
+var credentialStore CredentialStore = FileStore{}
+
+// SetCredentialStore makes credentials load from and save to store
+func SetCredentialStore(store CredentialStore) {
+	credentialStore = store
+}
+
+// NewCredentialStore returns the store named by credential_store: "file"
+// (the default when empty) or "keychain"
+func NewCredentialStore(name string) (CredentialStore, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", StoreFile:
+		return FileStore{}, nil
+	case StoreKeychain:
+		return KeychainStore{}, nil
+	}
+	return nil, fmt.Errorf("unknown credential_store %q, expected %q or %q", name, StoreFile, StoreKeychain)
+}
+
+// SaveCredentials stores creds in the configured store
+func SaveCredentials(creds *Credentials) error {
+	return credentialStore.Save(creds)
+}
+
+// CredentialLocation describes where the configured store keeps credentials
+func CredentialLocation() string {
+	return credentialStore.Location()
+}
+
+// FileStore keeps credentials in auth.json in the config directory,
+// readable only by the user
+type FileStore struct{}
+
+func (FileStore) path() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config directory: %w", err)
+	}
+	return filepath.Join(configDir, "auth.json"), nil
+}
+
+func (s FileStore) Load() (*Credentials, error) {
+	authPath, err := s.path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(authPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no credentials file found at %s", authPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read auth file: %w", err)
+	}
+	var creds Credentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("failed to parse auth file: %w", err)
+	}
+	return &creds, nil
+}
+
+func (s FileStore) Save(creds *Credentials) error {
+	authPath, err := s.path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(authPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	data, err := json.MarshalIndent(creds, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+	if err := os.WriteFile(authPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write auth file: %w", err)
+	}
+	return nil
+}
+
+func (s FileStore) Location() string {
+	if authPath, err := s.path(); err == nil {
+		return authPath
+	}
+	return "auth.json"
+}
+
+// KeychainStore keeps the API key in the OS credential store: the macOS
+// Keychain, the Secret Service (GNOME Keyring, KWallet) on Linux, or the
+// Windows Credential Manager
+type KeychainStore struct{}
+
+func (s KeychainStore) Load() (*Credentials, error) {
+	apiKey, err := keyring.Get(keychainService, keychainUser)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil, fmt.Errorf("no credentials found in %s", s.Location())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from %s: %w", s.Location(), err)
+	}
+	return &Credentials{ClaudeAPIKey: apiKey}, nil
+}
+
+func (s KeychainStore) Save(creds *Credentials) error {
+	if err := keyring.Set(keychainService, keychainUser, creds.ClaudeAPIKey); err != nil {
+		return fmt.Errorf("failed to write to %s: %w", s.Location(), err)
+	}
+	return nil
+}
+
+func (KeychainStore) Location() string {
+	return "the OS keychain"
+}
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func TestFileStore_SavesPrivateAuthFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	store := FileStore{}
+
+	if _, err := store.Load(); err == nil {
+		t.Fatalf("Expected an error before anything was saved")
+	}
+	if err := store.Save(&Credentials{ClaudeAPIKey: "sk-ant-file"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	creds, err := store.Load()
+	if err != nil || creds.ClaudeAPIKey != "sk-ant-file" {
+		t.Fatalf("Expected the saved key back, got %+v (err %v)", creds, err)
+	}
+	info, err := os.Stat(filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "tdd-pro", "auth.json"))
+	if err != nil {
+		t.Fatalf("Expected auth.json in the config directory: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("Expected auth.json to be readable only by the user, got %v", perm)
+	}
+}
+
+func TestGetClaudeAPIKey_ConsultsConfiguredStore(t *testing.T) {
+	keyring.MockInit()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("ANTHROPIC_API_KEY", "")
+	defer SetCredentialStore(FileStore{})
+
+	store, err := NewCredentialStore("Keychain")
+	if err != nil {
+		t.Fatalf("NewCredentialStore failed: %v", err)
+	}
+	SetCredentialStore(store)
+	if _, err := GetClaudeAPIKey(); err == nil {
+		t.Fatalf("Expected no key before one is saved")
+	}
+	if err := SaveCredentials(&Credentials{ClaudeAPIKey: "sk-ant-keychain"}); err != nil {
+		t.Fatalf("SaveCredentials failed: %v", err)
+	}
+	if key, err := GetClaudeAPIKey(); err != nil || key != "sk-ant-keychain" {
+		t.Errorf("Expected the key from the keychain, got %q (err %v)", key, err)
+	}
+	if _, err := os.Stat(filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "tdd-pro", "auth.json")); !os.IsNotExist(err) {
+		t.Errorf("Expected no plaintext auth.json with the keychain store, got %v", err)
+	}
+	if status := GetAuthStatus(); status != "Authenticated via stored credentials (the OS keychain)" {
+		t.Errorf("Expected the status to name the keychain, got %q", status)
+	}
+
+	// The environment still takes precedence
+	t.Setenv("ANTHROPIC_API_KEY", "sk-ant-env")
+	if key, _ := GetClaudeAPIKey(); key != "sk-ant-env" {
+		t.Errorf("Expected ANTHROPIC_API_KEY to win, got %q", key)
+	}
+}
+
+func TestNewCredentialStore_RejectsUnknownNames(t *testing.T) {
+	if store, err := NewCredentialStore(""); err != nil || store != (FileStore{}) {
+		t.Errorf("Expected the file store by default, got %v (err %v)", store, err)
+	}
+	if _, err := NewCredentialStore("vault"); err == nil {
+		t.Errorf("Expected an unknown store to be rejected")
+	}
+}
+
+func TestMaskAPIKey(t *testing.T) {
+	cases := map[string]string{
+		"sk-ant-REDACTED": "sk-ant-...mnop",
+		"other-provider-key-1234":       "...1234",
+		"sk-ant-abcd":                   "****",
+		"abc":                           "****",
+		"":                              "****",
+		"  sk-ant-api03-abcdefgh9876  ": "sk-ant-...9876",
+	}
+	for key, want := range cases {
+		if got := MaskAPIKey(key); got != want {
+			t.Errorf("MaskAPIKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
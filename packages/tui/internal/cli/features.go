@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"tddpro/internal/mcpclient"
+)
+
+// Features implements `tdd-pro features`, printing the project's features as
+// JSON (the default) or a table without starting the TUI. Returns the exit code.
+func Features(client mcpclient.Client, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("features", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	jsonFlag := fs.Bool("json", false, "Print features as JSON (same as --format=json)")
+	format := fs.String("format", "json", "Output format: json or table")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *jsonFlag {
+		*format = "json"
+	}
+	if *format != "json" && *format != "table" {
+		fmt.Fprintf(stderr, "Error: unknown format %q (use json or table)\n", *format)
+		return 2
+	}
+
+	data, err := client.ListFeaturesViaStdio()
+	if err != nil {
+		fmt.Fprintln(stderr, "Error listing features:", err)
+		return 1
+	}
+
+	if *format == "table" {
+		writeFeaturesTable(stdout, data)
+		return 0
+	}
+	out, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		fmt.Fprintln(stderr, "Error encoding features:", err)
+		return 1
+	}
+	fmt.Fprintln(stdout, string(out))
+	return 0
+}
+
+// writeFeaturesTable prints one row per feature, marking current features with *
+func writeFeaturesTable(w io.Writer, data *mcpclient.FeaturesData) {
+	current := map[string]bool{}
+	for _, id := range data.CurrentFeatures {
+		current[id] = true
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tID\tNAME\tCURRENT")
+	groups := []struct {
+		status   string
+		features []mcpclient.Feature
+	}{
+		{"approved", data.Approved},
+		{"planned", data.Planned},
+		{"refinement", data.Refinement},
+		{"backlog", data.Backlog},
+	}
+	for _, group := range groups {
+		for _, f := range group.features {
+			mark := ""
+			if current[f.ID] {
+				mark = "*"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", group.status, f.ID, f.Name, mark)
+		}
+	}
+	tw.Flush()
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"tddpro/internal/mcpclient"
+)
+
+func TestFeatures_JSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Features(mcpclient.NewDemoClient(), []string{"--json"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Expected exit 0, got %d: %s", code, stderr.String())
+	}
+	var data mcpclient.FeaturesData
+	if err := json.Unmarshal(stdout.Bytes(), &data); err != nil {
+		t.Fatalf("Expected JSON output, got %q: %v", stdout.String(), err)
+	}
+	if len(data.Approved) == 0 || data.Approved[0].ID != "user-auth" {
+		t.Errorf("Expected demo features, got %+v", data)
+	}
+}
+
+func TestFeatures_Table(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Features(mcpclient.NewDemoClient(), []string{"--format=table"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Expected exit 0, got %d: %s", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if !strings.HasPrefix(lines[0], "STATUS") {
+		t.Errorf("Expected header row, got %q", lines[0])
+	}
+	if !strings.Contains(stdout.String(), "backlog") || !strings.Contains(stdout.String(), "dark-mode") {
+		t.Errorf("Expected every status group in table, got:\n%s", stdout.String())
+	}
+}
+
+func TestFeatures_UnknownFormat(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Features(mcpclient.NewDemoClient(), []string{"--format=xml"}, &stdout, &stderr); code == 0 {
+		t.Error("Expected non-zero exit for unknown format")
+	}
+	if !strings.Contains(stderr.String(), "xml") {
+		t.Errorf("Expected error to name the format, got %q", stderr.String())
+	}
+}
@@ -21,19 +21,27 @@ func NewInitCommand() *InitCommand {
 	return &InitCommand{}
 }
 
-// Execute handles the /init command execution
+// Execute handles the /init command execution. "/init --repair" recreates a
+// missing features index in an existing project instead.
 func (cmd *InitCommand) Execute(arg string) (tea.Model, tea.Cmd) {
-	// Get current working directory or use provided argument
-	cwd := arg
-	if cwd == "" {
-		var err error
+	if arg == "--repair" {
+		return nil, cmd.repair()
+	}
+
+	// Get current working directory or use provided argument, expanding ~
+	// and relative paths; a missing directory is created below
+	var cwd string
+	var err error
+	if arg == "" {
 		cwd, err = os.Getwd()
-		if err != nil {
-			return nil, func() tea.Msg {
-				return CommandResultMsg{
-					Success: false,
-					Message: "Error getting current directory: " + err.Error(),
-				}
+	} else {
+		cwd, err = util.NormalizePath(arg)
+	}
+	if err != nil {
+		return nil, func() tea.Msg {
+			return CommandResultMsg{
+				Success: false,
+				Message: "Error resolving project directory: " + err.Error(),
 			}
 		}
 	}
@@ -110,6 +118,35 @@ func (cmd *InitCommand) createTddProStructure(cwd string) error {
 		return fmt.Errorf("failed to create .tdd-pro directory: %w", err)
 	}
 
+	return writeFeaturesIndex(tddProDir)
+}
+
+// repair recreates features/index.yml for a project that has .tdd-pro but lost its index
+func (cmd *InitCommand) repair() tea.Cmd {
+	result := func(success bool, message string) tea.Cmd {
+		return func() tea.Msg {
+			return CommandResultMsg{Success: success, Message: message}
+		}
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return result(false, "Error getting current directory: "+err.Error())
+	}
+	if !util.IsAlreadyInitialized(cwd) {
+		return result(false, "No .tdd-pro project found - run /init to create one")
+	}
+	indexPath, missing := util.MissingFeaturesIndex(cwd)
+	if !missing {
+		return result(true, "Nothing to repair: features/index.yml exists")
+	}
+	if err := writeFeaturesIndex(filepath.Dir(filepath.Dir(indexPath))); err != nil {
+		return result(false, "Error repairing project: "+err.Error())
+	}
+	return result(true, "Recreated "+indexPath)
+}
+
+// writeFeaturesIndex creates the features directory and an empty index.yml
+func writeFeaturesIndex(tddProDir string) error {
 	// Create features directory
 	featuresDir := filepath.Join(tddProDir, "features")
 	if err := os.MkdirAll(featuresDir, 0755); err != nil {
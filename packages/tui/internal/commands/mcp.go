@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"tddpro/internal/components/config"
+	"tddpro/internal/util"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// MCPCommand handles the /mcp command, which (re)creates the editor MCP
+// config files of an initialized project
+type MCPCommand struct {
+	mcpDialog *config.MCPConfigDialog
+}
+
+// NewMCPCommand creates a new mcp command handler
+func NewMCPCommand() *MCPCommand {
+	return &MCPCommand{}
+}
+
+// Execute opens the MCP configuration dialog against the project root, the
+// directory containing the .tdd-pro found from the working directory.
+// "/mcp remove" takes tdd-pro out of the editor config files instead.
+func (cmd *MCPCommand) Execute(arg string) (tea.Model, tea.Cmd) {
+	result := func(message string) tea.Cmd {
+		return func() tea.Msg {
+			return CommandResultMsg{Success: false, Message: message}
+		}
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, result("Error getting current directory: " + err.Error())
+	}
+	if !util.IsAlreadyInitialized(cwd) {
+		return nil, result("No .tdd-pro project found - run /init to create one")
+	}
+	root := filepath.Dir(util.FindTddProDirectoryDefault(cwd))
+	if strings.TrimSpace(arg) == "remove" {
+		return nil, cmd.remove(root)
+	}
+
+	cmd.mcpDialog = config.NewMCPReconfigureDialog(root)
+	cmd.mcpDialog.Show()
+
+	return cmd.mcpDialog, cmd.mcpDialog.Init()
+}
+
+// remove takes the tdd-pro server out of each editor config file under root,
+// keeping other servers and deleting files left empty
+func (cmd *MCPCommand) remove(root string) tea.Cmd {
+	var changed, failed []string
+	for _, name := range config.MCPConfigFiles {
+		removal, err := config.RemoveMCPServer(filepath.Join(root, name))
+		switch {
+		case err != nil:
+			failed = append(failed, name+": "+err.Error())
+		case removal.Deleted:
+			changed = append(changed, name+" (deleted)")
+		case removal.Removed:
+			changed = append(changed, name)
+		}
+	}
+	return func() tea.Msg {
+		if len(failed) > 0 {
+			return CommandResultMsg{Success: false, Message: "Error removing tdd-pro: " + strings.Join(failed, ", ")}
+		}
+		if len(changed) == 0 {
+			return CommandResultMsg{Success: true, Message: "No MCP config file lists tdd-pro"}
+		}
+		return CommandResultMsg{Success: true, Message: "Removed tdd-pro from " + strings.Join(changed, ", ")}
+	}
+}
+
+// Update handles updates for the MCP dialog
+func (cmd *MCPCommand) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if cmd.mcpDialog == nil {
+		return nil, nil
+	}
+
+	var mcpCmd tea.Cmd
+	model, mcpCmd := cmd.mcpDialog.Update(msg)
+	cmd.mcpDialog = model.(*config.MCPConfigDialog)
+
+	// Check for MCP configuration completion
+	if mcpMsg, ok := msg.(config.MCPConfigMsg); ok {
+		return nil, func() tea.Msg {
+			return CommandResultMsg{
+				Success: mcpMsg.Success,
+				Message: mcpMsg.Message,
+			}
+		}
+	}
+
+	return cmd.mcpDialog, mcpCmd
+}
+
+// View renders the MCP dialog
+func (cmd *MCPCommand) View() string {
+	if cmd.mcpDialog == nil {
+		return ""
+	}
+	return cmd.mcpDialog.View()
+}
+
+// IsActive returns whether the mcp command is currently active
+func (cmd *MCPCommand) IsActive() bool {
+	return cmd.mcpDialog != nil && cmd.mcpDialog.IsVisible()
+}
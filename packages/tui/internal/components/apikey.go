@@ -0,0 +1,112 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"tddpro/internal/auth"
+	"tddpro/internal/components/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyRevealDuration is how long /auth show displays the full key after r
+const keyRevealDuration = 5 * time.Second
+
+// keyReveal is the API key reported by /auth show, masked unless revealed
+type keyReveal struct {
+	key      string
+	source   string // ANTHROPIC_API_KEY or the credential store
+	revealed bool
+	seq      int // tells the current reveal's expiry from earlier ones
+}
+
+// keyRevealEndedMsg masks the key again after a reveal
+type keyRevealEndedMsg struct {
+	seq int
+}
+
+// apiKeyStatus describes the Claude API key in use with the key masked
+func apiKeyStatus() string {
+	key, source, err := auth.ResolveAPIKey()
+	if err != nil || key == "" {
+		return "Claude key: not configured, run /auth"
+	}
+	return fmt.Sprintf("Claude key: %s (%s)", auth.MaskAPIKey(key), source)
+}
+
+// handleAuthShow reports the stored Claude API key masked, with r revealing
+// it briefly so it can be checked: /auth show
+func handleAuthShow(p *Prompt) (*Prompt, tea.Cmd) {
+	p.textInput.SetValue("")
+	key, source, err := auth.ResolveAPIKey()
+	if err != nil || key == "" {
+		p.StatusBar = "No Claude API key configured, run /auth"
+		return p, nil
+	}
+	p.keyRevealSeq++
+	p.keyReveal = &keyReveal{key: key, source: source, seq: p.keyRevealSeq}
+	p.StatusBar = p.keyReveal.status()
+	return p, nil
+}
+
+// status renders the key line, with the full key only while revealed
+func (r *keyReveal) status() string {
+	if r.revealed {
+		return fmt.Sprintf("Claude key: %s (%s) · hidden again in %s", r.key, r.source, keyRevealDuration)
+	}
+	return fmt.Sprintf("Claude key: %s (%s) · r reveal for %s", auth.MaskAPIKey(r.key), r.source, keyRevealDuration)
+}
+
+// updateKeyReveal reveals the key on r. Any other key dismisses the key line
+// and is handled as usual, reported by handled being false.
+func (p *Prompt) updateKeyReveal(msg tea.KeyMsg) (cmd tea.Cmd, handled bool) {
+	reveal := p.keyReveal
+	if msg.String() == "r" && !reveal.revealed {
+		reveal.revealed = true
+		p.StatusBar = reveal.status()
+		seq := reveal.seq
+		return tea.Tick(keyRevealDuration, func(time.Time) tea.Msg {
+			return keyRevealEndedMsg{seq: seq}
+		}), true
+	}
+	p.keyReveal = nil
+	p.StatusBar = ""
+	return nil, false
+}
+
+// updateKeyRevealEnded masks the key once its reveal times out
+func (p *Prompt) updateKeyRevealEnded(msg tea.Msg) (tea.Cmd, bool) {
+	ended, ok := msg.(keyRevealEndedMsg)
+	if !ok {
+		return nil, false
+	}
+	if p.keyReveal != nil && p.keyReveal.seq == ended.seq {
+		p.keyReveal.revealed = false
+		p.StatusBar = p.keyReveal.status()
+	}
+	return nil, true
+}
+
+// updateAuthResult reports /auth once its dialog has closed: the key being
+// saved, then what the API made of it
+func (p *Prompt) updateAuthResult(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case config.AuthResultMsg:
+		p.StatusBar = msg.Message
+		p.authCommand = nil
+		return nil, true
+	case config.AuthVerifiedMsg:
+		switch {
+		case msg.Err == nil:
+			p.StatusBar = "Claude API key verified with " + msg.BaseURL
+		case errors.Is(msg.Err, auth.ErrInvalidAPIKey):
+			p.StatusBar = "Claude API key saved, but " + msg.BaseURL + " rejected it. Run /auth to replace it."
+		default:
+			p.StatusBar = "Claude API key saved, but couldn't be verified: " + msg.Err.Error()
+		}
+		return nil, true
+	}
+	return nil, false
+}
@@ -0,0 +1,63 @@
+package components
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"tddpro/internal/auth"
+	"tddpro/internal/components/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAuthShow_MasksKeyUntilRevealed(t *testing.T) {
+	p := newDemoPrompt(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("ANTHROPIC_API_KEY", "")
+
+	handleAuth(p, "show")
+	if p.keyReveal != nil || p.StatusBar != "No Claude API key configured, run /auth" {
+		t.Fatalf("Expected no key to show, got %q", p.StatusBar)
+	}
+
+	const key = "sk-ant-REDACTED"
+	t.Setenv("ANTHROPIC_API_KEY", key)
+	handleAuth(p, "show")
+	if strings.Contains(p.StatusBar, key) || !strings.Contains(p.StatusBar, "sk-ant-...wxyz (ANTHROPIC_API_KEY)") {
+		t.Fatalf("Expected the key masked, got %q", p.StatusBar)
+	}
+
+	// r reveals the key until the reveal times out
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if cmd == nil || !strings.Contains(p.StatusBar, key) || p.textInput.Value() != "" {
+		t.Fatalf("Expected r to reveal the key without typing, got %q", p.StatusBar)
+	}
+	p.Update(keyRevealEndedMsg{seq: p.keyReveal.seq})
+	if strings.Contains(p.StatusBar, key) || !strings.Contains(p.StatusBar, "sk-ant-...wxyz") {
+		t.Fatalf("Expected the key masked again after the reveal, got %q", p.StatusBar)
+	}
+
+	// Any other key dismisses the key line and is typed as usual
+	p.FeaturesViewActive = false
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	if p.keyReveal != nil || strings.Contains(p.StatusBar, "Claude key") || p.textInput.Value() != "h" {
+		t.Errorf("Expected another key to dismiss the key line, got status %q, input %q", p.StatusBar, p.textInput.Value())
+	}
+}
+
+func TestAuthResult_ReportedAfterTheDialogCloses(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.Update(config.AuthResultMsg{Success: true, Message: "Claude API key saved successfully!"})
+	if p.StatusBar != "Claude API key saved successfully!" {
+		t.Errorf("Expected the save reported, got %q", p.StatusBar)
+	}
+	p.Update(config.AuthVerifiedMsg{BaseURL: "https://gateway.example.com", Err: auth.ErrInvalidAPIKey})
+	if !strings.Contains(p.StatusBar, "saved, but https://gateway.example.com rejected it") {
+		t.Errorf("Expected the rejection reported, got %q", p.StatusBar)
+	}
+	p.Update(config.AuthVerifiedMsg{BaseURL: "https://gateway.example.com", Err: errors.New("offline")})
+	if p.StatusBar != "Claude API key saved, but couldn't be verified: offline" {
+		t.Errorf("Expected the failed check reported, got %q", p.StatusBar)
+	}
+}
@@ -0,0 +1,134 @@
+package components
+
+import (
+	"log/slog"
+	"time"
+
+	"tddpro/internal/mcpclient"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// featuresRefreshTickMsg fires every features refresh interval
+type featuresRefreshTickMsg time.Time
+
+// featuresRefreshedMsg carries a features listing fetched in the background
+type featuresRefreshedMsg struct {
+	data    *mcpclient.FeaturesData
+	details map[string]*mcpclient.FeatureDetail
+	err     error
+}
+
+// SetFeaturesRefresh re-fetches the features view every d so changes made by
+// agents outside the TUI show up. Zero disables it.
+func (p *Prompt) SetFeaturesRefresh(d time.Duration) {
+	p.featuresRefresh = d
+}
+
+// scheduleFeaturesRefresh returns the next refresh tick, or nil when auto-refresh is off
+func (p *Prompt) scheduleFeaturesRefresh() tea.Cmd {
+	if p.featuresRefresh <= 0 {
+		return nil
+	}
+	return tea.Tick(p.featuresRefresh, func(t time.Time) tea.Msg {
+		return featuresRefreshTickMsg(t)
+	})
+}
+
+// editingFeatures reports whether an edit is in progress that a refresh
+// would clobber: a task, PRD or feature field edit, or an open form or dialog
+func (p *Prompt) editingFeatures() bool {
+	return p.editingTask || p.editingPRD || p.focusState == 1 || p.featureForm != nil ||
+		p.statusEdit != "" || p.deps != nil || p.pendingImport != nil
+}
+
+// updateFeaturesRefresh fetches features on each tick while the view is open
+// and nothing is being edited, then swaps in the result keeping the selection
+func (p *Prompt) updateFeaturesRefresh(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case featuresRefreshTickMsg:
+		next := p.scheduleFeaturesRefresh()
+		// Edits skip this tick; the next one after leaving edit mode refreshes
+		if !p.FeaturesViewActive || p.MCP == nil || p.featuresRefreshing || p.featuresLoading || p.editingFeatures() {
+			return next, true
+		}
+		p.featuresRefreshing = true
+		return tea.Batch(next, fetchFeatures(p.MCP)), true
+	case featuresRefreshedMsg:
+		p.featuresRefreshing = false
+		if msg.err != nil {
+			slog.Debug("features auto-refresh failed", "err", msg.err)
+			return nil, true
+		}
+		// An edit may have started while the fetch was running
+		if p.FeaturesViewActive && !p.editingFeatures() {
+			p.applyFeaturesRefresh(msg.data, msg.details)
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+// fetchFeatures lists features and their details off the event loop
+func fetchFeatures(client mcpclient.Client) tea.Cmd {
+	return func() tea.Msg {
+		data, details, err := listFeatures(client)
+		return featuresRefreshedMsg{data: data, details: details, err: err}
+	}
+}
+
+// listFeatures lists features and batch fetches their details. It blocks on
+// the MCP server, so it only runs inside commands.
+func listFeatures(client mcpclient.Client) (*mcpclient.FeaturesData, map[string]*mcpclient.FeatureDetail, error) {
+	data, err := client.ListFeaturesViaStdio()
+	if err != nil || data == nil {
+		return data, nil, err
+	}
+	var details map[string]*mcpclient.FeatureDetail
+	if ids := featureIDs(data); len(ids) > 0 {
+		// Without details tasks are fetched again as features are visited
+		if details, err = client.GetFeaturesViaStdio(ids); err != nil {
+			slog.Debug("feature details prefetch failed", "err", err)
+		}
+	}
+	return data, details, nil
+}
+
+// applyFeaturesRefresh replaces the listed features, keeping the selected
+// feature, task and scroll position where they still exist
+func (p *Prompt) applyFeaturesRefresh(data *mcpclient.FeaturesData, details map[string]*mcpclient.FeatureDetail) {
+	selectedID := ""
+	if p.SelectedFeature != nil {
+		selectedID = p.SelectedFeature.ID
+	}
+	p.FeaturesData = *data
+	p.featuresErr = nil
+
+	p.SelectedFeature = nil
+	visible := p.visibleFeatures()
+	for i := range visible {
+		if visible[i].ID == selectedID {
+			p.SelectedFeature = &visible[i]
+			break
+		}
+	}
+	if p.SelectedFeature == nil && len(visible) > 0 {
+		p.SelectedFeature = &visible[0]
+	}
+	for id := range p.marked {
+		if _, ok := p.FeaturesData.FindFeature(id); !ok {
+			delete(p.marked, id)
+		}
+	}
+
+	p.featureDetails.invalidate("")
+	for id, detail := range details {
+		detail.ID = id
+		p.featureDetails.put(detail)
+	}
+	if p.SelectedFeature != nil {
+		if detail, ok := p.featureDetails.peek(p.SelectedFeature.ID); ok && p.selectedTaskIndex >= len(detail.Tasks) {
+			p.selectedTaskIndex = max(len(detail.Tasks)-1, 0)
+		}
+	}
+}
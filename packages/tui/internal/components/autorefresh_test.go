@@ -0,0 +1,71 @@
+package components
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tddpro/internal/mcpclient"
+)
+
+func TestFeaturesAutoRefresh_KeepsSelectionAndPausesWhileEditing(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.SetFeaturesRefresh(time.Millisecond)
+	search, _ := p.FeaturesData.FindFeature("search")
+	p.SelectedFeature = search
+	p.sidebarScroll = 1
+	runMessageCmds(p, p.loadVisiblePRD())
+
+	// tick runs one refresh cycle, returning whether a fetch was started
+	tick := func() bool {
+		cmd := p.scheduleFeaturesRefresh()
+		if cmd == nil {
+			t.Fatal("Expected auto-refresh to be scheduled")
+		}
+		_, cmd = p.Update(cmd())
+		batch, ok := cmd().(tea.BatchMsg)
+		if !ok {
+			return false
+		}
+		for _, c := range batch {
+			if msg := c(); msg != nil {
+				if _, isTick := msg.(featuresRefreshTickMsg); !isTick {
+					p.Update(msg)
+				}
+			}
+		}
+		return true
+	}
+
+	// An agent adds a feature outside the TUI
+	if err := p.MCP.CreateFeatureViaStdio(mcpclient.Feature{ID: "audit-log", Name: "Audit Log"}); err != nil {
+		t.Fatal(err)
+	}
+	p.focusState = 1 // the feature fields are editable here
+	if tick() {
+		t.Fatal("Expected no refresh while a feature is being edited")
+	}
+	if _, ok := p.FeaturesData.FindFeature("audit-log"); ok {
+		t.Fatal("Expected the features to be left alone while editing")
+	}
+
+	p.focusState = 0
+	if !tick() {
+		t.Fatal("Expected a refresh once editing stopped")
+	}
+	if _, ok := p.FeaturesData.FindFeature("audit-log"); !ok {
+		t.Error("Expected the new feature after the refresh")
+	}
+	if p.SelectedFeature == nil || p.SelectedFeature.ID != "search" {
+		t.Errorf("Expected the selection kept, got %+v", p.SelectedFeature)
+	}
+	if p.sidebarScroll != 1 {
+		t.Errorf("Expected the scroll position kept, got %d", p.sidebarScroll)
+	}
+
+	p.SetFeaturesRefresh(0)
+	if p.scheduleFeaturesRefresh() != nil {
+		t.Error("Expected auto-refresh off when the interval is zero")
+	}
+}
@@ -0,0 +1,134 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tddpro/internal/util"
+)
+
+// featureMove is one status change of a batch
+type featureMove struct {
+	featureID string
+	status    string
+}
+
+// batchStatusSavedMsg reports a batch status change: the moves that were
+// saved and the first error, if any
+type batchStatusSavedMsg struct {
+	moved  []featureMove
+	failed int
+	err    error
+}
+
+// toggleFeatureMark marks or unmarks the selected feature for a batch status change
+func (p *Prompt) toggleFeatureMark() {
+	if p.SelectedFeature == nil || p.blockedByReadOnly() {
+		return
+	}
+	if p.marked == nil {
+		p.marked = map[string]bool{}
+	}
+	id := p.SelectedFeature.ID
+	if p.marked[id] {
+		delete(p.marked, id)
+	} else {
+		p.marked[id] = true
+	}
+	if len(p.marked) == 0 {
+		p.StatusBar = ""
+		return
+	}
+	p.StatusBar = fmt.Sprintf("%d marked: shift+right moves them forward, shift+left back, esc clears", len(p.marked))
+}
+
+// clearFeatureMarks drops the batch selection, reporting whether there was one
+func (p *Prompt) clearFeatureMarks() bool {
+	if len(p.marked) == 0 {
+		return false
+	}
+	p.marked = nil
+	p.StatusBar = "Selection cleared"
+	return true
+}
+
+// moveMarkedFeatures moves every marked feature one status forward (delta
+// -1, towards approved) or back (delta 1, towards backlog). Features already
+// at the end of the workflow stay where they are.
+func (p *Prompt) moveMarkedFeatures(delta int) tea.Cmd {
+	if p.blockedByReadOnly() {
+		return nil
+	}
+	if len(p.marked) == 0 {
+		p.StatusBar = "Mark features with v first"
+		return nil
+	}
+	if p.MCP == nil {
+		p.StatusBar = "MCP client not available"
+		return nil
+	}
+	var moves []featureMove
+	for id := range p.marked {
+		current := p.featureStatusRank(id)
+		next := current + delta
+		if current < 0 || next < 0 || next >= len(util.FeatureStatuses) {
+			continue
+		}
+		moves = append(moves, featureMove{featureID: id, status: util.FeatureStatuses[next]})
+	}
+	p.marked = nil
+	if len(moves) == 0 {
+		p.StatusBar = "Marked features can't move further"
+		return nil
+	}
+	sort.Slice(moves, func(i, j int) bool { return moves[i].featureID < moves[j].featureID })
+
+	client := p.MCP
+	p.StatusBar = fmt.Sprintf("Moving %d features...", len(moves))
+	return func() tea.Msg {
+		var saved batchStatusSavedMsg
+		for _, move := range moves {
+			if err := client.UpdateFeatureStatusViaStdio(move.featureID, move.status); err != nil {
+				saved.failed++
+				if saved.err == nil {
+					saved.err = fmt.Errorf("%s: %w", move.featureID, err)
+				}
+				continue
+			}
+			saved.moved = append(saved.moved, move)
+		}
+		return saved
+	}
+}
+
+// updateBatchStatus moves the saved features into their new sidebar groups
+func (p *Prompt) updateBatchStatus(msg tea.Msg) (tea.Cmd, bool) {
+	saved, ok := msg.(batchStatusSavedMsg)
+	if !ok {
+		return nil, false
+	}
+	p.StatusBar = ""
+	for _, move := range saved.moved {
+		if p.statusEditID == move.featureID {
+			p.statusEditID = "" // the status selector starts over from the new status
+		}
+		selected := p.SelectedFeature != nil && p.SelectedFeature.ID == move.featureID
+		if moved, ok := p.FeaturesData.SetFeatureStatus(move.featureID, move.status); ok && selected {
+			p.SelectedFeature = moved
+		}
+	}
+	// The selected feature may have left the filtered group
+	if p.featureStatusFilter != "" && p.SelectedFeature != nil && p.SelectedFeature.Status != p.featureStatusFilter {
+		p.featureStatusFilter = ""
+	}
+	noun := "features"
+	if len(saved.moved) == 1 {
+		noun = "feature"
+	}
+	if saved.err != nil {
+		return p.showToast(toastError, fmt.Sprintf("Moved %d %s, %d failed: %s", len(saved.moved), noun, saved.failed, errorText(saved.err))), true
+	}
+	return p.showToast(toastSuccess, fmt.Sprintf("Moved %d %s", len(saved.moved), noun)), true
+}
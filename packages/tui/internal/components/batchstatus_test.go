@@ -0,0 +1,48 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBatchStatus_MovesMarkedFeatures(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.WindowWidth, p.WindowHeight = 120, 40
+	mark := func(id string) {
+		p.SelectedFeature, _ = p.FeaturesData.FindFeature(id)
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	}
+
+	mark("search")
+	mark("dark-mode")
+	if len(p.marked) != 2 || !strings.Contains(p.generateSidebarContent(), "✓") {
+		t.Fatalf("Expected two marked features with checks, got %v", p.marked)
+	}
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyShiftRight})
+	if cmd == nil {
+		t.Fatalf("Expected a batch command, status %q", p.StatusBar)
+	}
+	_, toastCmd := p.Update(cmd())
+	if toastCmd == nil || p.toast == nil || p.toast.text != "Moved 2 features" {
+		t.Errorf("Expected a count of moved features, got %+v", p.toast)
+	}
+	if search, _ := p.FeaturesData.FindFeature("search"); search.Status != "approved" {
+		t.Errorf("Expected search to move to approved, got %q", search.Status)
+	}
+	if darkMode, _ := p.FeaturesData.FindFeature("dark-mode"); darkMode.Status != "refinement" {
+		t.Errorf("Expected dark-mode to move to refinement, got %q", darkMode.Status)
+	}
+	if len(p.marked) != 0 {
+		t.Errorf("Expected the selection to be cleared, got %v", p.marked)
+	}
+
+	// Esc clears a selection before it closes the view
+	mark("search")
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if len(p.marked) != 0 || !p.FeaturesViewActive {
+		t.Errorf("Expected esc to clear the marks only, marked %v", p.marked)
+	}
+}
@@ -1,94 +1,392 @@
 package components
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
 )
 
+// CommandPaletteItem is an action the palette can run: a slash command or a
+// key binding of the current view
 type CommandPaletteItem struct {
-	Title string
-	Desc  string
+	Title    string // the command or keys as shown, e.g. "/plan [dir]" or "z"
+	Desc     string
+	Context  string
+	Mutates  bool
+	command  string       // run through the command handlers, "" for key bindings
+	needsArg bool         // the command is inserted into the prompt to be finished
+	keys     []tea.KeyMsg // replayed for key bindings
 }
 
+// CommandPalette lists every action with its binding, narrows them as the
+// user types and hands the selected one back through Current
 type CommandPalette struct {
+	Active   bool
+	ReadOnly bool // grays out actions that edit the project
 	items    []CommandPaletteItem
-	filtered []CommandPaletteItem
+	filter   textinput.Model
 	selected int
-	query    string
-	show     bool
+	// The items drawn by the last View and the line of the first, so a click
+	// can be mapped to one
+	start, shown, firstLine int
 }
 
-func NewCommandPalette(commands []CommandPaletteItem) *CommandPalette {
-	return &CommandPalette{
-		items:    commands,
-		filtered: commands,
-		selected: 0,
-		show:     false,
+// Open shows the palette with the given items and an empty filter
+func (cp *CommandPalette) Open(items []CommandPaletteItem) {
+	cp.filter = textinput.New()
+	cp.filter.Placeholder = "Type to filter (e.g. doctor, compact)"
+	cp.filter.Prompt = "> "
+	cp.filter.Focus()
+	cp.items = items
+	cp.selected = 0
+	cp.Active = true
+}
+
+// Close hides the palette
+func (cp *CommandPalette) Close() {
+	cp.Active = false
+	cp.filter.Blur()
+}
+
+// Filtered returns the items matching the filter, best matches first
+func (cp *CommandPalette) Filtered() []CommandPaletteItem {
+	query := strings.TrimSpace(cp.filter.Value())
+	if query == "" {
+		return cp.items
+	}
+	matches := fuzzy.FindFrom(query, paletteSource(cp.items))
+	result := make([]CommandPaletteItem, len(matches))
+	for i, match := range matches {
+		result[i] = cp.items[match.Index]
 	}
+	return result
 }
 
-func (cp *CommandPalette) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "up":
-			if cp.selected > 0 {
-				cp.selected--
-			}
-		case "down":
-			if cp.selected < len(cp.filtered)-1 {
-				cp.selected++
-			}
-		case "esc":
-			cp.show = false
-		case "enter":
-			cp.show = false
-			// Selection handled by parent
+// paletteSource adapts palette items for fuzzy matching
+type paletteSource []CommandPaletteItem
+
+func (s paletteSource) String(i int) string { return s[i].Title + " " + s[i].Desc }
+func (s paletteSource) Len() int            { return len(s) }
+
+// Current returns the selected item, nil when nothing matches the filter
+func (cp *CommandPalette) Current() *CommandPaletteItem {
+	filtered := cp.Filtered()
+	if len(filtered) == 0 {
+		return nil
+	}
+	if cp.selected >= len(filtered) {
+		cp.selected = len(filtered) - 1
+	}
+	return &filtered[cp.selected]
+}
+
+// Update handles keys while the palette is open: esc closes, up/down select,
+// everything else edits the filter. Enter is left to the caller.
+func (cp *CommandPalette) Update(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "ctrl+p":
+		cp.Close()
+		return nil
+	case "up":
+		if cp.selected > 0 {
+			cp.selected--
+		}
+		return nil
+	case "down":
+		if cp.selected < len(cp.Filtered())-1 {
+			cp.selected++
+		}
+		return nil
+	}
+	var cmd tea.Cmd
+	cp.filter, cmd = cp.filter.Update(msg)
+	cp.selected = 0
+	return cmd
+}
+
+// ItemAt returns the index into Filtered of the item drawn on the given line
+// of the last View, or -1 when no item is there
+func (cp *CommandPalette) ItemAt(line int) int {
+	row := line - cp.firstLine
+	if row < 0 || row >= cp.shown {
+		return -1
+	}
+	return cp.start + row
+}
+
+// UpdateMouse scrolls the selection with the wheel. A left click on an item
+// selects it and reports true so the caller can run it.
+func (cp *CommandPalette) UpdateMouse(msg tea.MouseMsg, line int) bool {
+	switch {
+	case msg.Button == tea.MouseButtonWheelUp:
+		cp.Update(tea.KeyMsg{Type: tea.KeyUp})
+	case msg.Button == tea.MouseButtonWheelDown:
+		cp.Update(tea.KeyMsg{Type: tea.KeyDown})
+	case msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress:
+		if i := cp.ItemAt(line); i >= 0 {
+			cp.selected = i
+			return true
 		}
-		// Filtering on query (if you want to add fuzzy search)
 	}
-	return cp, nil
+	return false
 }
 
-func (cp *CommandPalette) View() string {
-	if !cp.show || len(cp.filtered) == 0 {
-		return ""
+// View renders the filter and matching items within maxLines, keeping the
+// selection in view
+func (cp *CommandPalette) View(width, maxLines int, theme Theme) string {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Focus)).Bold(true)
+	contextStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Muted)).Width(14)
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Text)).Bold(true).Width(24)
+	descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Value))
+	disabledStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Border))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Muted)).Italic(true)
+
+	filtered := cp.Filtered()
+	lines := []string{titleStyle.Render("Command Palette"), cp.filter.View(), ""}
+	if len(filtered) == 0 {
+		lines = append(lines, hintStyle.Render("No matching actions"))
+	}
+
+	visible := maxLines - 5 // title, filter, spacing and hint
+	if visible < 3 {
+		visible = 3
 	}
-	style := lipgloss.NewStyle().Background(lipgloss.Color("236")).Foreground(lipgloss.Color("15")).Padding(1, 2)
-	selectedStyle := style.Copy().Foreground(lipgloss.Color("205")).Bold(true)
-	rows := ""
-	for i, item := range cp.filtered {
-		row := item.Title + "\t" + item.Desc
-		if i == cp.selected {
-			rows += selectedStyle.Render(row) + "\n"
+	start := 0
+	if cp.selected >= visible {
+		start = cp.selected - visible + 1
+	}
+	end := start + visible
+	if end > len(filtered) {
+		end = len(filtered)
+	}
+	cp.start, cp.shown = start, end-start
+	// Items follow the top border and the lines above them
+	cp.firstLine = 1 + lipgloss.Height(strings.Join(lines, "\n"))
+	// One line per item, so long descriptions are cut rather than wrapped
+	rowStyle := lipgloss.NewStyle().MaxWidth(width - 4)
+	for i, item := range filtered[start:end] {
+		marker := "  "
+		if start+i == cp.selected {
+			marker = titleStyle.Render("▸ ")
+		}
+		// Each context is named once, on the first of its items in view
+		context := ""
+		if i == 0 || filtered[start+i-1].Context != item.Context {
+			context = item.Context
+		}
+		row := marker + contextStyle.Render(context)
+		if cp.ReadOnly && item.Mutates {
+			row += disabledStyle.Width(24).Render(item.Title) + disabledStyle.Render(item.Desc+" (read-only)")
 		} else {
-			rows += style.Render(row) + "\n"
+			row += keyStyle.Render(item.Title) + descStyle.Render(item.Desc)
 		}
+		lines = append(lines, rowStyle.Render(row))
 	}
-	return rows
+
+	lines = append(lines, "", hintStyle.Render(fmt.Sprintf("%d of %d actions · ↑↓ select · enter run · esc close", len(filtered), len(cp.items))))
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(theme.Focus)).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
 }
 
-func (cp *CommandPalette) Current() *CommandPaletteItem {
-	if len(cp.filtered) == 0 {
-		return nil
+// paletteKeyTypes maps the named keys used in KeyMap to the keys they replay
+var paletteKeyTypes = map[string]tea.KeyType{
+	"enter":       tea.KeyEnter,
+	"esc":         tea.KeyEsc,
+	"tab":         tea.KeyTab,
+	"up":          tea.KeyUp,
+	"down":        tea.KeyDown,
+	"left":        tea.KeyLeft,
+	"right":       tea.KeyRight,
+	"pgup":        tea.KeyPgUp,
+	"pgdown":      tea.KeyPgDown,
+	"delete":      tea.KeyDelete,
+	"shift+left":  tea.KeyShiftLeft,
+	"shift+right": tea.KeyShiftRight,
+}
+
+// paletteKeys turns a KeyMap key label such as "z", "ctrl+r" or "y i" into the
+// keys to replay. ok is false for labels that can't be replayed, like
+// "<number> g".
+func paletteKeys(label string) ([]tea.KeyMsg, bool) {
+	var keys []tea.KeyMsg
+	for _, name := range strings.Fields(label) {
+		var key tea.KeyMsg
+		switch keyType, named := paletteKeyTypes[name]; {
+		case named:
+			key = tea.KeyMsg{Type: keyType}
+		case len(name) == len("ctrl+x") && strings.HasPrefix(name, "ctrl+") && name[5] >= 'a' && name[5] <= 'z':
+			key = tea.KeyMsg{Type: tea.KeyCtrlA + tea.KeyType(name[5]-'a')}
+		case len([]rune(name)) == 1:
+			key = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name)}
+		default:
+			return nil, false
+		}
+		// Anything that doesn't round-trip would replay the wrong key
+		if key.String() != name {
+			return nil, false
+		}
+		keys = append(keys, key)
 	}
-	return &cp.filtered[cp.selected]
+	return keys, len(keys) > 0
 }
 
-func (cp *CommandPalette) Show() {
-	cp.show = true
-	cp.selected = 0
-	cp.filtered = cp.items
+// paletteContexts returns the KeyMap contexts whose keys work in the current
+// view
+func (p *Prompt) paletteContexts() []string {
+	if !p.FeaturesViewActive {
+		return []string{"Prompt"}
+	}
+	switch p.focusState {
+	case 1:
+		return []string{"Features", "Feature Data"}
+	case 2:
+		return []string{"Features", "Tasks"}
+	}
+	return []string{"Features"}
+}
+
+// paletteItems lists every command handler, described by its KeyMap entries,
+// then the key bindings of the current view that can be replayed
+func (p *Prompt) paletteItems() []CommandPaletteItem {
+	var items []CommandPaletteItem
+	described := map[string]bool{}
+	for _, b := range KeyMap {
+		if b.Context != "Commands" {
+			continue
+		}
+		// Optional arguments are left off; required ones are typed by the user
+		var words []string
+		for _, word := range strings.Fields(b.Keys[0]) {
+			if strings.HasPrefix(word, "<") || strings.HasPrefix(word, "[") {
+				break
+			}
+			words = append(words, word)
+		}
+		needsArg := strings.Contains(b.Keys[0], " <")
+		if _, ok := commandHandlers[words[0]]; !ok || debugCommands[words[0]] && !p.debugTools {
+			continue
+		}
+		described[words[0]] = true
+		items = append(items, CommandPaletteItem{
+			Title:    b.Keys[0],
+			Desc:     b.Description,
+			Context:  b.Context,
+			Mutates:  b.Mutates,
+			command:  strings.Join(words, " "),
+			needsArg: needsArg,
+		})
+	}
+	// A handler missing from KeyMap is still listed, just without a description
+	var undescribed []string
+	for name := range commandHandlers {
+		if !described[name] && (!debugCommands[name] || p.debugTools) {
+			undescribed = append(undescribed, name)
+		}
+	}
+	sort.Strings(undescribed)
+	for _, name := range undescribed {
+		items = append(items, CommandPaletteItem{Title: name, Context: "Commands", command: name})
+	}
+
+	for _, context := range p.paletteContexts() {
+		for _, b := range KeyMap {
+			if b.Context != context {
+				continue
+			}
+			for _, label := range b.Keys {
+				keys, ok := paletteKeys(label)
+				// ctrl+p would only reopen the palette, except in the
+				// feature data where it opens the pager
+				if !ok || label == "ctrl+p" && b.Context != "Feature Data" {
+					continue
+				}
+				items = append(items, CommandPaletteItem{
+					Title:   b.KeysLabel(),
+					Desc:    b.Description,
+					Context: b.Context,
+					Mutates: b.Mutates,
+					keys:    keys,
+				})
+				break
+			}
+		}
+	}
+	return items
+}
+
+// openPalette shows the command palette for the current view
+func (p *Prompt) openPalette() tea.Cmd {
+	p.palette.ReadOnly = p.readOnly
+	p.palette.Open(p.paletteItems())
+	return textinput.Blink
 }
 
-func (cp *CommandPalette) Hide() {
-	cp.show = false
+// updatePaletteMouse scrolls the palette with the wheel and runs the item
+// clicked on
+func (p *Prompt) updatePaletteMouse(msg tea.MouseMsg) (*Prompt, tea.Cmd) {
+	// The palette is drawn below the one-line header
+	if !p.palette.UpdateMouse(msg, msg.Y-1) {
+		return p, nil
+	}
+	item := p.palette.Current()
+	p.palette.Close()
+	if item == nil {
+		return p, nil
+	}
+	return p.runPaletteItem(*item)
 }
 
-func (cp *CommandPalette) IsShown() bool {
-	return cp.show
+// updatePalette handles keys while the palette is open; enter closes it and
+// runs the selected action
+func (p *Prompt) updatePalette(msg tea.KeyMsg) (*Prompt, tea.Cmd) {
+	if msg.String() != "enter" {
+		return p, p.palette.Update(msg)
+	}
+	item := p.palette.Current()
+	p.palette.Close()
+	if item == nil {
+		return p, nil
+	}
+	return p.runPaletteItem(*item)
 }
 
-func (cp *CommandPalette) Init() tea.Cmd {
-	return nil
+// runPaletteItem runs a slash command through its handler, or replays a key
+// binding as if it had been pressed. Commands that need an argument are put
+// in the prompt for the user to finish.
+func (p *Prompt) runPaletteItem(item CommandPaletteItem) (*Prompt, tea.Cmd) {
+	if item.command == "" {
+		var cmds []tea.Cmd
+		for _, key := range item.keys {
+			var cmd tea.Cmd
+			p, cmd = p.Update(key)
+			cmds = append(cmds, cmd)
+		}
+		return p, tea.Batch(cmds...)
+	}
+
+	if item.needsArg {
+		p.FeaturesViewActive = false
+		p.focusState = 0
+		p.textInput.SetValue(item.command + " ")
+		p.textInput.CursorEnd()
+		p.StatusBar = "Finish " + item.Title + " and press enter"
+		return p, nil
+	}
+	cmd, arg := parseCommand(item.command)
+	handler, ok := commandHandlers[cmd]
+	if !ok {
+		return p, nil
+	}
+	p.history.Add(item.command)
+	p.recordCommand(cmd)
+	return handler(p, arg)
 }
@@ -0,0 +1,121 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCommandPalette_RunsCommandsAndKeys(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.WindowWidth, p.WindowHeight = 120, 40
+	p.FeaturesTab, p.focusState = 1, 2
+	send := func(msg tea.KeyMsg) tea.Cmd {
+		_, cmd := p.Update(msg)
+		return cmd
+	}
+	open := func(query string) *CommandPaletteItem {
+		send(tea.KeyMsg{Type: tea.KeyCtrlP})
+		if !p.palette.Active {
+			t.Fatal("Expected ctrl+p to open the palette")
+		}
+		send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(query)})
+		return p.palette.Current()
+	}
+
+	if item := open(""); item == nil || item.Title != "/help" {
+		t.Errorf("Expected the commands first, got %+v", item)
+	}
+	if view := p.View(); !strings.Contains(view, "Command Palette") || !strings.Contains(view, "/doctor") {
+		t.Errorf("Expected the commands listed, got %q", view)
+	}
+	send(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.palette.Active || !p.FeaturesViewActive || p.focusState != 2 {
+		t.Fatal("Expected esc to close only the palette")
+	}
+
+	// Key bindings are replayed as if pressed
+	if item := open("compact"); item == nil || item.Title != "z" {
+		t.Fatalf("Expected the compact tasks key, got %+v", item)
+	}
+	if view := p.View(); !strings.Contains(view, "z                       Toggle compact tasks") {
+		t.Errorf("Expected the Tasks key with its binding, got %q", view)
+	}
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.palette.Active || !p.compactTasks {
+		t.Error("Expected enter to close the palette and toggle compact tasks")
+	}
+
+	// Commands needing an argument are left in the prompt to finish
+	if item := open("/export"); item == nil || item.Title != "/export <id> [path] [--force]" {
+		t.Fatalf("Expected /export, got %+v", item)
+	}
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.FeaturesViewActive || p.textInput.Value() != "/export " {
+		t.Errorf("Expected /export in the prompt, got %q", p.textInput.Value())
+	}
+
+	// Everything else runs through its command handler, quitting included
+	if item := open("/quit"); item == nil || item.Title != "/quit" {
+		t.Fatalf("Expected /quit, got %+v", item)
+	}
+	cmd := send(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected /quit to quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("Expected /quit from the palette to quit to the shell")
+	}
+
+	// The feature data keeps ctrl+p for the PRD pager
+	p.FeaturesViewActive, p.FeaturesTab, p.focusState = true, 0, 1
+	send(tea.KeyMsg{Type: tea.KeyCtrlP})
+	if p.palette.Active {
+		t.Error("Expected ctrl+p in the feature data to open the pager, not the palette")
+	}
+}
+
+func TestCommandPalette_ListsEveryHandlerAndTakesClicks(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.WindowWidth, p.WindowHeight = 120, 40
+	p.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+
+	listed := map[string]bool{}
+	for _, item := range p.palette.Filtered() {
+		listed[item.command] = true
+	}
+	for name := range commandHandlers {
+		if !listed[name] && !debugCommands[name] {
+			t.Errorf("Expected %s in the palette", name)
+		}
+		if listed[name] && debugCommands[name] {
+			t.Errorf("Expected %s left out without debug tools", name)
+		}
+	}
+	p.SetDebugTools(true)
+	withDebug := map[string]bool{}
+	for _, item := range p.paletteItems() {
+		withDebug[item.command] = true
+	}
+	for name := range debugCommands {
+		if !withDebug[name] {
+			t.Errorf("Expected %s in the palette with debug tools", name)
+		}
+	}
+
+	// The first item sits below the header, border, title, filter and a blank line
+	p.View()
+	p.Update(tea.MouseMsg{Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+	if item := p.palette.Current(); item == nil || item.Title != "/features" {
+		t.Errorf("Expected the wheel to move the selection, got %+v", item)
+	}
+	p.Update(tea.MouseMsg{X: 10, Y: 2, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if !p.palette.Active {
+		t.Fatal("Expected a click outside the items to keep the palette open")
+	}
+	p.Update(tea.MouseMsg{X: 10, Y: 5, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if p.palette.Active || !p.help.Active {
+		t.Error("Expected clicking /help to close the palette and run it")
+	}
+}
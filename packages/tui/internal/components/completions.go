@@ -28,11 +28,12 @@ type CompletionProvider interface {
 
 // CommandCompletionProvider provides command completions
 type CommandCompletionProvider struct {
-	// We'll generate commands dynamically based on context
+	// Commands are generated dynamically based on context; usage orders them
+	usage *CommandUsage
 }
 
 func NewCommandCompletionProvider() *CommandCompletionProvider {
-	return &CommandCompletionProvider{}
+	return &CommandCompletionProvider{usage: LoadCommandUsage(DefaultCommandUsagePath())}
 }
 
 // getContextualCommands returns commands based on current context
@@ -49,12 +50,55 @@ func (c *CommandCompletionProvider) getContextualCommands() []CompletionItem {
 		Title: "/features", Description: "List all features from the MCP server", Value: "/features", IsCommand: true,
 	})
 
+	commands = append(commands, CompletionItem{
+		Title: "/plan", Description: "Run the planning workflow to generate features", Value: "/plan", IsCommand: true,
+	})
+
+	commands = append(commands, CompletionItem{
+		Title: "/export", Description: "Write a feature's PRD and tasks to a Markdown file", Value: "/export", IsCommand: true,
+	})
+
+	commands = append(commands, CompletionItem{
+		Title: "/save-transcript", Description: "Save this session's messages and replies to a Markdown file", Value: "/save-transcript", IsCommand: true,
+	})
+
+	commands = append(commands, CompletionItem{
+		Title: "/import", Description: "Import a Markdown file as a PRD (replaces the selected feature's, or --new)", Value: "/import", IsCommand: true,
+	})
+
+	commands = append(commands, CompletionItem{
+		Title: "/status", Description: "Show backend connection and MCP server version", Value: "/status", IsCommand: true,
+	})
+
+	commands = append(commands, CompletionItem{
+		Title: "/doctor", Description: "Check the MCP server, backend, credentials, project and config", Value: "/doctor", IsCommand: true,
+	})
+
+	commands = append(commands, CompletionItem{
+		Title: "/version-check", Description: "Check for a newer TDD-Pro release", Value: "/version-check", IsCommand: true,
+	})
+
+	commands = append(commands, CompletionItem{
+		Title: "/clear", Description: "Clear the conversation and status (/clear session also resets the session)", Value: "/clear", IsCommand: true,
+	})
+
+	commands = append(commands, CompletionItem{
+		Title: "/undo", Description: "Revert the last task or feature edit", Value: "/undo", IsCommand: true,
+	})
+
 	// Only show /init if no .tdd-pro directory exists in current or parent directories
 	cwd, err := os.Getwd()
 	if err == nil && !util.IsAlreadyInitialized(cwd) {
 		commands = append(commands, CompletionItem{
 			Title: "/init", Description: "Initialize TDD-Pro in current directory", Value: "/init", IsCommand: true,
 		})
+	} else if err == nil {
+		commands = append(commands, CompletionItem{
+			Title: "/mcp", Description: "Create or repair editor MCP config files", Value: "/mcp", IsCommand: true,
+		})
+		commands = append(commands, CompletionItem{
+			Title: "/mcp remove", Description: "Remove tdd-pro from editor MCP config files, keeping other servers", Value: "/mcp remove", IsCommand: true,
+		})
 	}
 
 	// Always show auth for configuring Claude API key
@@ -80,6 +124,7 @@ func (c *CommandCompletionProvider) GetCompletions(query string) ([]CompletionIt
 	commands := c.getContextualCommands()
 
 	if query == "" {
+		c.sortCommands(commands, nil)
 		return commands, nil
 	}
 
@@ -94,13 +139,32 @@ func (c *CommandCompletionProvider) GetCompletions(query string) ([]CompletionIt
 
 	// Convert matches back to CompletionItems
 	result := make([]CompletionItem, len(matches))
+	scores := make(map[string]int, len(matches))
 	for i, match := range matches {
 		result[i] = commands[match.Index]
+		scores[match.Str] = match.Score
+	}
+
+	c.sortCommands(result, scores)
+	return result, nil
+}
+
+// sortCommands orders commands by how often they've been run, falling back
+// to the listing order for an empty query, or to the preferred order (help,
+// features, clear, init, auth) and then fuzzy score for matches. With no usage
+// history the order is unchanged. quit is always last.
+func (c *CommandCompletionProvider) sortCommands(items []CompletionItem, scores map[string]int) {
+	// Define preferred order for the rest
+	orderMap := map[string]int{
+		"/help":     1,
+		"/features": 2,
+		"/clear":    3,
+		"/init":     4,
+		"/auth":     5,
 	}
 
-	// Custom sort to prioritize order: help, features, init (if present), quit (always last)
-	sort.Slice(result, func(i, j int) bool {
-		a, b := result[i].Title, result[j].Title
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i].Title, items[j].Title
 
 		// Always put quit last
 		if a == "/quit" {
@@ -110,12 +174,13 @@ func (c *CommandCompletionProvider) GetCompletions(query string) ([]CompletionIt
 			return true
 		}
 
-		// Define preferred order for the rest
-		orderMap := map[string]int{
-			"/help":     1,
-			"/features": 2,
-			"/init":     3,
-			"/auth":     4,
+		// Most-used commands float up
+		if countA, countB := c.usage.Count(a), c.usage.Count(b); countA != countB {
+			return countA > countB
+		}
+		// Without a query the listing order is already the preferred one
+		if scores == nil {
+			return false
 		}
 
 		orderA, okA := orderMap[a]
@@ -132,10 +197,13 @@ func (c *CommandCompletionProvider) GetCompletions(query string) ([]CompletionIt
 		}
 
 		// Fallback to fuzzy score for any other commands
-		return matches[i].Score > matches[j].Score
+		return scores[a] > scores[b]
 	})
+}
 
-	return result, nil
+// RecordCommand counts a run of cmd towards completion ordering
+func (c *CommandCompletionProvider) RecordCommand(cmd string) error {
+	return c.usage.Record(cmd)
 }
 
 // CompletionManager manages different completion providers
@@ -150,11 +218,24 @@ func NewCompletionManager() *CompletionManager {
 
 	// Register providers
 	manager.providers["commands"] = NewCommandCompletionProvider()
+	manager.providers["paths"] = NewPathCompletionProvider()
 
 	return manager
 }
 
+// RecordCommand counts a run of cmd so completions can list it earlier
+func (m *CompletionManager) RecordCommand(cmd string) error {
+	if provider, ok := m.providers["commands"].(*CommandCompletionProvider); ok {
+		return provider.RecordCommand(cmd)
+	}
+	return nil
+}
+
 func (m *CompletionManager) GetProvider(input string) CompletionProvider {
+	// A directory-taking command followed by a space completes its path argument
+	if isDirArgument(input) {
+		return m.providers["paths"]
+	}
 	if strings.HasPrefix(input, "/") {
 		return m.providers["commands"]
 	}
@@ -171,6 +252,7 @@ type CompletionDialog struct {
 	provider CompletionProvider
 	width    int
 	height   int
+	theme    Theme
 }
 
 func NewCompletionDialog() *CompletionDialog {
@@ -180,13 +262,27 @@ func NewCompletionDialog() *CompletionDialog {
 		visible:  false,
 		width:    60,
 		height:   8,
+		theme:    DarkTheme,
 	}
 }
 
+// SetTheme sets the colors used to render the dialog
+func (d *CompletionDialog) SetTheme(theme Theme) {
+	d.theme = theme
+}
+
 func (d *CompletionDialog) SetProvider(provider CompletionProvider) {
 	d.provider = provider
 }
 
+// ProviderID returns the ID of the provider supplying the items, "" if none
+func (d *CompletionDialog) ProviderID() string {
+	if d.provider == nil {
+		return ""
+	}
+	return d.provider.GetID()
+}
+
 func (d *CompletionDialog) UpdateQuery(query string) tea.Cmd {
 	if d.provider == nil {
 		return nil
@@ -248,10 +344,14 @@ func (d *CompletionDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter", "tab":
 			d.Hide()
 			if len(d.items) > 0 && d.selected < len(d.items) {
-				// Return a message with the selected completion
+				// Return a message with the selected completion. Only enter
+				// runs a command; tab just fills it in.
+				item := d.items[d.selected]
+				execute := msg.String() == "enter"
 				return d, func() tea.Msg {
 					return CompletionSelectedMsg{
-						Item: d.items[d.selected],
+						Item:    item,
+						Execute: execute,
 					}
 				}
 			}
@@ -269,21 +369,21 @@ func (d *CompletionDialog) View() string {
 	// Bagels-style completion dialog
 	dialogStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
-		Background(lipgloss.Color("234")).
+		BorderForeground(lipgloss.Color(d.theme.Border)).
+		Background(lipgloss.Color(d.theme.DialogBg)).
 		Padding(1).
 		Width(d.width).
 		MaxHeight(d.height)
 
 	selectedStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("39")).
-		Foreground(lipgloss.Color("255")).
+		Background(lipgloss.Color(d.theme.Focus)).
+		Foreground(lipgloss.Color(d.theme.SelectedText)).
 		Bold(true).
 		Width(d.width - 4) // Account for padding and border
 
 	normalStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("234")).
-		Foreground(lipgloss.Color("248")).
+		Background(lipgloss.Color(d.theme.DialogBg)).
+		Foreground(lipgloss.Color(d.theme.Value)).
 		Width(d.width - 4)
 
 	var rows []string
@@ -310,7 +410,7 @@ func (d *CompletionDialog) View() string {
 		item := d.items[i]
 		text := item.Title
 		if item.Description != "" {
-			text += lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render(" - " + item.Description)
+			text += lipgloss.NewStyle().Foreground(lipgloss.Color(d.theme.Muted)).Render(" - " + item.Description)
 		}
 
 		if i == d.selected {
@@ -337,5 +437,32 @@ func (d *CompletionDialog) HasItems() bool {
 
 // CompletionSelectedMsg is sent when a completion is selected
 type CompletionSelectedMsg struct {
-	Item CompletionItem
+	Item    CompletionItem
+	Execute bool // run the command (enter) rather than insert it (tab)
+}
+
+// completeCommand extends a partially typed command as far as it is
+// unambiguous: to the full command when one matches, otherwise to the
+// longest prefix shared by all matches. Input is returned unchanged when
+// nothing matches or an argument has already been started.
+func completeCommand(input string, commands []string) string {
+	if !strings.HasPrefix(input, "/") || strings.Contains(input, " ") {
+		return input
+	}
+	var matches []string
+	for _, command := range commands {
+		if strings.HasPrefix(command, input) {
+			matches = append(matches, command)
+		}
+	}
+	if len(matches) == 0 {
+		return input
+	}
+	prefix := matches[0]
+	for _, match := range matches[1:] {
+		for !strings.HasPrefix(match, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
 }
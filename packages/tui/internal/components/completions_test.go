@@ -0,0 +1,213 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCompleteCommand(t *testing.T) {
+	commands := []string{"/auth", "/destroy", "/features", "/help", "/init", "/quit"}
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"/fe", "/features"},
+		{"/features", "/features"},
+		{"/", "/"},             // ambiguous, nothing in common beyond the slash
+		{"/x", "/x"},           // no match
+		{"/fe arg", "/fe arg"}, // argument already started
+		{"hello", "hello"},     // not a command
+	}
+	for _, tt := range tests {
+		if got := completeCommand(tt.input, commands); got != tt.want {
+			t.Errorf("completeCommand(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	if got := completeCommand("/pl", []string{"/plan", "/planning"}); got != "/plan" {
+		t.Errorf("Expected longest common prefix /plan, got %q", got)
+	}
+}
+
+func TestCompletionDialog_TabInsertsEnterExecutes(t *testing.T) {
+	for key, wantExecute := range map[tea.KeyType]bool{tea.KeyTab: false, tea.KeyEnter: true} {
+		d := NewCompletionDialog()
+		d.SetProvider(NewCommandCompletionProvider())
+		d.Show()
+		d.UpdateQuery("/help")
+
+		_, cmd := d.Update(tea.KeyMsg{Type: key})
+		if cmd == nil {
+			t.Fatalf("Expected a selection command for %v", key)
+		}
+		selected, ok := cmd().(CompletionSelectedMsg)
+		if !ok {
+			t.Fatalf("Expected CompletionSelectedMsg for %v", key)
+		}
+		if selected.Execute != wantExecute {
+			t.Errorf("Key %v: expected Execute=%v, got %v", key, wantExecute, selected.Execute)
+		}
+	}
+}
+
+func TestPrompt_TabInInput(t *testing.T) {
+	p := NewPrompt()
+	p.completionDialog = nil // exercise Tab without the dialog open
+
+	p.textInput.SetValue("/fea")
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := p.textInput.Value(); got != "/features" {
+		t.Errorf("Expected command completion, got %q", got)
+	}
+
+	// Debug commands only complete with debug tools enabled
+	p.textInput.SetValue("/too")
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := p.textInput.Value(); got != "/too" {
+		t.Errorf("Expected /tool hidden without debug tools, got %q", got)
+	}
+	p.SetDebugTools(true)
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := p.textInput.Value(); got != "/tool" {
+		t.Errorf("Expected /tool completed with debug tools, got %q", got)
+	}
+
+	p.textInput.SetValue("hello")
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := p.textInput.Value(); got != "hello" {
+		t.Errorf("Expected Tab to do nothing by default, got %q", got)
+	}
+
+	p.SetTabSpaces(2)
+	p.textInput.SetValue("ab")
+	p.textInput.SetCursor(1)
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := p.textInput.Value(); got != "a  b" {
+		t.Errorf("Expected two spaces at the cursor, got %q", got)
+	}
+}
+
+func TestCommandCompletions_OrderedByUsage(t *testing.T) {
+	t.Chdir(t.TempDir())
+	path := filepath.Join(t.TempDir(), "command-usage.json")
+	provider := &CommandCompletionProvider{usage: LoadCommandUsage(path)}
+
+	titles := func(query string) []string {
+		items, err := provider.GetCompletions(query)
+		if err != nil {
+			t.Fatalf("GetCompletions failed: %v", err)
+		}
+		var got []string
+		for _, item := range items {
+			got = append(got, item.Title)
+		}
+		return got
+	}
+
+	// No history keeps the static order
+	if got := titles(""); got[0] != "/help" || got[1] != "/features" || got[len(got)-1] != "/quit" {
+		t.Errorf("Expected the default order, got %v", got)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := provider.RecordCommand("/auth"); err != nil {
+			t.Fatalf("RecordCommand failed: %v", err)
+		}
+	}
+	provider.RecordCommand("/quit")
+
+	// Counts survive a reload
+	provider = &CommandCompletionProvider{usage: LoadCommandUsage(path)}
+	got := titles("")
+	if got[0] != "/auth" || got[1] != "/help" {
+		t.Errorf("Expected /auth first, then the default order, got %v", got)
+	}
+	if got[len(got)-1] != "/quit" {
+		t.Errorf("Expected /quit last regardless of usage, got %v", got)
+	}
+}
+
+func TestPathCompletions(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{"src/app", "scripts", ".git"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	manager := NewCompletionManager()
+	if got := manager.GetProvider("/plan").GetID(); got != "commands" {
+		t.Errorf("Expected command completion before the space, got %s", got)
+	}
+	if got := manager.GetProvider("/features x").GetID(); got != "commands" {
+		t.Errorf("Expected no path completion for /features, got %s", got)
+	}
+
+	values := func(input string) []string {
+		provider := manager.GetProvider(input)
+		if provider.GetID() != "paths" {
+			t.Fatalf("Expected path completion for %q, got %s", input, provider.GetID())
+		}
+		items, err := provider.GetCompletions(strings.TrimPrefix(input, "/"))
+		if err != nil {
+			t.Fatalf("GetCompletions failed: %v", err)
+		}
+		var got []string
+		for _, item := range items {
+			got = append(got, item.Value)
+		}
+		return got
+	}
+
+	if got := values("/plan "); strings.Join(got, ",") != "/plan scripts/,/plan src/" {
+		t.Errorf("Expected visible directories only, got %v", got)
+	}
+	if got := values("/destroy --dry-run sr"); len(got) == 0 || got[0] != "/destroy --dry-run src/" {
+		t.Errorf("Expected the last word completed with src/ first, got %v", got)
+	}
+	if got := values("/init src/"); len(got) != 1 || got[0] != "/init src/app/" {
+		t.Errorf("Expected subdirectories of src, got %v", got)
+	}
+	if got := values("/init ."); len(got) != 1 || got[0] != "/init .git/" {
+		t.Errorf("Expected hidden directories when asked for, got %v", got)
+	}
+	if got := values("/init --rep"); len(got) != 0 {
+		t.Errorf("Expected no completions for a flag, got %v", got)
+	}
+}
+
+func TestPrompt_PathCompletionTabInsertsEnterRuns(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	t.Setenv("HOME", t.TempDir()) // running the command records usage
+
+	p := NewPrompt()
+	for _, r := range "/destroy --dry-run s" {
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if cmd == nil {
+		t.Fatal("Expected tab to select the directory")
+	}
+	p.Update(cmd())
+	if got := p.textInput.Value(); got != "/destroy --dry-run src/" {
+		t.Fatalf("Expected the directory inserted, got %q", got)
+	}
+
+	// Enter runs the command rather than picking a completion
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(p.StatusBar, "No TDD-Pro project found") {
+		t.Errorf("Expected /destroy to run, got status %q", p.StatusBar)
+	}
+}
@@ -1,11 +1,13 @@
 package config
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
+	"time"
+
+	"tddpro/internal/auth"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -26,6 +28,12 @@ type AuthResultMsg struct {
 	Message string
 }
 
+// AuthVerifiedMsg reports how the Anthropic API (or gateway) took a saved key
+type AuthVerifiedMsg struct {
+	BaseURL string
+	Err     error // auth.ErrInvalidAPIKey when the key was rejected, nil when accepted
+}
+
 // AuthCredentials represents stored Claude credentials
 type AuthCredentials struct {
 	ClaudeAPIKey string `json:"claude_api_key"`
@@ -99,7 +107,7 @@ func (d *AuthDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Check if form is complete
 	if d.form.State == huh.StateCompleted {
 		d.visible = false
-		
+
 		// Save credentials
 		if err := d.saveCredentials(); err != nil {
 			return d, func() tea.Msg {
@@ -110,17 +118,30 @@ func (d *AuthDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		return d, func() tea.Msg {
+		// The key is kept whatever the API makes of it; the check is only reported
+		return d, tea.Sequence(func() tea.Msg {
 			return AuthResultMsg{
 				Success: true,
-				Message: "Claude API key saved successfully! Credentials stored in ~/.config/tdd-pro/auth.json",
+				Message: "Claude API key saved successfully! Credentials stored in " + auth.CredentialLocation() + ". Verifying it...",
 			}
-		}
+		}, d.verify())
 	}
 
 	return d, cmd
 }
 
+// verify checks the saved key against the Anthropic API (or the gateway in
+// ANTHROPIC_BASE_URL / anthropic_base_url) in the background
+func (d *AuthDialog) verify() tea.Cmd {
+	apiKey := d.apiKey
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		baseURL := auth.BaseURL()
+		return AuthVerifiedMsg{BaseURL: baseURL, Err: auth.VerifyAPIKey(ctx, baseURL, apiKey)}
+	}
+}
+
 func (d *AuthDialog) View() string {
 	if !d.visible {
 		return ""
@@ -187,81 +208,18 @@ func (d *AuthDialog) IsVisible() bool {
 	return d.visible
 }
 
-// saveCredentials saves the API key to the auth file
+// saveCredentials saves the API key to the configured credential store
 func (d *AuthDialog) saveCredentials() error {
-	// Get config directory
-	configDir, err := getConfigDir()
-	if err != nil {
-		return fmt.Errorf("failed to get config directory: %w", err)
-	}
-	
-	// Ensure config directory exists
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return fmt.Errorf("failed to create config directory: %w", err)
-	}
-	
-	// Prepare credentials
-	creds := AuthCredentials{
-		ClaudeAPIKey: d.apiKey,
-	}
-	
-	// Marshal to JSON
-	data, err := json.MarshalIndent(creds, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal credentials: %w", err)
-	}
-	
-	// Write to auth file with restricted permissions
-	authPath := filepath.Join(configDir, "auth.json")
-	if err := os.WriteFile(authPath, data, 0600); err != nil {
-		return fmt.Errorf("failed to write auth file: %w", err)
-	}
-	
-	return nil
+	return auth.SaveCredentials(&auth.Credentials{ClaudeAPIKey: d.apiKey})
 }
 
-// getConfigDir returns the TDD-Pro config directory
-func getConfigDir() (string, error) {
-	// Use XDG config directory or fallback to ~/.config
-	configDir := os.Getenv("XDG_CONFIG_HOME")
-	if configDir == "" {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return "", err
-		}
-		configDir = filepath.Join(homeDir, ".config")
-	}
-	
-	return filepath.Join(configDir, "tdd-pro"), nil
-}
-
-// LoadCredentials loads Claude credentials from the auth file
+// LoadCredentials loads Claude credentials from the configured credential store
 func LoadCredentials() (*AuthCredentials, error) {
-	configDir, err := getConfigDir()
+	creds, err := auth.LoadCredentials()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get config directory: %w", err)
+		return nil, err
 	}
-	
-	authPath := filepath.Join(configDir, "auth.json")
-	
-	// Check if auth file exists
-	if _, err := os.Stat(authPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("no credentials found, run /auth to configure")
-	}
-	
-	// Read auth file
-	data, err := os.ReadFile(authPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read auth file: %w", err)
-	}
-	
-	// Parse credentials
-	var creds AuthCredentials
-	if err := json.Unmarshal(data, &creds); err != nil {
-		return nil, fmt.Errorf("failed to parse auth file: %w", err)
-	}
-	
-	return &creds, nil
+	return &AuthCredentials{ClaudeAPIKey: creds.ClaudeAPIKey}, nil
 }
 
 // GetClaudeAPIKey returns the stored Claude API key
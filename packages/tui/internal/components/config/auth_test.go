@@ -0,0 +1,51 @@
+package config
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"tddpro/internal/auth"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+func TestAuthDialog_SavesBeforeVerifying(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	t.Setenv("ANTHROPIC_BASE_URL", server.URL)
+
+	dialog := NewAuthDialog()
+	dialog.Show()
+	dialog.apiKey = "sk-ant-api03-rejected-key"
+	dialog.form.State = huh.StateCompleted
+
+	// The key is saved on completing the form, without waiting on the API
+	_, cmd := dialog.Update(tea.WindowSizeMsg{})
+	if cmd == nil || dialog.IsVisible() {
+		t.Fatal("Expected the dialog to close with the result to report")
+	}
+	creds, err := LoadCredentials()
+	if err != nil || creds.ClaudeAPIKey != "sk-ant-api03-rejected-key" {
+		t.Fatalf("Expected the key saved, got %+v (%v)", creds, err)
+	}
+	if requests != 0 {
+		t.Errorf("Expected no request before the verification runs, got %d", requests)
+	}
+
+	// A rejection is reported but keeps the key
+	verified, ok := dialog.verify()().(AuthVerifiedMsg)
+	if !ok || !errors.Is(verified.Err, auth.ErrInvalidAPIKey) || verified.BaseURL != server.URL {
+		t.Fatalf("Expected the rejection reported, got %+v", verified)
+	}
+	if creds, err := LoadCredentials(); err != nil || creds.ClaudeAPIKey != "sk-ant-api03-rejected-key" {
+		t.Errorf("Expected the rejected key kept, got %+v (%v)", creds, err)
+	}
+}
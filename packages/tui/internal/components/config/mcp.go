@@ -1,10 +1,12 @@
 package config
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"tddpro/internal/mcpclient"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
@@ -32,6 +34,7 @@ type MCPConfigDialog struct {
 	form        *huh.Form
 	visible     bool
 	projectPath string
+	reconfigure bool // opened by /mcp on an initialized project rather than by /init
 	
 	// Form values
 	createMCPConfigs bool
@@ -56,6 +59,15 @@ func NewMCPConfigDialog(projectPath string) *MCPConfigDialog {
 	return dialog
 }
 
+// NewMCPReconfigureDialog creates the dialog for /mcp, which (re)creates or
+// repairs the MCP config files of an already initialized project
+func NewMCPReconfigureDialog(projectPath string) *MCPConfigDialog {
+	dialog := NewMCPConfigDialog(projectPath)
+	dialog.reconfigure = true
+	dialog.form = dialog.createForm()
+	return dialog
+}
+
 // Show displays the MCP configuration dialog
 func (d *MCPConfigDialog) Show() {
 	d.visible = true
@@ -121,11 +133,16 @@ func (d *MCPConfigDialog) createForm() *huh.Form {
 	// Use Charm theme for professional look
 	theme := huh.ThemeCharm()
 	
+	intro := "TDD-Pro includes an MCP (Model Context Protocol) server for AI integration.\n\nWould you like to create configuration files for your editors?"
+	if d.reconfigure {
+		intro = "Create or repair the MCP configuration files for " + d.projectPath + ".\n\nExisting files keep their other servers; only the tdd-pro entry is replaced."
+	}
+	
 	return huh.NewForm(
 		huh.NewGroup(
 			huh.NewNote().
 				Title("🔧 MCP Configuration Setup").
-				Description("TDD-Pro includes an MCP (Model Context Protocol) server for AI integration.\n\nWould you like to create configuration files for your editors?").
+				Description(intro).
 				Next(true).
 				NextLabel("Continue"),
 		),
@@ -161,9 +178,13 @@ func (d *MCPConfigDialog) createForm() *huh.Form {
 func (d *MCPConfigDialog) handleFormComplete() tea.Cmd {
 	return func() tea.Msg {
 		if !d.createMCPConfigs {
+			message := "TDD-Pro initialized successfully (MCP configuration skipped)"
+			if d.reconfigure {
+				message = "MCP configuration unchanged"
+			}
 			return MCPConfigMsg{
 				Success: true,
-				Message: "TDD-Pro initialized successfully (MCP configuration skipped)",
+				Message: message,
 			}
 		}
 		
@@ -216,6 +237,9 @@ func (d *MCPConfigDialog) handleFormComplete() tea.Cmd {
 		}
 		
 		message := "TDD-Pro initialized successfully!"
+		if d.reconfigure {
+			message = "MCP configuration updated!"
+		}
 		if len(createdFiles) > 0 {
 			message += fmt.Sprintf(" Created: %v", createdFiles)
 		}
@@ -245,11 +269,12 @@ func (d *MCPConfigDialog) createMCPConfigFile(filePath string) error {
 		return fmt.Errorf("failed to find MCP server: %w", err)
 	}
 	
+	command, args := serverInvocation(serverPath)
 	config := MCPConfig{
 		MCPServers: map[string]MCPServer{
 			"tdd-pro": {
-				Command: serverPath,
-				Args:    []string{},
+				Command: command,
+				Args:    args,
 				Env: map[string]string{
 					"NODE_ENV": "development",
 				},
@@ -257,21 +282,8 @@ func (d *MCPConfigDialog) createMCPConfigFile(filePath string) error {
 		},
 	}
 	
-	// Check if file exists and has existing config
-	if existingData, err := os.ReadFile(filePath); err == nil {
-		var existingConfig MCPConfig
-		if err := json.Unmarshal(existingData, &existingConfig); err == nil {
-			// Merge with existing config
-			if existingConfig.MCPServers == nil {
-				existingConfig.MCPServers = make(map[string]MCPServer)
-			}
-			existingConfig.MCPServers["tdd-pro"] = config.MCPServers["tdd-pro"]
-			config = existingConfig
-		}
-	}
-	
-	// Write the configuration file
-	data, err := json.MarshalIndent(config, "", "  ")
+	// Merge into an existing config, keeping its other servers and keys as they are
+	data, err := mergeMCPServer(filePath, config.MCPServers["tdd-pro"])
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
@@ -283,7 +295,21 @@ func (d *MCPConfigDialog) createMCPConfigFile(filePath string) error {
 	return nil
 }
 
-// findMCPServerPath finds the tdd-pro-mcp binary in the same directory as the current executable
+// serverInvocation returns the command and args an editor runs to start the
+// server at serverPath. Binaries run directly; a .ts source from a development
+// checkout runs the way the TUI launches it (directly or through bun).
+func serverInvocation(serverPath string) (string, []string) {
+	if strings.HasSuffix(serverPath, ".ts") {
+		if cmd, err := mcpclient.ServerCommand(serverPath); err == nil {
+			return cmd.Path, append([]string{}, cmd.Args[1:]...)
+		}
+	}
+	return serverPath, []string{}
+}
+
+// findMCPServerPath finds the tdd-pro-mcp binary in the same directory as the
+// current executable, falling back to the discovery the TUI itself uses
+// (TDDPRO_MCP_PATH, ~/.tdd-pro/bin, TDDPRO_PATH and the source checkout)
 func (d *MCPConfigDialog) findMCPServerPath() (string, error) {
 	// Get the path of the current executable
 	exePath, err := os.Executable()
@@ -310,5 +336,9 @@ func (d *MCPConfigDialog) findMCPServerPath() (string, error) {
 		}
 	}
 	
+	if path, err := mcpclient.GetMCPServerPath(); err == nil {
+		return path, nil
+	}
+	
 	return "", fmt.Errorf("could not find tdd-pro-mcp binary. Expected in same directory as tdd-pro executable: %s", exeDir)
 }
\ No newline at end of file
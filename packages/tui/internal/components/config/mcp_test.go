@@ -2,8 +2,10 @@ package config
 
 import (
 	"encoding/json"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -566,4 +568,125 @@ func TestMCPConfig_EmptyMcpServersSection(t *testing.T) {
 	if tddProServer.Command != mockMCPPath {
 		t.Errorf("Expected tdd-pro command %s, got %s", mockMCPPath, tddProServer.Command)
 	}
-}
\ No newline at end of file
+}
+
+func TestHandleFormComplete_WritesEditorConfigPaths(t *testing.T) {
+	tempDir := t.TempDir()
+	dialog := &MCPConfigDialog{
+		projectPath:      tempDir,
+		createMCPConfigs: true,
+		createCursor:     true,
+		createVSCode:     true,
+		findMCPServerPathFunc: func() (string, error) {
+			return filepath.Join(tempDir, "mock-tdd-pro-mcp"), nil
+		},
+	}
+
+	msg, ok := dialog.handleFormComplete()().(MCPConfigMsg)
+	if !ok || !msg.Success {
+		t.Fatalf("Expected successful MCPConfigMsg, got %+v", msg)
+	}
+
+	var written []string
+	err := filepath.WalkDir(tempDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(tempDir, path)
+		written = append(written, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to walk project dir: %v", err)
+	}
+
+	// WalkDir visits in lexical order
+	expected := []string{".cursor/mcp.json", ".mcp.json", ".vscode/mcp.json"}
+	if len(written) != len(expected) {
+		t.Fatalf("Expected files %v, got %v", expected, written)
+	}
+	for i := range expected {
+		if written[i] != expected[i] {
+			t.Errorf("Expected files %v, got %v", expected, written)
+			break
+		}
+	}
+}
+
+func TestHandleFormComplete_ReconfigureKeepsOtherServers(t *testing.T) {
+	tempDir := t.TempDir()
+	existing := `{"mcpServers": {"other": {"command": "other-server", "args": [], "env": {}}}}`
+	if err := os.WriteFile(filepath.Join(tempDir, ".mcp.json"), []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dialog := &MCPConfigDialog{
+		projectPath:      tempDir,
+		reconfigure:      true,
+		createMCPConfigs: true,
+		findMCPServerPathFunc: func() (string, error) {
+			return "/opt/tdd-pro-mcp", nil
+		},
+	}
+
+	msg, ok := dialog.handleFormComplete()().(MCPConfigMsg)
+	if !ok || !msg.Success || !strings.HasPrefix(msg.Message, "MCP configuration updated!") {
+		t.Fatalf("Expected an MCP configuration updated message, got %+v", msg)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tempDir, ".mcp.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config MCPConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatal(err)
+	}
+	if config.MCPServers["other"].Command != "other-server" || config.MCPServers["tdd-pro"].Command != "/opt/tdd-pro-mcp" {
+		t.Errorf("Expected both servers after reconfiguring, got %+v", config.MCPServers)
+	}
+}
+
+func TestFindMCPServerPath_FallsBackToServerDiscovery(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	server := filepath.Join(t.TempDir(), "custom-mcp")
+	if err := os.WriteFile(server, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TDDPRO_MCP_PATH", server)
+
+	dialog := NewMCPConfigDialog(t.TempDir())
+	path, err := dialog.findMCPServerPath()
+	if err != nil || path != server {
+		t.Fatalf("Expected TDDPRO_MCP_PATH %s, got %q (%v)", server, path, err)
+	}
+}
+
+func TestCreateMCPConfigFile_RunsTypeScriptSource(t *testing.T) {
+	tempDir := t.TempDir()
+	// An executable .ts source runs directly through its shebang
+	source := filepath.Join(tempDir, "mcp-stdio-server.ts")
+	if err := os.WriteFile(source, []byte("#!/usr/bin/env bun\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	dialog := &MCPConfigDialog{
+		projectPath:           tempDir,
+		findMCPServerPathFunc: func() (string, error) { return source, nil },
+	}
+	configPath := filepath.Join(tempDir, ".mcp.json")
+	if err := dialog.createMCPConfigFile(configPath); err != nil {
+		t.Fatalf("createMCPConfigFile failed: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config MCPConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatal(err)
+	}
+	server := config.MCPServers["tdd-pro"]
+	if server.Command != source || len(server.Args) != 0 {
+		t.Errorf("Expected %s with no args, got %s %v", source, server.Command, server.Args)
+	}
+}
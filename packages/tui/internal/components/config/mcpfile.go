@@ -0,0 +1,104 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// MCPConfigFiles are the editor MCP config files /mcp writes, relative to the
+// project root: Claude Code, Cursor and VS Code
+var MCPConfigFiles = []string{
+	".mcp.json",
+	filepath.Join(".cursor", "mcp.json"),
+	filepath.Join(".vscode", "mcp.json"),
+}
+
+// mergeMCPServer returns the config file at filePath with server set as its
+// tdd-pro server. Other servers and top-level keys are kept as they are,
+// fields this package doesn't know included; a missing or unparsable file
+// is replaced.
+func mergeMCPServer(filePath string, server MCPServer) ([]byte, error) {
+	config := map[string]json.RawMessage{}
+	servers := map[string]json.RawMessage{}
+	if data, err := os.ReadFile(filePath); err == nil {
+		var existing, existingServers map[string]json.RawMessage
+		if json.Unmarshal(data, &existing) == nil && existing != nil {
+			if raw, ok := existing["mcpServers"]; !ok || json.Unmarshal(raw, &existingServers) == nil {
+				config = existing
+				if existingServers != nil {
+					servers = existingServers
+				}
+			}
+		}
+	}
+
+	var err error
+	if servers["tdd-pro"], err = json.Marshal(server); err != nil {
+		return nil, err
+	}
+	if config["mcpServers"], err = json.Marshal(servers); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(config, "", "  ")
+}
+
+// MCPRemoval reports what RemoveMCPServer did to one config file
+type MCPRemoval struct {
+	Removed bool // the file had a tdd-pro server, which is gone now
+	Deleted bool // nothing else was left, so the file was deleted
+}
+
+// RemoveMCPServer removes the tdd-pro server from the MCP config file at
+// filePath. Other servers and top-level keys are kept as they are; a file
+// left with nothing in it is deleted. A missing file, or one without a
+// tdd-pro server, is left alone.
+func RemoveMCPServer(filePath string) (MCPRemoval, error) {
+	data, err := os.ReadFile(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return MCPRemoval{}, nil
+	}
+	if err != nil {
+		return MCPRemoval{}, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	// Raw messages keep whatever the other servers and keys contain
+	var config map[string]json.RawMessage
+	if err := json.Unmarshal(data, &config); err != nil {
+		return MCPRemoval{}, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	var servers map[string]json.RawMessage
+	if raw, ok := config["mcpServers"]; ok {
+		if err := json.Unmarshal(raw, &servers); err != nil {
+			return MCPRemoval{}, fmt.Errorf("failed to parse mcpServers: %w", err)
+		}
+	}
+	if _, ok := servers["tdd-pro"]; !ok {
+		return MCPRemoval{}, nil
+	}
+
+	delete(servers, "tdd-pro")
+	if len(servers) == 0 {
+		delete(config, "mcpServers")
+	} else if config["mcpServers"], err = json.Marshal(servers); err != nil {
+		return MCPRemoval{}, fmt.Errorf("failed to marshal mcpServers: %w", err)
+	}
+
+	if len(config) == 0 {
+		if err := os.Remove(filePath); err != nil {
+			return MCPRemoval{}, fmt.Errorf("failed to delete config file: %w", err)
+		}
+		return MCPRemoval{Removed: true, Deleted: true}, nil
+	}
+	data, err = json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return MCPRemoval{}, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return MCPRemoval{}, fmt.Errorf("failed to write config file: %w", err)
+	}
+	return MCPRemoval{Removed: true}, nil
+}
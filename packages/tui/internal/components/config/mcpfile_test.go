@@ -0,0 +1,89 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRemoveMCPServer_KeepsOtherServersAndDeletesEmptyFiles(t *testing.T) {
+	dir := t.TempDir()
+	dialog := &MCPConfigDialog{projectPath: dir, findMCPServerPathFunc: func() (string, error) {
+		return "/usr/local/bin/tdd-pro-mcp", nil
+	}}
+
+	// A config shared with another server and a key of its own
+	shared := filepath.Join(dir, ".mcp.json")
+	existing := `{"mcpServers": {"github": {"command": "gh-mcp", "args": ["--stdio"], "timeout": 30}}, "inputs": [{"id": "token"}]}`
+	if err := os.WriteFile(shared, []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := dialog.createMCPConfigFile(shared); err != nil {
+		t.Fatal(err)
+	}
+	// A config holding only tdd-pro
+	own := filepath.Join(dir, ".cursor", "mcp.json")
+	if err := dialog.createMCPConfigFile(own); err != nil {
+		t.Fatal(err)
+	}
+
+	// servers reads the shared config, checking what isn't tdd-pro's is intact
+	servers := func() (map[string]json.RawMessage, []byte) {
+		t.Helper()
+		data, err := os.ReadFile(shared)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var config map[string]json.RawMessage
+		var servers map[string]json.RawMessage
+		if err := json.Unmarshal(data, &config); err != nil || json.Unmarshal(config["mcpServers"], &servers) != nil {
+			t.Fatalf("Expected valid JSON, got %s", data)
+		}
+		var github map[string]any
+		if err := json.Unmarshal(servers["github"], &github); err != nil || github["command"] != "gh-mcp" || github["timeout"] != 30.0 {
+			t.Errorf("Expected the github server kept with all its fields, got %s", data)
+		}
+		if _, ok := config["inputs"]; !ok {
+			t.Errorf("Expected other top-level keys kept, got %s", data)
+		}
+		return servers, data
+	}
+	if merged, _ := servers(); merged["tdd-pro"] == nil {
+		t.Error("Expected tdd-pro merged into the shared config")
+	}
+
+	removal, err := RemoveMCPServer(shared)
+	if err != nil || removal != (MCPRemoval{Removed: true}) {
+		t.Fatalf("Expected tdd-pro removed from the shared config, got %+v, %v", removal, err)
+	}
+	remaining, data := servers()
+	if _, ok := remaining["tdd-pro"]; ok {
+		t.Error("Expected the tdd-pro server gone")
+	}
+
+	removal, err = RemoveMCPServer(own)
+	if err != nil || removal != (MCPRemoval{Removed: true, Deleted: true}) {
+		t.Fatalf("Expected the tdd-pro only config deleted, got %+v, %v", removal, err)
+	}
+	if _, err := os.Stat(own); !os.IsNotExist(err) {
+		t.Errorf("Expected %s deleted, got %v", own, err)
+	}
+
+	// Files without tdd-pro, or missing ones, are left alone
+	for _, path := range []string{shared, filepath.Join(dir, ".vscode", "mcp.json")} {
+		if removal, err := RemoveMCPServer(path); err != nil || removal != (MCPRemoval{}) {
+			t.Errorf("Expected nothing to remove from %s, got %+v, %v", path, removal, err)
+		}
+	}
+	if after, _ := os.ReadFile(shared); string(after) != string(data) {
+		t.Errorf("Expected a config without tdd-pro to be left untouched, got %s", after)
+	}
+
+	// A file that isn't JSON is reported rather than overwritten
+	broken := filepath.Join(dir, "broken.json")
+	os.WriteFile(broken, []byte("{not json"), 0644)
+	if _, err := RemoveMCPServer(broken); err == nil {
+		t.Error("Expected an error for invalid JSON")
+	}
+}
@@ -0,0 +1,97 @@
+package components
+
+import (
+	"time"
+
+	"tddpro/internal/mcpclient"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// idleCheckInterval is how often the idle timeout is evaluated
+const idleCheckInterval = 30 * time.Second
+
+// idleCheckMsg fires periodically to close an idle SSE connection
+type idleCheckMsg time.Time
+
+// Init starts background checks the prompt needs while the program runs
+func (p *Prompt) Init() tea.Cmd {
+	return tea.Batch(p.scheduleIdleCheck(), p.scheduleFeaturesRefresh(), p.startupVersionCheck())
+}
+
+// SetIdleTimeout closes the SSE connection after d without sending a message.
+// It reconnects on the next message. Zero disables the timeout.
+func (p *Prompt) SetIdleTimeout(d time.Duration) {
+	p.idleTimeout = d
+}
+
+// scheduleIdleCheck returns the next idle check, or nil when the timeout is off
+func (p *Prompt) scheduleIdleCheck() tea.Cmd {
+	if p.idleTimeout <= 0 {
+		return nil
+	}
+	interval := idleCheckInterval
+	if p.idleTimeout < interval {
+		interval = p.idleTimeout
+	}
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return idleCheckMsg(t)
+	})
+}
+
+// checkIdle closes the SSE connection once the idle timeout has elapsed
+func (p *Prompt) checkIdle() {
+	if p.idleTimeout <= 0 || p.MCP == nil || p.awaitingReply || !p.MCP.Connected() {
+		return
+	}
+	if time.Since(p.lastActivity) < p.idleTimeout {
+		return
+	}
+	p.MCP.Close()
+	p.idleDisconnected = true
+}
+
+// ConnectionStateMsg is sent when the backend connection changes state so the
+// indicator and status bar can be redrawn
+type ConnectionStateMsg struct {
+	State mcpclient.ConnectionState
+}
+
+// updateConnectionStatus reflects a reconnect in the status bar. The client's
+// current state is read rather than the message's, since notifications may
+// arrive out of order.
+func (p *Prompt) updateConnectionStatus() {
+	if p.MCP == nil {
+		return
+	}
+	switch p.MCP.ConnectionState() {
+	case mcpclient.StateReconnecting:
+		p.reconnecting = true
+		p.StatusBar = "Reconnecting..."
+	case mcpclient.StateConnected:
+		if p.reconnecting {
+			p.reconnecting = false
+			p.StatusBar = "Reconnected, waiting for reply..."
+		}
+	}
+}
+
+// connectionIndicator renders the SSE connection state shown next to the header
+func (p *Prompt) connectionIndicator() string {
+	if p.MCP == nil {
+		return ""
+	}
+	switch p.MCP.ConnectionState() {
+	case mcpclient.StateConnected:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Success)).Render("● connected")
+	case mcpclient.StateConnecting:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render("◌ connecting…")
+	case mcpclient.StateReconnecting:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Warning)).Render("◌ reconnecting…")
+	}
+	if p.idleDisconnected {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render("○ disconnected (idle)")
+	}
+	return ""
+}
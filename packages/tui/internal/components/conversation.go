@@ -0,0 +1,139 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// MessageRole identifies who authored a conversation message
+type MessageRole string
+
+const (
+	RoleUser  MessageRole = "user"
+	RoleAgent MessageRole = "agent"
+)
+
+// MessageState tracks the delivery state of a user message
+type MessageState int
+
+const (
+	MessageSending MessageState = iota
+	MessageSent
+	MessageFailed
+	MessageCancelled // the user stopped waiting for the reply
+)
+
+// ConversationMessage is a single entry in the conversation history
+type ConversationMessage struct {
+	Role    MessageRole
+	Content string
+	State   MessageState
+}
+
+// Conversation holds the messages exchanged with the agent this session
+type Conversation struct {
+	Messages []ConversationMessage
+	Focused  bool // whether the history has keyboard focus instead of the input
+	Selected int  // index of the highlighted message while focused
+}
+
+// Append adds a message and returns its index so its state can be updated later
+func (c *Conversation) Append(role MessageRole, content string, state MessageState) int {
+	c.Messages = append(c.Messages, ConversationMessage{Role: role, Content: content, State: state})
+	return len(c.Messages) - 1
+}
+
+// SetState updates the delivery state of the message at index
+func (c *Conversation) SetState(index int, state MessageState) {
+	if index >= 0 && index < len(c.Messages) {
+		c.Messages[index].State = state
+	}
+}
+
+// Focus gives the history keyboard focus and highlights the newest message
+func (c *Conversation) Focus() {
+	c.Focused = true
+	c.Selected = len(c.Messages) - 1
+}
+
+// Blur returns keyboard focus to the input
+func (c *Conversation) Blur() {
+	c.Focused = false
+}
+
+// MoveSelection moves the highlight by delta, clamped to the message list
+func (c *Conversation) MoveSelection(delta int) {
+	if c.IsEmpty() {
+		return
+	}
+	c.Selected += delta
+	if c.Selected < 0 {
+		c.Selected = 0
+	}
+	if c.Selected >= len(c.Messages) {
+		c.Selected = len(c.Messages) - 1
+	}
+}
+
+// SelectedMessage returns the highlighted message, or nil when none is selected
+func (c *Conversation) SelectedMessage() *ConversationMessage {
+	if !c.Focused || c.Selected < 0 || c.Selected >= len(c.Messages) {
+		return nil
+	}
+	return &c.Messages[c.Selected]
+}
+
+// IsEmpty returns whether no messages have been exchanged yet
+func (c *Conversation) IsEmpty() bool {
+	return len(c.Messages) == 0
+}
+
+// View renders the conversation, keeping only the last maxLines lines visible
+func (c *Conversation) View(width int, maxLines int, theme Theme) string {
+	if c.IsEmpty() {
+		return ""
+	}
+
+	userStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Text)).Width(width)
+	agentStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Value)).PaddingLeft(2).Width(width)
+	stateStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Muted)).Italic(true)
+	failedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Error)).Italic(true)
+
+	selectedStyle := lipgloss.NewStyle().Background(lipgloss.Color(theme.StatusBg))
+
+	var rows []string
+	for i, msg := range c.Messages {
+		var row string
+		if msg.Role == RoleAgent {
+			row = agentStyle.Render(msg.Content)
+		} else {
+			row = userStyle.Render("> " + msg.Content + c.stateLabel(msg.State, stateStyle, failedStyle))
+		}
+		if c.Focused && i == c.Selected {
+			row = selectedStyle.Render(row)
+		}
+		rows = append(rows, row)
+	}
+
+	lines := strings.Split(strings.Join(rows, "\n"), "\n")
+	if maxLines > 0 && len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// stateLabel renders the delivery state suffix shown after a user message
+func (c *Conversation) stateLabel(state MessageState, stateStyle, failedStyle lipgloss.Style) string {
+	switch state {
+	case MessageSending:
+		return stateStyle.Render(" (sending…)")
+	case MessageSent:
+		return stateStyle.Render(" (sent)")
+	case MessageFailed:
+		return failedStyle.Render(" (failed)")
+	case MessageCancelled:
+		return stateStyle.Render(" (cancelled)")
+	}
+	return ""
+}
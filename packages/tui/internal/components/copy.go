@@ -0,0 +1,84 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tddpro/internal/util"
+)
+
+// handleCopyKey handles the y-prefixed copy chords in the features view:
+// y i copies the selected feature's ID and y p its PRD. Returns whether the
+// key was consumed, and the command loading the PRD when it isn't cached.
+func (p *Prompt) handleCopyKey(key string) (tea.Cmd, bool) {
+	if !p.pendingCopy {
+		if key != "y" {
+			return nil, false
+		}
+		p.pendingCopy = true
+		p.StatusBar = "Copy: i feature ID, p PRD (esc to cancel)"
+		return nil, true
+	}
+
+	p.pendingCopy = false
+	switch key {
+	case "i":
+		if p.SelectedFeature != nil {
+			p.copyValue("feature ID", p.SelectedFeature.ID)
+		}
+		return nil, true
+	case "p":
+		return p.copyPRD(), true
+	case "esc":
+		p.StatusBar = ""
+		return nil, true
+	}
+	// Any other key abandons the copy and is handled normally
+	p.StatusBar = ""
+	return nil, false
+}
+
+// copyPRD copies the selected feature's PRD document, from the cache or once
+// it has loaded
+func (p *Prompt) copyPRD() tea.Cmd {
+	if p.SelectedFeature == nil || p.MCP == nil {
+		p.StatusBar = "No feature selected"
+		return nil
+	}
+	return p.withPRD(p.SelectedFeature.ID, func(prd string) tea.Cmd {
+		if prd == "" {
+			p.StatusBar = "No PRD document available"
+			return nil
+		}
+		p.copyValue("PRD", prd)
+		return nil
+	})
+}
+
+// copyValue copies value to the clipboard. Over SSH or on a headless machine
+// there's no clipboard, so the value is shown in the status area instead.
+func (p *Prompt) copyValue(label, value string) {
+	if err := util.CopyToClipboard(value); err != nil {
+		p.StatusBar = fmt.Sprintf("Clipboard unavailable, %s: %s", label, statusLine(value, p.WindowWidth))
+		return
+	}
+	if lines := strings.Count(value, "\n") + 1; lines > 1 {
+		p.StatusBar = fmt.Sprintf("Copied %s (%d lines) to clipboard", label, lines)
+	} else {
+		p.StatusBar = fmt.Sprintf("Copied %s %s to clipboard", label, value)
+	}
+}
+
+// statusLine flattens text onto one line, truncated to fit width
+func statusLine(text string, width int) string {
+	line := strings.Join(strings.Fields(text), " ")
+	if width < 80 {
+		width = 80
+	}
+	if runes := []rune(line); len(runes) > width {
+		line = string(runes[:width-1]) + "…"
+	}
+	return line
+}
@@ -0,0 +1,67 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"tddpro/internal/mcpclient"
+)
+
+func TestHandleCopyKey_CopiesFeatureID(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.focusState = 0
+
+	if _, ok := p.handleCopyKey("y"); !ok || !p.pendingCopy {
+		t.Fatal("Expected y to start a copy chord")
+	}
+	if _, ok := p.handleCopyKey("i"); !ok {
+		t.Fatal("Expected i to complete the copy chord")
+	}
+	// With or without a clipboard the status names the copied ID
+	if !strings.Contains(p.StatusBar, "user-auth") {
+		t.Errorf("Expected status to mention the feature ID, got %q", p.StatusBar)
+	}
+
+	p.handleCopyKey("y")
+	if _, ok := p.handleCopyKey("down"); ok || p.pendingCopy {
+		t.Error("Expected an unrelated key to cancel the chord and be handled normally")
+	}
+}
+
+func TestHandleCopyKey_CopiesPRDFromTheCache(t *testing.T) {
+	p := newDemoPrompt(t)
+	client := &countingClient{DemoClient: mcpclient.NewDemoClient()}
+	p.MCP = client
+	p.focusState = 0
+	p.prds.invalidate(p.SelectedFeature.ID)
+
+	// An uncached PRD is loaded in the background, then copied
+	p.handleCopyKey("y")
+	cmd, ok := p.handleCopyKey("p")
+	if !ok || cmd == nil || client.docs != 0 || p.StatusBar != "Loading PRD..." {
+		t.Fatalf("Expected the PRD loaded off the event loop, got %d fetches and %q", client.docs, p.StatusBar)
+	}
+	p.Update(prdLoadedMsg{featureID: p.SelectedFeature.ID, content: "# Auth PRD\nSessions"})
+	// With or without a clipboard the status shows what was copied
+	if !strings.Contains(p.StatusBar, "PRD") || strings.Contains(p.StatusBar, "Loading") {
+		t.Errorf("Expected the PRD copied once loaded, got %q", p.StatusBar)
+	}
+
+	// A cached PRD is copied straight away
+	p.StatusBar = ""
+	p.handleCopyKey("y")
+	if cmd, _ := p.handleCopyKey("p"); cmd != nil || client.docs != 0 || !strings.Contains(p.StatusBar, "PRD") {
+		t.Errorf("Expected the cached PRD copied, got %d fetches and %q", client.docs, p.StatusBar)
+	}
+}
+
+func TestStatusLine_FlattensAndTruncates(t *testing.T) {
+	long := "# PRD\n\n" + strings.Repeat("word ", 40)
+	line := statusLine(long, 80)
+	if strings.Contains(line, "\n") {
+		t.Errorf("Expected a single line, got %q", line)
+	}
+	if n := len([]rune(line)); n > 80 {
+		t.Errorf("Expected at most 80 runes, got %d", n)
+	}
+}
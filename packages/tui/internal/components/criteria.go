@@ -0,0 +1,147 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CriteriaList edits acceptance criteria one row at a time: enter edits the
+// selected row in place, d deletes it and a appends a new one
+type CriteriaList struct {
+	Items    []string
+	Selected int
+	editing  int // index of the row being edited, len(Items) while appending, -1 when browsing
+	input    textinput.Model
+}
+
+// NewCriteriaList returns a list over items with the first row selected
+func NewCriteriaList(items []string) *CriteriaList {
+	return &CriteriaList{Items: append([]string(nil), items...), editing: -1}
+}
+
+// Editing reports whether a row is open in the input
+func (c *CriteriaList) Editing() bool {
+	return c.editing >= 0
+}
+
+// Update handles a message, returning done once the user leaves the list (esc or ctrl+g)
+func (c *CriteriaList) Update(msg tea.Msg) (done bool, cmd tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if c.Editing() {
+		if ok {
+			switch keyMsg.String() {
+			case "enter":
+				c.commitEdit()
+				return false, nil
+			case "esc":
+				c.editing = -1
+				return false, nil
+			}
+		}
+		c.input, cmd = c.input.Update(msg)
+		return false, cmd
+	}
+	if !ok {
+		return false, nil
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		if c.Selected > 0 {
+			c.Selected--
+		}
+	case "down", "j":
+		if c.Selected < len(c.Items)-1 {
+			c.Selected++
+		}
+	case "enter":
+		if c.Selected < len(c.Items) {
+			return false, c.startEdit(c.Selected, c.Items[c.Selected])
+		}
+	case "a":
+		return false, c.startEdit(len(c.Items), "")
+	case "d":
+		c.delete(c.Selected)
+	case "esc", "ctrl+g":
+		return true, nil
+	}
+	return false, nil
+}
+
+// startEdit opens row index in the input, prefilled with value
+func (c *CriteriaList) startEdit(index int, value string) tea.Cmd {
+	c.editing = index
+	c.input = textinput.New()
+	c.input.Prompt = ""
+	c.input.Placeholder = "Describe the criterion..."
+	c.input.SetValue(value)
+	return c.input.Focus()
+}
+
+// commitEdit saves the input to its row, trimmed like the one-per-line text:
+// a row left blank is removed instead of kept empty
+func (c *CriteriaList) commitEdit() {
+	value := strings.TrimSpace(c.input.Value())
+	index := c.editing
+	c.editing = -1
+	switch {
+	case index == len(c.Items):
+		if value != "" {
+			c.Items = append(c.Items, value)
+			c.Selected = len(c.Items) - 1
+		}
+	case value == "":
+		c.delete(index)
+	default:
+		c.Items[index] = value
+	}
+}
+
+// delete removes the row at index, keeping the selection in range
+func (c *CriteriaList) delete(index int) {
+	if index < 0 || index >= len(c.Items) {
+		return
+	}
+	c.Items = append(c.Items[:index], c.Items[index+1:]...)
+	if c.Selected >= len(c.Items) && c.Selected > 0 {
+		c.Selected = len(c.Items) - 1
+	}
+}
+
+// View renders the numbered rows with the selection highlighted and the edited row as an input
+func (c *CriteriaList) View(width int, theme Theme) string {
+	rowStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Value)).Padding(0, 1).Width(width)
+	selectedStyle := rowStyle.
+		Foreground(lipgloss.Color(theme.SelectedText)).
+		Background(lipgloss.Color(theme.Focus))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Muted)).Italic(true).Padding(0, 1)
+
+	var rows []string
+	for i, item := range c.Items {
+		label := fmt.Sprintf("%d. ", i+1)
+		switch {
+		case i == c.editing:
+			rows = append(rows, rowStyle.Render(label+c.input.View()))
+		case i == c.Selected && !c.Editing():
+			rows = append(rows, selectedStyle.Render(label+item))
+		default:
+			rows = append(rows, rowStyle.Render(label+item))
+		}
+	}
+	if c.editing == len(c.Items) {
+		rows = append(rows, rowStyle.Render(fmt.Sprintf("%d. ", len(c.Items)+1)+c.input.View()))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, hintStyle.Render("No criteria yet"))
+	}
+
+	hint := "enter edit · d delete · a add · esc done"
+	if c.Editing() {
+		hint = "enter save · esc cancel · blank removes the row"
+	}
+	return strings.Join(rows, "\n") + "\n" + hintStyle.Render(hint)
+}
@@ -0,0 +1,82 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func typeKeys(c *CriteriaList, keys ...string) {
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "ctrl+u":
+			msg = tea.KeyMsg{Type: tea.KeyCtrlU}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		c.Update(msg)
+	}
+}
+
+func TestCriteriaList_EditDeleteAppend(t *testing.T) {
+	c := NewCriteriaList([]string{"first", "second", "third"})
+
+	// Edit the second row in place
+	typeKeys(c, "down", "enter", "ctrl+u", "  updated  ", "enter")
+	// Delete the first row
+	typeKeys(c, "k", "d")
+	// Append a new row; a blank append is dropped
+	typeKeys(c, "a", "fourth", "enter", "a", "   ", "enter")
+
+	want := []string{"updated", "third", "fourth"}
+	if strings.Join(c.Items, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected %v, got %v", want, c.Items)
+	}
+}
+
+func TestCriteriaList_BlankEditRemovesRow(t *testing.T) {
+	c := NewCriteriaList([]string{"keep", "drop"})
+	typeKeys(c, "down", "enter", "ctrl+u", "enter")
+	if len(c.Items) != 1 || c.Items[0] != "keep" {
+		t.Errorf("Expected blank edit to remove the row, got %v", c.Items)
+	}
+
+	typeKeys(c, "enter", "ctrl+u", "changed", "esc")
+	if c.Items[0] != "keep" {
+		t.Errorf("Expected esc to discard the edit, got %v", c.Items)
+	}
+}
+
+func TestTaskEditForm_CriteriaListRoundTrip(t *testing.T) {
+	f := &TaskEditForm{visible: true, title: "Task", criteria: []string{"one", "two"}}
+	f.buildForm()
+	f.Init()
+
+	f.Update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	if f.criteriaList == nil {
+		t.Fatal("Expected ctrl+g to open the criteria list")
+	}
+	f.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	f.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	if f.criteriaList != nil {
+		t.Fatal("Expected esc to close the criteria list, not the form")
+	}
+	if !f.visible {
+		t.Error("Expected the task form to stay open")
+	}
+	if f.criteriaText != "two" || len(f.criteria) != 1 {
+		t.Errorf("Expected criteria serialized back to the form, got %q / %v", f.criteriaText, f.criteria)
+	}
+	if key := f.form.GetFocusedField().GetKey(); key != "criteria" {
+		t.Errorf("Expected the criteria field focused after the list closes, got %q", key)
+	}
+}
@@ -0,0 +1,281 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"tddpro/internal/mcpclient"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dependencyEditor is the dependency list of the feature data panel while it
+// has the keyboard (ctrl+k). Links are saved as soon as they're added or removed.
+type dependencyEditor struct {
+	featureID string
+	selected  int  // index into the feature's dependencies
+	adding    bool // picking a feature to link
+	candidate int  // index into dependencyCandidates while adding
+}
+
+// featureDependenciesSavedMsg is sent when a feature's dependency list has
+// been written via MCP
+type featureDependenciesSavedMsg struct {
+	featureID    string
+	dependencies []string
+	message      string // toast shown on success
+	warning      bool   // message warns about a cycle
+	err          error
+}
+
+// openDependencyEditor gives the dependency list of the selected feature the keyboard
+func (p *Prompt) openDependencyEditor() {
+	if p.SelectedFeature == nil || p.blockedByReadOnly() {
+		return
+	}
+	p.deps = &dependencyEditor{featureID: p.SelectedFeature.ID}
+	p.StatusBar = "Dependencies: enter opens, a adds, x removes, esc when done"
+}
+
+// dependencyCandidates lists the features that can still be linked from the selected feature
+func (p *Prompt) dependencyCandidates() []mcpclient.Feature {
+	if p.SelectedFeature == nil {
+		return nil
+	}
+	linked := map[string]bool{p.SelectedFeature.ID: true}
+	for _, id := range p.SelectedFeature.Dependencies {
+		linked[id] = true
+	}
+	var candidates []mcpclient.Feature
+	for _, feature := range p.allFeatures() {
+		if !linked[feature.ID] {
+			candidates = append(candidates, feature)
+		}
+	}
+	return candidates
+}
+
+// allFeatures returns every feature in sidebar group order, ignoring any status filter
+func (p *Prompt) allFeatures() []mcpclient.Feature {
+	return append(append(append(append([]mcpclient.Feature{}, p.FeaturesData.Approved...), p.FeaturesData.Planned...), p.FeaturesData.Refinement...), p.FeaturesData.Backlog...)
+}
+
+// dependencyCycle returns the loop that linking from to to would close, e.g.
+// [a b c a], following existing dependencies from to back to from. It
+// returns nil when there's no cycle.
+func (p *Prompt) dependencyCycle(from, to string) []string {
+	visited := map[string]bool{}
+	var path []string
+	var visit func(id string) bool
+	visit = func(id string) bool {
+		path = append(path, id)
+		if id == from {
+			return true
+		}
+		if !visited[id] {
+			visited[id] = true
+			if feature, ok := p.FeaturesData.FindFeature(id); ok {
+				for _, dep := range feature.Dependencies {
+					if visit(dep) {
+						return true
+					}
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+	if !visit(to) {
+		return nil
+	}
+	return append([]string{from}, path...)
+}
+
+// handleDependencyKey handles keys while the dependency editor is open
+func (p *Prompt) handleDependencyKey(key string) tea.Cmd {
+	feature := p.SelectedFeature
+	if feature == nil || feature.ID != p.deps.featureID {
+		p.deps = nil
+		return nil
+	}
+
+	if p.deps.adding {
+		candidates := p.dependencyCandidates()
+		switch key {
+		case "up", "k":
+			if len(candidates) > 0 {
+				p.deps.candidate = (p.deps.candidate - 1 + len(candidates)) % len(candidates)
+			}
+		case "down", "j":
+			if len(candidates) > 0 {
+				p.deps.candidate = (p.deps.candidate + 1) % len(candidates)
+			}
+		case "enter":
+			if p.deps.candidate >= len(candidates) {
+				return nil
+			}
+			target := candidates[p.deps.candidate]
+			p.deps.adding = false
+			dependencies := append(append([]string{}, feature.Dependencies...), target.ID)
+			message, warning := "Linked to "+target.Name, false
+			if cycle := p.dependencyCycle(feature.ID, target.ID); cycle != nil {
+				message, warning = "Dependency cycle: "+strings.Join(cycle, " → "), true
+			}
+			return p.saveDependencies(feature.ID, dependencies, message, warning)
+		case "esc":
+			p.deps.adding = false
+		}
+		return nil
+	}
+
+	switch key {
+	case "up", "k":
+		if n := len(feature.Dependencies); n > 0 {
+			p.deps.selected = (p.deps.selected - 1 + n) % n
+		}
+	case "down", "j":
+		if n := len(feature.Dependencies); n > 0 {
+			p.deps.selected = (p.deps.selected + 1) % n
+		}
+	case "enter":
+		if p.deps.selected < len(feature.Dependencies) {
+			p.jumpToFeature(feature.Dependencies[p.deps.selected])
+		}
+	case "a":
+		if len(p.dependencyCandidates()) == 0 {
+			p.StatusBar = "No other features to link"
+			return nil
+		}
+		p.deps.adding = true
+		p.deps.candidate = 0
+	case "x", "delete", "backspace":
+		if p.deps.selected >= len(feature.Dependencies) {
+			return nil
+		}
+		removed := feature.Dependencies[p.deps.selected]
+		var dependencies []string
+		for _, id := range feature.Dependencies {
+			if id != removed {
+				dependencies = append(dependencies, id)
+			}
+		}
+		return p.saveDependencies(feature.ID, dependencies, "Unlinked "+p.featureName(removed), false)
+	case "esc", "ctrl+k":
+		p.deps = nil
+		p.StatusBar = ""
+	}
+	return nil
+}
+
+// jumpToFeature selects the feature with the given ID, clearing a status
+// filter that would hide it
+func (p *Prompt) jumpToFeature(featureID string) {
+	feature, ok := p.FeaturesData.FindFeature(featureID)
+	if !ok {
+		p.StatusBar = "Feature " + featureID + " no longer exists"
+		return
+	}
+	visible := false
+	for _, f := range p.visibleFeatures() {
+		visible = visible || f.ID == featureID
+	}
+	if !visible {
+		p.featureStatusFilter = ""
+	}
+	p.SelectedFeature = feature
+	p.deps = nil
+	p.mainPanelScroll = 0
+	p.StatusBar = "Jumped to " + feature.Name
+}
+
+// featureName returns the name of the feature with the given ID, or the ID
+// itself when it isn't listed
+func (p *Prompt) featureName(featureID string) string {
+	if feature, ok := p.FeaturesData.FindFeature(featureID); ok {
+		return feature.Name
+	}
+	return featureID
+}
+
+// saveDependencies writes a feature's dependency list via the update-feature tool
+func (p *Prompt) saveDependencies(featureID string, dependencies []string, message string, warning bool) tea.Cmd {
+	if dependencies == nil {
+		dependencies = []string{} // an empty list, not null, clears the links
+	}
+	return func() tea.Msg {
+		err := p.MCP.UpdateFeatureViaStdio(featureID, map[string]interface{}{"dependencies": dependencies})
+		return featureDependenciesSavedMsg{featureID: featureID, dependencies: dependencies, message: message, warning: warning, err: err}
+	}
+}
+
+// updateFeatureDependencies applies a saved dependency list to the listed feature
+func (p *Prompt) updateFeatureDependencies(msg tea.Msg) (tea.Cmd, bool) {
+	saved, ok := msg.(featureDependenciesSavedMsg)
+	if !ok {
+		return nil, false
+	}
+	if saved.err != nil {
+		return p.toastResult(saved.err, "Error saving dependencies", ""), true
+	}
+	if feature, ok := p.FeaturesData.FindFeature(saved.featureID); ok {
+		feature.Dependencies = saved.dependencies
+	}
+	// The selection may be a copy of the listed feature
+	if p.SelectedFeature != nil && p.SelectedFeature.ID == saved.featureID {
+		p.SelectedFeature.Dependencies = saved.dependencies
+	}
+	if p.deps != nil && p.deps.selected >= len(saved.dependencies) {
+		p.deps.selected = max(len(saved.dependencies)-1, 0)
+	}
+	if saved.warning {
+		return p.showToast(toastError, saved.message), true
+	}
+	return p.showToast(toastSuccess, saved.message), true
+}
+
+// dependenciesView renders the dependency list of the feature data panel,
+// with the selection and the feature picker while the editor is open
+func (p *Prompt) dependenciesView(feature *mcpclient.Feature) string {
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted))
+	value := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Value))
+	selected := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Bold(true)
+	editing := p.deps != nil && p.deps.featureID == feature.ID
+
+	var b strings.Builder
+	if len(feature.Dependencies) == 0 {
+		b.WriteString("  " + muted.Render("None") + "\n")
+	}
+	for i, id := range feature.Dependencies {
+		line := "→ " + p.featureName(id) + " (" + id + ")"
+		switch {
+		case editing && !p.deps.adding && i == p.deps.selected:
+			b.WriteString("› " + selected.Render(line) + "\n")
+		case func() bool { _, ok := p.FeaturesData.FindFeature(id); return !ok }():
+			b.WriteString("  " + lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Error)).Render(line+" missing") + "\n")
+		default:
+			b.WriteString("  " + value.Render(line) + "\n")
+		}
+	}
+
+	if !editing {
+		if p.focusState == 1 && !p.readOnly {
+			b.WriteString("  " + muted.Render("(ctrl+k to edit links)") + "\n")
+		}
+		return b.String()
+	}
+	if !p.deps.adding {
+		b.WriteString("  " + muted.Render("(enter open · a add · x remove · esc done)") + "\n")
+		return b.String()
+	}
+	b.WriteString("  " + muted.Render("Link to: (enter add · esc cancel)") + "\n")
+	for i, candidate := range p.dependencyCandidates() {
+		line := fmt.Sprintf("%s (%s)", candidate.Name, candidate.ID)
+		if i == p.deps.candidate {
+			b.WriteString("  › " + selected.Render(line) + "\n")
+		} else {
+			b.WriteString("    " + value.Render(line) + "\n")
+		}
+	}
+	return b.String()
+}
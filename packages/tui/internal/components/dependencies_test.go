@@ -0,0 +1,56 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFeatureDependencies_JumpLinkAndUnlink(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.SelectedFeature = &p.FeaturesData.Refinement[0] // notifications
+	p.focusState = 1
+	p.WindowWidth, p.WindowHeight = 120, 40
+	p.View()
+
+	p.Update(tea.KeyMsg{Type: tea.KeyCtrlK})
+	if p.deps == nil {
+		t.Fatal("Expected ctrl+k to open the dependency editor")
+	}
+	if view := p.generateFeatureDataContent(p.SelectedFeature); !strings.Contains(view, "Full-Text Search (search)") {
+		t.Errorf("Expected the linked features to be listed, got %q", view)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.SelectedFeature.ID != "user-auth" || p.deps != nil {
+		t.Fatalf("Expected enter to jump to user-auth and close the editor, got %s", p.SelectedFeature.ID)
+	}
+
+	// Linking user-auth to search closes search → user-auth
+	p.Update(tea.KeyMsg{Type: tea.KeyCtrlK})
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected adding a link to save it")
+	}
+	p.Update(cmd())
+	if got := p.SelectedFeature.Dependencies; len(got) != 1 || got[0] != "search" {
+		t.Fatalf("Expected user-auth to depend on search, got %v", got)
+	}
+	if p.toast == nil || p.toast.kind != toastError || !strings.Contains(p.toast.text, "user-auth → search → user-auth") {
+		t.Errorf("Expected a cycle warning, got %+v", p.toast)
+	}
+	data, _ := p.MCP.ListFeaturesViaStdio()
+	if deps := data.Approved[0].Dependencies; len(deps) != 1 {
+		t.Errorf("Expected the link to be persisted via MCP, got %v", deps)
+	}
+
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if cmd == nil {
+		t.Fatal("Expected removing a link to save it")
+	}
+	p.Update(cmd())
+	if len(p.SelectedFeature.Dependencies) != 0 {
+		t.Errorf("Expected the link to be removed, got %v", p.SelectedFeature.Dependencies)
+	}
+}
@@ -0,0 +1,236 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"tddpro/internal/auth"
+	"tddpro/internal/mcpclient"
+	"tddpro/internal/util"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// doctorCheckTimeout bounds each /doctor check
+const doctorCheckTimeout = 5 * time.Second
+
+// doctorCheck is one /doctor diagnostic. run reports what it found, or why
+// the check failed.
+type doctorCheck struct {
+	name string
+	hint string // how to fix a failure
+	run  func(ctx context.Context) (string, error)
+}
+
+type doctorResult struct {
+	name   string
+	detail string
+	hint   string
+	err    error
+}
+
+// doctorResultMsg carries the results of /doctor, in check order
+type doctorResultMsg struct {
+	results []doctorResult
+}
+
+// SetConfigCheck sets how /doctor validates the config files; check returns
+// the files it read. main's tui package owns the config format.
+func (p *Prompt) SetConfigCheck(check func() ([]string, error)) {
+	p.configCheck = check
+}
+
+// handleDoctor checks the whole environment at once: MCP server, backend,
+// SSE, credentials, project and config. The checks run concurrently, each
+// with its own timeout.
+func handleDoctor(p *Prompt, arg string) (*Prompt, tea.Cmd) {
+	p.textInput.SetValue("")
+	checks := p.doctorChecks()
+	p.StatusBar = fmt.Sprintf("Running %d checks...", len(checks))
+	return p, func() tea.Msg {
+		return doctorResultMsg{results: runDoctorChecks(checks, doctorCheckTimeout)}
+	}
+}
+
+// doctorChecks captures what the checks need so they can run off the event loop
+func (p *Prompt) doctorChecks() []doctorCheck {
+	apiURL := strings.TrimRight(p.APIURL, "/")
+	client := http.DefaultClient
+	var headers map[string]string
+	if p.MCP != nil {
+		client = p.MCP.HTTPClient()
+		headers = p.MCP.RequestHeaders()
+	}
+	checks := []doctorCheck{
+		{
+			name: "MCP server",
+			hint: "install TDD-Pro or set TDDPRO_MCP_PATH to the tdd-pro-mcp binary",
+			run: func(context.Context) (string, error) {
+				return mcpclient.GetMCPServerPath()
+			},
+		},
+		{
+			name: "Backend",
+			hint: "start the backend, or point --api-url or api in config.yml at it",
+			run: func(ctx context.Context) (string, error) {
+				if apiURL == "" {
+					return "", errors.New("no API URL set")
+				}
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+				if err != nil {
+					return "", err
+				}
+				for key, value := range headers {
+					req.Header.Set(key, value)
+				}
+				resp, err := client.Do(req)
+				if err != nil {
+					return "", err
+				}
+				resp.Body.Close()
+				return fmt.Sprintf("%s answered %s", apiURL, resp.Status), nil
+			},
+		},
+		{
+			name: "SSE",
+			hint: "check that no proxy buffers text/event-stream; replies fall back to polling otherwise",
+			run: func(ctx context.Context) (string, error) {
+				if apiURL == "" {
+					return "", errors.New("no API URL set")
+				}
+				sessionID, err := mcpclient.ProbeSSE(ctx, client, headers, apiURL)
+				if err != nil {
+					return "", err
+				}
+				return "sessionId " + sessionID, nil
+			},
+		},
+		{
+			name: "Credentials",
+			hint: "run /auth or set ANTHROPIC_API_KEY",
+			run: func(context.Context) (string, error) {
+				key, source, err := auth.ResolveAPIKey()
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%s (%s)", auth.MaskAPIKey(key), source), nil
+			},
+		},
+		{
+			name: "Project",
+			hint: "run /init here, or /init --repair if .tdd-pro is incomplete",
+			run:  checkProject,
+		},
+	}
+	if configCheck := p.configCheck; configCheck != nil {
+		checks = append(checks, doctorCheck{
+			name: "Config",
+			hint: "fix the YAML or remove keys TDD-Pro doesn't know",
+			run: func(context.Context) (string, error) {
+				files, err := configCheck()
+				if err != nil {
+					return "", err
+				}
+				if len(files) == 0 {
+					return "no config files, using defaults", nil
+				}
+				return strings.Join(files, ", "), nil
+			},
+		})
+	}
+	return checks
+}
+
+// checkProject looks for an initialized project above the working directory
+// with a readable features index
+func checkProject(context.Context) (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	dir := util.FindTddProDirectoryDefault(cwd)
+	// ~/.tdd-pro holds installed binaries, not a project
+	if dir == "" || dir == util.GetConfigDir() {
+		return "", fmt.Errorf("no .tdd-pro directory in %s or above", cwd)
+	}
+	if indexPath, missing := util.MissingFeaturesIndex(cwd); missing {
+		return "", fmt.Errorf("%s is missing", indexPath)
+	}
+	if indexPath := util.FeaturesIndexPath(cwd); indexPath != "" {
+		if _, err := util.LoadFeaturesIndex(indexPath); err != nil {
+			return "", fmt.Errorf("invalid features index: %w", err)
+		}
+	}
+	return dir, nil
+}
+
+// runDoctorChecks runs every check at once and waits for all of them
+func runDoctorChecks(checks []doctorCheck, timeout time.Duration) []doctorResult {
+	results := make([]doctorResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runDoctorCheck(check, timeout)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// runDoctorCheck gives up on a check after timeout, even one that doesn't
+// watch its context
+func runDoctorCheck(check doctorCheck, timeout time.Duration) doctorResult {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	type outcome struct {
+		detail string
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		detail, err := check.run(ctx)
+		done <- outcome{detail, err}
+	}()
+	result := doctorResult{name: check.name, hint: check.hint}
+	select {
+	case out := <-done:
+		result.detail, result.err = out.detail, out.err
+	case <-ctx.Done():
+		result.err = fmt.Errorf("timed out after %s", timeout)
+	}
+	return result
+}
+
+// updateDoctorResult lists each check as passed or failed, with a hint for
+// failures, and a summary last
+func (p *Prompt) updateDoctorResult(msg tea.Msg) (tea.Cmd, bool) {
+	doctor, ok := msg.(doctorResultMsg)
+	if !ok {
+		return nil, false
+	}
+	var lines []string
+	failed := 0
+	for _, result := range doctor.results {
+		if result.err != nil {
+			failed++
+			lines = append(lines, fmt.Sprintf("✗ %s: %v - %s", result.name, result.err, result.hint))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("✓ %s: %s", result.name, result.detail))
+	}
+	if failed == 0 {
+		lines = append(lines, fmt.Sprintf("All %d checks passed", len(doctor.results)))
+	} else {
+		lines = append(lines, fmt.Sprintf("%d of %d checks failed", failed, len(doctor.results)))
+	}
+	p.StatusBar = strings.Join(lines, "\n")
+	return nil, true
+}
@@ -0,0 +1,85 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDoctor_ReportsEachCheckWithHints(t *testing.T) {
+	p := newDemoPrompt(t)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	})
+	mux.HandleFunc("/sse", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: endpoint\ndata: /message?sessionId=doctor-1\n\n")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	p.APIURL = server.URL
+
+	mcpServer := filepath.Join(t.TempDir(), "tdd-pro-mcp")
+	if err := os.WriteFile(mcpServer, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("Failed to write the MCP server: %v", err)
+	}
+	t.Setenv("TDDPRO_MCP_PATH", mcpServer)
+	t.Setenv("ANTHROPIC_API_KEY", "sk-ant-REDACTED")
+	// A project whose features index is missing
+	project := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(project, ".tdd-pro"), 0755); err != nil {
+		t.Fatalf("Failed to create .tdd-pro: %v", err)
+	}
+	t.Chdir(project)
+	p.SetConfigCheck(func() ([]string, error) {
+		return nil, errors.New("config.yml: field colour not found")
+	})
+
+	_, cmd := handleDoctor(p, "")
+	p.Update(cmd())
+	for _, want := range []string{
+		"✓ MCP server: " + mcpServer,
+		"✓ Backend: " + server.URL + " answered 200 OK",
+		"✓ SSE: sessionId doctor-1",
+		"✓ Credentials: sk-ant-...1234 (ANTHROPIC_API_KEY)",
+		"✗ Project: " + filepath.Join(project, ".tdd-pro", "features", "index.yml") + " is missing",
+		"/init --repair",
+		"✗ Config: config.yml: field colour not found - fix the YAML",
+	} {
+		if !strings.Contains(p.StatusBar, want) {
+			t.Errorf("Expected %q in the report, got:\n%s", want, p.StatusBar)
+		}
+	}
+	if !strings.HasSuffix(p.StatusBar, "2 of 6 checks failed") {
+		t.Errorf("Expected a summary last, got:\n%s", p.StatusBar)
+	}
+}
+
+func TestRunDoctorChecks_TimesOutEachCheck(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	checks := []doctorCheck{
+		{name: "stuck", run: func(context.Context) (string, error) {
+			<-block // ignores its context
+			return "", nil
+		}},
+		{name: "quick", run: func(context.Context) (string, error) {
+			return "fine", nil
+		}},
+	}
+	results := runDoctorChecks(checks, 20*time.Millisecond)
+	if results[0].err == nil || !strings.Contains(results[0].err.Error(), "timed out") {
+		t.Errorf("Expected the stuck check to time out, got %+v", results[0])
+	}
+	if results[1].err != nil || results[1].detail != "fine" {
+		t.Errorf("Expected the quick check to pass, got %+v", results[1])
+	}
+}
@@ -0,0 +1,100 @@
+package components
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// SetEditor sets the command PRDs are edited with, e.g. "code --wait".
+// Empty falls back to $EDITOR; with neither, PRDs are edited inline.
+func (p *Prompt) SetEditor(editor string) {
+	p.editor = strings.TrimSpace(editor)
+}
+
+// editorCommand returns the configured editor command, otherwise $EDITOR
+func (p *Prompt) editorCommand() string {
+	if p.editor != "" {
+		return p.editor
+	}
+	return strings.TrimSpace(os.Getenv("EDITOR"))
+}
+
+// editorArgs splits an editor command such as `code --wait` into arguments
+// and checks that the program is on PATH
+func editorArgs(editor string) ([]string, error) {
+	args, err := splitCommandLine(editor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid editor command %q: %w", editor, err)
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no editor command set")
+	}
+	if _, err := exec.LookPath(args[0]); err != nil {
+		return nil, fmt.Errorf("editor %q not found on PATH", args[0])
+	}
+	return args, nil
+}
+
+// splitCommandLine splits s into words the way a POSIX shell would, without
+// expanding anything: single quotes keep text as is, double quotes allow \"
+// and \\, and a backslash outside quotes escapes the next character
+func splitCommandLine(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, r := range s {
+		switch {
+		case escaped:
+			// Inside double quotes other backslashes are kept, e.g. "C:\Program Files"
+			if quote == '"' && r != '"' && r != '\\' {
+				word.WriteRune('\\')
+			}
+			word.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case quote == '"':
+			switch r {
+			case '"':
+				quote = 0
+			case '\\':
+				escaped = true
+			default:
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == '\\':
+			escaped = true
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,58 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	cases := map[string][]string{
+		"code --wait":                      {"code", "--wait"},
+		"  vim  ":                          {"vim"},
+		`"/opt/Sublime Text/subl" -w`:      {"/opt/Sublime Text/subl", "-w"},
+		`emacs --eval '(setq x "y")'`:      {"emacs", "--eval", `(setq x "y")`},
+		`my\ editor "say \"hi\"" "C:\bin"`: {"my editor", `say "hi"`, `C:\bin`},
+		`''`:                               {""},
+		"":                                 nil,
+	}
+	for input, want := range cases {
+		got, err := splitCommandLine(input)
+		if err != nil || !slices.Equal(got, want) {
+			t.Errorf("splitCommandLine(%q) = %q (err %v), want %q", input, got, err, want)
+		}
+	}
+	for _, input := range []string{`code "--wait`, `vim 'x`, `vim \`} {
+		if _, err := splitCommandLine(input); err == nil {
+			t.Errorf("Expected %q to be rejected", input)
+		}
+	}
+}
+
+func TestStartPRDEdit_UsesConfiguredEditorOrFallsBackInline(t *testing.T) {
+	p := newDemoPrompt(t)
+	t.Setenv("EDITOR", "")
+
+	// An editor that isn't on PATH falls back to inline editing
+	p.SetEditor("no-such-editor-tdd-pro --wait")
+	p.startPRDEdit()
+	if !p.editingPRD || !strings.Contains(p.StatusBar, `editor "no-such-editor-tdd-pro" not found on PATH`) || !strings.Contains(p.StatusBar, "inline") {
+		t.Fatalf("Expected an inline fallback explaining the missing editor, got %q", p.StatusBar)
+	}
+	p.editingPRD = false
+
+	// A configured command on PATH is launched, arguments and all
+	dir := t.TempDir()
+	editor := filepath.Join(dir, "fake-editor")
+	if err := os.WriteFile(editor, []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+		t.Fatalf("Failed to write the fake editor: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	p.SetEditor("fake-editor --wait")
+	_, cmd := p.startPRDEdit()
+	if cmd == nil || p.editingPRD || p.StatusBar != "Opening fake-editor..." {
+		t.Fatalf("Expected the configured editor to be launched, got %q", p.StatusBar)
+	}
+}
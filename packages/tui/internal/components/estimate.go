@@ -0,0 +1,80 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"tddpro/internal/mcpclient"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// formatEstimate renders an estimate without trailing zeros, e.g. "3" or "1.5"
+func formatEstimate(estimate float64) string {
+	return strconv.FormatFloat(estimate, 'f', -1, 64)
+}
+
+// parseEstimate reads the task form's estimate field. Blank means not
+// estimated (0).
+func parseEstimate(text string) (float64, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, nil
+	}
+	estimate, err := strconv.ParseFloat(text, 64)
+	if err != nil || estimate < 0 || math.IsInf(estimate, 0) || math.IsNaN(estimate) {
+		return 0, fmt.Errorf("estimate must be a number of points or hours, e.g. 3 or 1.5")
+	}
+	return estimate, nil
+}
+
+// estimateBadge renders a task's estimate, "" when it has none
+func (p *Prompt) estimateBadge(task mcpclient.Task) string {
+	if task.Estimate <= 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render("est " + formatEstimate(task.Estimate))
+}
+
+// estimateRollup totals a feature's task estimates from cached details only,
+// like taskProgress. estimated counts the tasks that have one.
+func (p *Prompt) estimateRollup(featureID string) (total float64, estimated, tasks int) {
+	if p.featureDetails == nil {
+		return 0, 0, 0
+	}
+	detail, ok := p.featureDetails.peek(featureID)
+	if !ok {
+		return 0, 0, 0
+	}
+	for _, task := range detail.Tasks {
+		if task.Estimate > 0 {
+			total += task.Estimate
+			estimated++
+		}
+	}
+	return total, estimated, len(detail.Tasks)
+}
+
+// estimateRollupView renders the feature's total estimate for the data
+// panel, "" when none of its tasks is estimated
+func (p *Prompt) estimateRollupView(featureID string) string {
+	total, estimated, tasks := p.estimateRollup(featureID)
+	if estimated == 0 {
+		return ""
+	}
+	view := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Text)).Render(formatEstimate(total))
+	if estimated < tasks {
+		view += lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render(fmt.Sprintf(" (%d of %d tasks estimated)", estimated, tasks))
+	}
+	return view
+}
+
+// estimateText is an estimate as the task form shows it, blank for none
+func estimateText(estimate float64) string {
+	if estimate <= 0 {
+		return ""
+	}
+	return formatEstimate(estimate)
+}
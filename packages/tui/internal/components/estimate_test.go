@@ -0,0 +1,63 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTaskEstimate_SavedRenderedAndRolledUp(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.focusState, p.FeaturesTab = 2, 1
+	p.WindowWidth, p.WindowHeight = 120, 40
+
+	if content := p.generateFeatureDataContent(p.SelectedFeature); strings.Contains(content, "Estimate:") {
+		t.Errorf("Expected no rollup without estimates, got %q", content)
+	}
+	if view := p.renderTasksForFeature(p.SelectedFeature); strings.Contains(view, "· est") {
+		t.Errorf("Expected no estimate badges, got %q", view)
+	}
+
+	_, cmd := p.Update(TaskEditCompleteMsg{Title: "Hash passwords with bcrypt", Description: "Store only bcrypt hashes of user passwords.", Criteria: []string{"Plaintext passwords are never persisted"}, Estimate: 2.5})
+	runMessageCmds(p, cmd)
+	detail, _ := p.MCP.GetFeatureViaStdio(p.SelectedFeature.ID)
+	if detail.Tasks[0].Estimate != 2.5 {
+		t.Fatalf("Expected the estimate saved, got %v", detail.Tasks[0].Estimate)
+	}
+	if view := p.renderTasksForFeature(p.SelectedFeature); !strings.Contains(view, "Task 1: Hash passwords with bcrypt · est 2.5") || strings.Count(view, "· est") != 1 {
+		t.Errorf("Expected the estimate in task 1's header only, got %q", view)
+	}
+	p.compactTasks = true
+	if view := p.renderTasksForFeature(p.SelectedFeature); !strings.Contains(view, "1/1 tests est 2.5") {
+		t.Errorf("Expected the estimate in the compact badge, got %q", view)
+	}
+	if content := p.generateFeatureDataContent(p.SelectedFeature); !strings.Contains(content, "Estimate: 2.5 (1 of 3 tasks estimated)") {
+		t.Errorf("Expected the rollup in the data panel, got %q", content)
+	}
+
+	// The form shows the estimate and /undo restores the previous one
+	p.startTaskEdit()
+	if p.taskEditForm.estimate != "2.5" || p.taskEditForm.dirty() {
+		t.Errorf("Expected the form to open with the estimate, got %q", p.taskEditForm.estimate)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	_, cmd = handleUndo(p, "")
+	p.Update(cmd())
+	if detail, _ := p.MCP.GetFeatureViaStdio(p.SelectedFeature.ID); detail.Tasks[0].Estimate != 0 {
+		t.Errorf("Expected /undo to clear the estimate, got %v", detail.Tasks[0].Estimate)
+	}
+}
+
+func TestParseEstimate(t *testing.T) {
+	for text, want := range map[string]float64{"": 0, "  ": 0, "3": 3, " 1.5 ": 1.5, "0": 0} {
+		if got, err := parseEstimate(text); err != nil || got != want {
+			t.Errorf("parseEstimate(%q) = %v (err %v), want %v", text, got, err, want)
+		}
+	}
+	for _, text := range []string{"-1", "three", "NaN", "Inf"} {
+		if _, err := parseEstimate(text); err == nil {
+			t.Errorf("Expected %q to be rejected", text)
+		}
+	}
+}
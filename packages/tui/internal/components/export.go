@@ -0,0 +1,197 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"tddpro/internal/mcpclient"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// featureExportedMsg reports the result of /export
+type featureExportedMsg struct {
+	path string
+	err  error
+}
+
+// featureSlug turns a name into a kebab-case ID, e.g. "User Authentication"
+// becomes "user-authentication". It's "" when the name has no letters or digits.
+func featureSlug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// exportFileName names the export of a feature, e.g. "user-authentication.md"
+func exportFileName(feature mcpclient.Feature) string {
+	name := featureSlug(feature.Name)
+	if name == "" {
+		name = feature.ID
+	}
+	return name + ".md"
+}
+
+// exportMarkdown combines a feature's metadata, PRD and tasks into one document
+func exportMarkdown(feature mcpclient.Feature, detail *mcpclient.FeatureDetail, prd string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", feature.Name)
+	fmt.Fprintf(&b, "- ID: `%s`\n", feature.ID)
+	if feature.Status != "" {
+		fmt.Fprintf(&b, "- Status: %s\n", feature.Status)
+	}
+	if len(feature.Dependencies) > 0 {
+		fmt.Fprintf(&b, "- Depends on: %s\n", strings.Join(feature.Dependencies, ", "))
+	}
+	if !feature.CreatedAt.IsZero() {
+		fmt.Fprintf(&b, "- Created: %s\n", feature.CreatedAt.Format("2006-01-02 15:04"))
+	}
+	if !feature.UpdatedAt.IsZero() {
+		fmt.Fprintf(&b, "- Updated: %s\n", feature.UpdatedAt.Format("2006-01-02 15:04"))
+	}
+	if description := strings.TrimSpace(feature.Description); description != "" {
+		fmt.Fprintf(&b, "\n%s\n", description)
+	}
+
+	if prd = strings.TrimSpace(prd); prd != "" {
+		fmt.Fprintf(&b, "\n---\n\n%s\n", prd)
+	}
+
+	b.WriteString("\n---\n\n## Tasks\n")
+	if detail == nil || len(detail.Tasks) == 0 {
+		b.WriteString("\nNo tasks yet.\n")
+		return b.String()
+	}
+	for i, task := range detail.Tasks {
+		status := task.Status
+		if status == "" {
+			status = "pending"
+		}
+		fmt.Fprintf(&b, "\n### %d. %s (%s)\n", i+1, task.Title, status)
+		if description := strings.TrimSpace(task.Description); description != "" {
+			fmt.Fprintf(&b, "\n%s\n", description)
+		}
+		if len(task.EvaluationCriteria) > 0 {
+			b.WriteString("\nCriteria:\n")
+			for _, criterion := range task.EvaluationCriteria {
+				fmt.Fprintf(&b, "- %s\n", criterion)
+			}
+		}
+	}
+	return b.String()
+}
+
+// handleExport writes a feature's PRD and tasks to a Markdown file:
+// /export [featureId] [path] [--force]. The feature defaults to the selected
+// one and the path to ./<feature-name>.md. An existing file is only
+// overwritten with --force.
+func handleExport(p *Prompt, arg string) (*Prompt, tea.Cmd) {
+	p.textInput.SetValue("")
+	if p.MCP == nil {
+		p.StatusBar = "MCP client not available"
+		return p, nil
+	}
+	force := false
+	var words []string
+	for _, field := range strings.Fields(arg) {
+		if field == "--force" {
+			force = true
+		} else {
+			words = append(words, field)
+		}
+	}
+	featureID, path := "", ""
+	if len(words) > 0 {
+		featureID, path = words[0], strings.Join(words[1:], " ")
+	}
+	if featureID == "" && p.SelectedFeature != nil {
+		featureID = p.SelectedFeature.ID
+	}
+	if featureID == "" {
+		p.StatusBar = "Usage: /export <featureId> [path] [--force]"
+		return p, nil
+	}
+	feature, ok := p.FeaturesData.FindFeature(featureID)
+	if !ok {
+		p.StatusBar = "Unknown feature: " + featureID
+		return p, nil
+	}
+	if path = strings.TrimSpace(path); path == "" {
+		path = "./" + exportFileName(*feature)
+	}
+
+	client := p.MCP
+	exported := *feature
+	p.StatusBar = "Exporting " + exported.Name + "..."
+	return p, func() tea.Msg {
+		detail, err := client.GetFeatureViaStdio(exported.ID)
+		if err != nil {
+			return featureExportedMsg{path: path, err: fmt.Errorf("loading tasks: %w", err)}
+		}
+		prd, err := client.GetFeatureDocumentViaStdio(exported.ID)
+		if err != nil {
+			return featureExportedMsg{path: path, err: fmt.Errorf("loading PRD: %w", err)}
+		}
+		err = writeNewFile(path, []byte(exportMarkdown(exported, detail, prd)), 0644, force)
+		return featureExportedMsg{path: path, err: err}
+	}
+}
+
+// writeNewFile writes data to path, refusing to replace an existing file
+// unless force is set. /export and /save-transcript both write this way.
+func writeNewFile(path string, data []byte, perm os.FileMode, force bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(path, flags, perm)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// updateFeatureExport reports where /export wrote the feature
+func (p *Prompt) updateFeatureExport(msg tea.Msg) (tea.Cmd, bool) {
+	exported, ok := msg.(featureExportedMsg)
+	if !ok {
+		return nil, false
+	}
+	if exported.err != nil {
+		p.StatusBar = "Export failed: " + writeFailure(exported.path, exported.err)
+	} else {
+		p.StatusBar = "Exported to " + exported.path
+	}
+	return nil, true
+}
+
+// writeFailure explains why writing the file at path failed, naming the
+// common causes plainly
+func writeFailure(path string, err error) string {
+	switch {
+	case errors.Is(err, fs.ErrExist):
+		return path + " already exists (add --force to overwrite it)"
+	case errors.Is(err, fs.ErrPermission):
+		return "no permission to write " + path
+	case errors.Is(err, fs.ErrNotExist):
+		return "directory " + filepath.Dir(path) + " doesn't exist"
+	}
+	return err.Error()
+}
@@ -0,0 +1,60 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tddpro/internal/mcpclient"
+)
+
+func TestExport_WritesFeatureMarkdown(t *testing.T) {
+	p := newDemoPrompt(t)
+	path := filepath.Join(t.TempDir(), "spec.md")
+
+	_, cmd := handleExport(p, "user-auth "+path)
+	if cmd == nil {
+		t.Fatalf("Expected an export command, status %q", p.StatusBar)
+	}
+	p.Update(cmd())
+	if p.StatusBar != "Exported to "+path {
+		t.Fatalf("Expected the written path in the status bar, got %q", p.StatusBar)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Export file missing: %v", err)
+	}
+	doc := string(data)
+	for _, want := range []string{"# User Authentication", "- Status: approved", "## Tasks", "### 1. Hash passwords with bcrypt (completed)", "Criteria:"} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("Expected %q in export:\n%s", want, doc)
+		}
+	}
+
+	// An existing file is only replaced with --force
+	os.WriteFile(path, []byte("keep me"), 0644)
+	_, cmd = handleExport(p, "user-auth "+path)
+	p.Update(cmd())
+	if data, _ := os.ReadFile(path); string(data) != "keep me" || !strings.Contains(p.StatusBar, "already exists") {
+		t.Errorf("Expected the existing file kept, got %q and %q", data, p.StatusBar)
+	}
+	_, cmd = handleExport(p, "user-auth --force "+path)
+	p.Update(cmd())
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "# User Authentication") || p.StatusBar != "Exported to "+path {
+		t.Errorf("Expected --force to overwrite the file, got %q", p.StatusBar)
+	}
+
+	_, cmd = handleExport(p, "user-auth "+filepath.Join(t.TempDir(), "missing", "spec.md"))
+	p.Update(cmd())
+	if !strings.HasPrefix(p.StatusBar, "Export failed: directory") {
+		t.Errorf("Expected a friendly write error, got %q", p.StatusBar)
+	}
+
+	if _, cmd = handleExport(p, "nope"); cmd != nil || p.StatusBar != "Unknown feature: nope" {
+		t.Errorf("Expected unknown features to be refused, got %q", p.StatusBar)
+	}
+	if got := exportFileName(mcpclient.Feature{ID: "x", Name: "User Authentication!"}); got != "user-authentication.md" {
+		t.Errorf("exportFileName = %q", got)
+	}
+}
@@ -0,0 +1,289 @@
+package components
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"tddpro/internal/mcpclient"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// featureDetailTTL is how long a cached feature detail is used before it's refetched
+const featureDetailTTL = 30 * time.Second
+
+// featureCache holds feature details by ID so navigating the features view
+// doesn't start an MCP server process per selection. It's shared with the
+// goroutines that save edits, hence the mutex.
+type featureCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedFeature
+	now     func() time.Time
+}
+
+type cachedFeature struct {
+	detail  *mcpclient.FeatureDetail
+	fetched time.Time
+}
+
+func newFeatureCache() *featureCache {
+	return &featureCache{entries: map[string]cachedFeature{}, now: time.Now}
+}
+
+// get returns a cached detail that hasn't expired
+func (c *featureCache) get(featureID string) (*mcpclient.FeatureDetail, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[featureID]
+	if !ok || c.now().Sub(entry.fetched) > featureDetailTTL {
+		return nil, false
+	}
+	return entry.detail, true
+}
+
+// peek returns a cached detail however old it is
+func (c *featureCache) peek(featureID string) (*mcpclient.FeatureDetail, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[featureID]
+	return entry.detail, ok
+}
+
+func (c *featureCache) put(detail *mcpclient.FeatureDetail) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[detail.ID] = cachedFeature{detail: detail, fetched: c.now()}
+}
+
+// invalidate drops one feature, or every feature when featureID is ""
+func (c *featureCache) invalidate(featureID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if featureID == "" {
+		c.entries = map[string]cachedFeature{}
+		return
+	}
+	delete(c.entries, featureID)
+}
+
+// featureDetail returns a feature's tasks from the cache, fetching it when missing or stale
+func (p *Prompt) featureDetail(featureID string) (*mcpclient.FeatureDetail, error) {
+	if detail, ok := p.featureDetails.get(featureID); ok {
+		return detail, nil
+	}
+	detail, err := p.MCP.GetFeatureViaStdio(featureID)
+	if err != nil {
+		return nil, err
+	}
+	detail.ID = featureID
+	p.featureDetails.put(detail)
+	return detail, nil
+}
+
+// errTasksLoading is returned by tasksFor while a feature's tasks are being
+// fetched in the background
+var errTasksLoading = errors.New("tasks are loading")
+
+// tasksLoadedMsg delivers a feature's tasks fetched by fetchTasks
+type tasksLoadedMsg struct {
+	featureID string
+	detail    *mcpclient.FeatureDetail
+	err       error
+}
+
+// tasksFor returns a feature's tasks for rendering, scrolling and selection.
+// It never fetches: a cached detail is used however old it is, and a feature
+// that hasn't been loaded yet reports errTasksLoading (or why its load failed)
+// until fetchTasks delivers it. Stale details are refetched by loadTasks when
+// the Tasks tab is entered, and edits and ctrl+r invalidate them.
+func (p *Prompt) tasksFor(featureID string) (*mcpclient.FeatureDetail, error) {
+	if detail, ok := p.featureDetails.peek(featureID); ok {
+		return detail, nil
+	}
+	if err := p.taskLoadErrs[featureID]; err != nil {
+		return nil, err
+	}
+	return nil, errTasksLoading
+}
+
+// loadTasks restores the task last selected in the selected feature and
+// refetches its tasks in the background when the cached ones have expired.
+// It's called on entering the Tasks tab, so a failed load is retried then.
+func (p *Prompt) loadTasks() tea.Cmd {
+	if p.SelectedFeature == nil || p.MCP == nil {
+		return nil
+	}
+	p.syncTaskSelection()
+	if _, ok := p.featureDetails.get(p.SelectedFeature.ID); ok {
+		return nil
+	}
+	delete(p.taskLoadErrs, p.SelectedFeature.ID)
+	return p.fetchTasks(p.SelectedFeature.ID)
+}
+
+// loadVisibleTasks starts fetching the selected feature's tasks when the
+// Tasks tab is showing and they've never been loaded, e.g. after moving to
+// another feature or an edit invalidated them
+func (p *Prompt) loadVisibleTasks() tea.Cmd {
+	if !p.FeaturesViewActive || p.FeaturesTab != 1 || p.SelectedFeature == nil || p.MCP == nil {
+		return nil
+	}
+	featureID := p.SelectedFeature.ID
+	if _, ok := p.featureDetails.peek(featureID); ok || p.taskLoadErrs[featureID] != nil {
+		return nil
+	}
+	return p.fetchTasks(featureID)
+}
+
+// fetchTasks fetches a feature's tasks off the event loop, animating the
+// spinner until they arrive. A feature already being fetched isn't fetched
+// again.
+func (p *Prompt) fetchTasks(featureID string) tea.Cmd {
+	if p.tasksLoading[featureID] {
+		return nil
+	}
+	if p.tasksLoading == nil {
+		p.tasksLoading = map[string]bool{}
+	}
+	var tick tea.Cmd
+	if !p.spinnerActive() {
+		tick = p.spinner.Tick
+	}
+	p.tasksLoading[featureID] = true
+	client := p.MCP
+	return tea.Batch(tick, func() tea.Msg {
+		detail, err := client.GetFeatureViaStdio(featureID)
+		return tasksLoadedMsg{featureID: featureID, detail: detail, err: err}
+	})
+}
+
+// updateTasksLoaded caches tasks delivered by fetchTasks, or remembers why
+// they couldn't be loaded
+func (p *Prompt) updateTasksLoaded(msg tea.Msg) bool {
+	loaded, ok := msg.(tasksLoadedMsg)
+	if !ok {
+		return false
+	}
+	delete(p.tasksLoading, loaded.featureID)
+	if loaded.err != nil {
+		slog.Debug("loading tasks failed", "feature", loaded.featureID, "err", loaded.err)
+		if p.taskLoadErrs == nil {
+			p.taskLoadErrs = map[string]error{}
+		}
+		p.taskLoadErrs[loaded.featureID] = loaded.err
+		return true
+	}
+	loaded.detail.ID = loaded.featureID
+	p.featureDetails.put(loaded.detail)
+	delete(p.taskLoadErrs, loaded.featureID)
+	if p.SelectedFeature != nil && p.SelectedFeature.ID == loaded.featureID {
+		// The selection was restored before the task count was known
+		if p.selectedTaskIndex >= len(loaded.detail.Tasks) {
+			p.selectedTaskIndex = max(len(loaded.detail.Tasks)-1, 0)
+		}
+		if p.focusState == 2 {
+			p.snapTaskSelection()
+		}
+	}
+	return true
+}
+
+// syncTaskSelection remembers the selected task of the feature it belonged
+// to and selects the one last used in the selected feature, clamped to its
+// current task count
+func (p *Prompt) syncTaskSelection() {
+	if p.SelectedFeature == nil || p.SelectedFeature.ID == p.taskIndexFeature {
+		return
+	}
+	if p.taskSelections == nil {
+		p.taskSelections = map[string]int{}
+	}
+	if p.taskIndexFeature != "" {
+		p.taskSelections[p.taskIndexFeature] = p.selectedTaskIndex
+	}
+	p.taskIndexFeature = p.SelectedFeature.ID
+	p.selectedTaskIndex = p.taskSelections[p.taskIndexFeature]
+	if detail, ok := p.featureDetails.peek(p.taskIndexFeature); ok && p.selectedTaskIndex >= len(detail.Tasks) {
+		p.selectedTaskIndex = max(len(detail.Tasks)-1, 0)
+	}
+}
+
+// featureDetailsFetchedMsg carries feature details batch fetched in the background
+type featureDetailsFetchedMsg struct {
+	details map[string]*mcpclient.FeatureDetail
+	err     error
+	refresh bool // fetched for ctrl+r, which reports the outcome
+}
+
+// featureIDs lists the IDs of every listed feature
+func featureIDs(data *mcpclient.FeaturesData) []string {
+	var ids []string
+	for _, group := range [][]mcpclient.Feature{data.Approved, data.Planned, data.Refinement, data.Backlog} {
+		for _, feature := range group {
+			ids = append(ids, feature.ID)
+		}
+	}
+	return ids
+}
+
+// prefetchFeatureDetails loads every listed feature with one batch call off
+// the event loop so the first visit to each feature is served from the cache
+func (p *Prompt) prefetchFeatureDetails() tea.Cmd {
+	return p.fetchFeatureDetails(false)
+}
+
+// refreshFeatureDetails drops cached details and refetches them, for when the
+// project changed outside the TUI
+func (p *Prompt) refreshFeatureDetails() tea.Cmd {
+	p.featureDetails.invalidate("")
+	p.taskLoadErrs = nil
+	p.prds.invalidate("")
+	cmd := p.fetchFeatureDetails(true)
+	if cmd == nil {
+		p.StatusBar = "Feature details refreshed"
+		return nil
+	}
+	p.StatusBar = "Refreshing feature details..."
+	return cmd
+}
+
+// fetchFeatureDetails batch fetches the listed features' details, or returns nil when there's nothing to fetch
+func (p *Prompt) fetchFeatureDetails(refresh bool) tea.Cmd {
+	ids := featureIDs(&p.FeaturesData)
+	if len(ids) == 0 || p.MCP == nil {
+		return nil
+	}
+	client := p.MCP
+	return func() tea.Msg {
+		details, err := client.GetFeaturesViaStdio(ids)
+		return featureDetailsFetchedMsg{details: details, err: err, refresh: refresh}
+	}
+}
+
+// updateFeatureDetailsFetched caches batch fetched details, reporting how a
+// ctrl+r refresh went. After a failed prefetch details are still fetched one
+// at a time as features are visited.
+func (p *Prompt) updateFeatureDetailsFetched(msg tea.Msg) (tea.Cmd, bool) {
+	fetched, ok := msg.(featureDetailsFetchedMsg)
+	if !ok {
+		return nil, false
+	}
+	if fetched.err != nil {
+		if fetched.refresh {
+			p.StatusBar = "Error refreshing features: " + errorText(fetched.err)
+		} else {
+			slog.Warn("feature prefetch failed", "err", fetched.err)
+		}
+		return nil, true
+	}
+	for id, detail := range fetched.details {
+		detail.ID = id
+		p.featureDetails.put(detail)
+	}
+	if fetched.refresh {
+		p.StatusBar = "Feature details refreshed"
+	}
+	return nil, true
+}
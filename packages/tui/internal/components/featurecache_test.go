@@ -0,0 +1,176 @@
+package components
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tddpro/internal/mcpclient"
+)
+
+func TestFeatureDetails_PrefetchedAndCached(t *testing.T) {
+	t.Chdir(t.TempDir())
+	client := &countingClient{DemoClient: mcpclient.NewDemoClient()}
+	prompt := NewPromptWithClient(client, "http://localhost:4111", "test")
+	p := &prompt
+
+	// The listing and prefetch run as a command, not inside Update
+	_, cmd := handleFeatures(p, "")
+	if client.batch != 0 || !strings.Contains(p.View(), "Loading features") {
+		t.Fatalf("Expected /features to load in the background, got %d fetches", client.batch)
+	}
+	runMessageCmds(p, cmd)
+	if client.batch != 1 {
+		t.Fatalf("Expected one batch fetch on /features, got %d", client.batch)
+	}
+	for i := 0; i < 4; i++ {
+		p.moveFeatureSelection(1)
+		if _, err := p.featureDetail(p.SelectedFeature.ID); err != nil {
+			t.Fatalf("featureDetail failed: %v", err)
+		}
+	}
+	if client.single != 0 {
+		t.Errorf("Expected navigation to be served from the cache, got %d fetches", client.single)
+	}
+
+	// Expired entries are refetched
+	start := time.Now()
+	p.featureDetails.now = func() time.Time { return start.Add(featureDetailTTL + time.Second) }
+	if _, err := p.featureDetail(p.SelectedFeature.ID); err != nil {
+		t.Fatalf("featureDetail failed: %v", err)
+	}
+	if client.single != 1 {
+		t.Errorf("Expected a stale entry to be refetched once, got %d fetches", client.single)
+	}
+
+	cmd = p.refreshFeatureDetails()
+	if client.batch != 1 || p.StatusBar != "Refreshing feature details..." {
+		t.Errorf("Expected ctrl+r to fetch in the background, got %d fetches", client.batch)
+	}
+	runMessageCmds(p, cmd)
+	if client.batch != 2 || p.StatusBar != "Feature details refreshed" {
+		t.Errorf("Expected manual refresh to batch fetch again, got %d", client.batch)
+	}
+}
+
+func TestTasks_FetchedOnEnteringTabNotPerFrame(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.WindowWidth, p.WindowHeight = 120, 40
+	client := &countingClient{DemoClient: mcpclient.NewDemoClient()}
+	p.MCP = client
+	p.featureDetails.invalidate("")
+	clock := time.Now()
+	p.featureDetails.now = func() time.Time { return clock }
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if view := p.renderTasksForFeature(p.SelectedFeature); client.single != 0 || !strings.Contains(view, "Loading tasks…") {
+		t.Errorf("Expected a placeholder while the tasks are fetched in the background, got %d fetches and %q", client.single, view)
+	}
+	runMessageCmds(p, cmd)
+	if client.single != 1 {
+		t.Fatalf("Expected one fetch on entering the Tasks tab, got %d", client.single)
+	}
+	if view := p.renderTasksForFeature(p.SelectedFeature); !strings.Contains(view, "Hash passwords") {
+		t.Errorf("Expected the tasks once they arrive, got %q", view)
+	}
+
+	// Long after the cache expired, drawing and moving don't fetch again
+	clock = clock.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		p.View()
+		p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	if client.single != 1 {
+		t.Errorf("Expected rendering and selection to reuse the tasks, got %d fetches", client.single)
+	}
+
+	// Re-entering the tab refreshes the stale tasks once
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	p.View()
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if view := p.renderTasksForFeature(p.SelectedFeature); !strings.Contains(view, "Hash passwords") {
+		t.Errorf("Expected the stale tasks shown while they're refetched, got %q", view)
+	}
+	runMessageCmds(p, cmd)
+	if client.single != 2 {
+		t.Errorf("Expected one refetch on re-entering the tab, got %d fetches", client.single)
+	}
+}
+
+func TestTaskSelection_RememberedPerFeature(t *testing.T) {
+	p := newDemoPrompt(t)
+	key := func(keys ...string) {
+		for _, k := range keys {
+			switch k {
+			case "up", "down", "left":
+				p.Update(tea.KeyMsg{Type: map[string]tea.KeyType{"up": tea.KeyUp, "down": tea.KeyDown, "left": tea.KeyLeft}[k]})
+			default:
+				p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+			}
+		}
+	}
+
+	key("t", "down", "down")
+	if p.selectedTaskIndex != 2 {
+		t.Fatalf("Expected the third user-auth task selected, got %d", p.selectedTaskIndex)
+	}
+
+	key("left", "left", "down", "t")
+	if p.SelectedFeature.ID != "search" || p.selectedTaskIndex != 0 {
+		t.Fatalf("Expected search's first task, got %s task %d", p.SelectedFeature.ID, p.selectedTaskIndex)
+	}
+
+	key("left", "left", "up", "t")
+	if p.SelectedFeature.ID != "user-auth" || p.selectedTaskIndex != 2 {
+		t.Errorf("Expected user-auth's third task restored, got %s task %d", p.SelectedFeature.ID, p.selectedTaskIndex)
+	}
+
+	// A remembered task that no longer exists is clamped to the last one
+	p.taskSelections["search"] = 5
+	key("left", "left", "down", "t")
+	if p.selectedTaskIndex != 0 {
+		t.Errorf("Expected the selection clamped to search's only task, got %d", p.selectedTaskIndex)
+	}
+}
+
+func TestTasks_LoadedInBackgroundAsSelectionMoves(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.WindowWidth, p.WindowHeight = 120, 40
+	client := &countingClient{DemoClient: mcpclient.NewDemoClient()}
+	p.MCP = client
+	p.featureDetails.invalidate("")
+	p.FeaturesTab = 1
+
+	// Moving to another feature with the Tasks tab showing fetches its tasks
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if view := p.renderTasksForFeature(p.SelectedFeature); !strings.Contains(view, "Loading tasks…") {
+		t.Fatalf("Expected a loading placeholder, got %q", view)
+	}
+	runMessageCmds(p, cmd)
+	if view := p.renderTasksForFeature(p.SelectedFeature); client.single != 1 || strings.Contains(view, "Loading") {
+		t.Errorf("Expected one fetch to replace the placeholder, got %d and %q", client.single, view)
+	}
+
+	// A failed load is shown, not retried every frame, and retried on entering the tab
+	client.fail = errors.New("server exited")
+	p.featureDetails.invalidate("")
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	runMessageCmds(p, cmd)
+	if view := p.renderTasksForFeature(p.SelectedFeature); !strings.Contains(view, "Error loading tasks: server exited") {
+		t.Errorf("Expected the load error, got %q", view)
+	}
+	_, cmd = p.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	runMessageCmds(p, cmd)
+	if client.single != 2 {
+		t.Errorf("Expected no retry until the tab is entered, got %d fetches", client.single)
+	}
+	client.fail = nil
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	runMessageCmds(p, cmd)
+	if view := p.renderTasksForFeature(p.SelectedFeature); client.single != 3 || strings.Contains(view, "Error") {
+		t.Errorf("Expected entering the tab to retry, got %d fetches and %q", client.single, view)
+	}
+}
@@ -0,0 +1,156 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"tddpro/internal/mcpclient"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// featureCreateForm asks for the name and description of a new feature,
+// which is created in refinement
+type featureCreateForm struct {
+	form        *huh.Form
+	name        string
+	description string
+}
+
+// featureCreatedMsg reports the result of creating a feature with n
+type featureCreatedMsg struct {
+	feature mcpclient.Feature
+	err     error
+}
+
+// startFeatureCreate opens the new feature form
+func (p *Prompt) startFeatureCreate() (*Prompt, tea.Cmd) {
+	if p.blockedByReadOnly() {
+		return p, nil
+	}
+	if p.MCP == nil {
+		p.StatusBar = "MCP client not available"
+		return p, nil
+	}
+	f := &featureCreateForm{}
+	f.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Key("name").
+				Title("Feature Name").
+				Value(&f.name).
+				Placeholder("e.g. User Authentication").
+				Validate(func(s string) error {
+					if featureSlug(s) == "" {
+						return errors.New("enter a name")
+					}
+					return nil
+				}),
+
+			huh.NewText().
+				Key("description").
+				Title("Description").
+				Value(&f.description).
+				Placeholder("What the feature does and why, in a few sentences...").
+				Lines(5).
+				Validate(func(s string) error {
+					if n := len(strings.TrimSpace(s)); n < minFeatureDescription {
+						return fmt.Errorf("at least %d characters (%d so far)", minFeatureDescription, n)
+					}
+					return nil
+				}),
+		),
+	).
+		WithTheme(huh.ThemeDracula()).
+		WithShowHelp(true).
+		WithShowErrors(true)
+	p.featureForm = f
+	p.StatusBar = "New feature: it starts in refinement (esc to cancel)"
+	return p, f.form.Init()
+}
+
+// updateFeatureForm passes keys to the new feature form and creates the
+// feature once it's filled in
+func (p *Prompt) updateFeatureForm(msg tea.Msg) tea.Cmd {
+	f := p.featureForm
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "esc" {
+		p.featureForm = nil
+		p.StatusBar = "New feature cancelled"
+		return nil
+	}
+	form, cmd := f.form.Update(msg)
+	if updated, ok := form.(*huh.Form); ok {
+		f.form = updated
+	}
+	if f.form.State != huh.StateCompleted {
+		return cmd
+	}
+
+	p.featureForm = nil
+	name := strings.TrimSpace(f.form.GetString("name"))
+	feature := mcpclient.Feature{
+		ID:          p.uniqueFeatureID(featureSlug(name)),
+		Name:        name,
+		Description: strings.TrimSpace(f.form.GetString("description")),
+		Status:      "refinement",
+	}
+	client := p.MCP
+	p.StatusBar = "Creating " + feature.Name + "..."
+	return func() tea.Msg {
+		return featureCreatedMsg{feature: feature, err: client.CreateFeatureViaStdio(feature)}
+	}
+}
+
+// updateFeatureCreated lists and selects a created feature
+func (p *Prompt) updateFeatureCreated(msg tea.Msg) (tea.Cmd, bool) {
+	created, ok := msg.(featureCreatedMsg)
+	if !ok {
+		return nil, false
+	}
+	p.StatusBar = ""
+	if created.err != nil {
+		return p.toastResult(created.err, "Error creating feature", ""), true
+	}
+	p.FeaturesData.Refinement = append(p.FeaturesData.Refinement, created.feature)
+	p.featuresErr = nil
+	if p.featureStatusFilter != "" && p.featureStatusFilter != created.feature.Status {
+		p.featureStatusFilter = ""
+	}
+	p.SelectedFeature, _ = p.FeaturesData.FindFeature(created.feature.ID)
+	p.ensureFeatureVisible()
+	return p.showToast(toastSuccess, "Feature created: "+created.feature.Name), true
+}
+
+// featureFormView renders the new feature form as a dialog
+func (p *Prompt) featureFormView() string {
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Bold(true).Render("New Feature")
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(p.theme.Focus)).
+		Padding(1, 2).
+		Width(70).
+		Render(title + "\n\n" + p.featureForm.form.View())
+}
+
+// emptyFeaturesView explains an empty features view: a listing still loading
+// or one that failed, a status filter that hides everything, or a project
+// without features yet
+func (p *Prompt) emptyFeaturesView() string {
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted))
+	key := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Bold(true)
+	if p.featuresLoading {
+		return muted.Render("Loading features…") + "\n"
+	}
+	if p.featuresErr != nil {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Error)).Render("Couldn't load features: "+p.featuresErr.Error()) + "\n\n" +
+			muted.Render("Check the MCP server with /status, then reopen the list with /features.") + "\n"
+	}
+	if len(p.allFeatures()) > 0 {
+		return muted.Render("No "+p.featureStatusFilter+" features. Reopen with /features to see them all.") + "\n"
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Text)).Bold(true).Render("No features yet.") + "\n\n" +
+		muted.Render("Press ") + key.Render("n") + muted.Render(" to create one or ") + key.Render("p") + muted.Render(" to run /plan and generate features.") + "\n"
+}
@@ -0,0 +1,75 @@
+package components
+
+import (
+	"strings"
+
+	"tddpro/internal/mcpclient"
+	"tddpro/internal/util"
+)
+
+// featureGroup is one status group of the features view
+type featureGroup struct {
+	status   string
+	label    string
+	features []mcpclient.Feature
+	color    string
+}
+
+// parseFeatureStatus matches arg against the known statuses. It returns ""
+// and false for an unknown status; an empty arg is valid and means all.
+func parseFeatureStatus(arg string) (string, bool) {
+	arg = strings.ToLower(strings.TrimSpace(arg))
+	if arg == "" {
+		return "", true
+	}
+	for _, status := range util.FeatureStatuses {
+		if arg == status {
+			return status, true
+		}
+	}
+	return "", false
+}
+
+// featureGroups returns the status groups shown in the sidebar. With a
+// status filter only that group is shown, including planned, which the
+// unfiltered sidebar leaves out.
+func (p *Prompt) featureGroups() []featureGroup {
+	all := p.statusGroups()
+	if p.featureStatusFilter == "" {
+		return []featureGroup{all[0], all[2], all[3]}
+	}
+	for _, group := range all {
+		if group.status == p.featureStatusFilter {
+			return []featureGroup{group}
+		}
+	}
+	return nil
+}
+
+// statusGroups returns every status group in util.FeatureStatuses order, each
+// sorted by the selected feature sort
+func (p *Prompt) statusGroups() []featureGroup {
+	return []featureGroup{
+		{"approved", "Accepted", p.sortFeatures(p.FeaturesData.Approved), p.theme.Focus},
+		{"planned", "Planned", p.sortFeatures(p.FeaturesData.Planned), p.theme.Value},
+		{"refinement", "Refining", p.sortFeatures(p.FeaturesData.Refinement), p.theme.Warning},
+		{"backlog", "Backlog", p.sortFeatures(p.FeaturesData.Backlog), p.theme.Muted},
+	}
+}
+
+// visibleFeatures flattens the features the current status filter lets
+// through, in navigation order. Features of collapsed groups are skipped.
+func (p *Prompt) visibleFeatures() []mcpclient.Feature {
+	groups := p.featureGroups()
+	if p.featureStatusFilter == "" {
+		// Planned features are navigable even though the sidebar leaves them out
+		groups = p.statusGroups()
+	}
+	features := []mcpclient.Feature{}
+	for _, group := range groups {
+		if !p.collapsedGroups[group.status] {
+			features = append(features, group.features...)
+		}
+	}
+	return features
+}
@@ -0,0 +1,36 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"tddpro/internal/mcpclient"
+)
+
+func TestHandleFeatures_StatusFilter(t *testing.T) {
+	t.Chdir(t.TempDir())
+	prompt := NewPromptWithClient(mcpclient.NewDemoClient(), "http://localhost:4111", "test")
+	p := &prompt
+
+	openFeatures(p, "Backlog")
+	if p.SelectedFeature == nil || p.SelectedFeature.ID != "dark-mode" {
+		t.Fatalf("Expected the first backlog feature to be selected, got %+v", p.SelectedFeature)
+	}
+	sidebar := p.generateSidebarContent()
+	if !strings.Contains(sidebar, "Dark Mode") || strings.Contains(sidebar, "User Authentication") {
+		t.Errorf("Expected only the backlog group in the sidebar, got:\n%s", sidebar)
+	}
+	p.moveFeatureSelection(1)
+	if p.SelectedFeature.ID != "dark-mode" {
+		t.Errorf("Expected navigation to stay within the filter, got %s", p.SelectedFeature.ID)
+	}
+
+	// Unknown statuses fall back to every group
+	openFeatures(p, "shipped")
+	if p.featureStatusFilter != "" || !strings.Contains(p.StatusBar, "Unknown status") {
+		t.Errorf("Expected an unfiltered view and a warning, got filter %q, status %q", p.featureStatusFilter, p.StatusBar)
+	}
+	if p.SelectedFeature == nil || p.SelectedFeature.ID != "user-auth" {
+		t.Errorf("Expected the first approved feature to be selected, got %+v", p.SelectedFeature)
+	}
+}
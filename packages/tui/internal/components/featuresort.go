@@ -0,0 +1,133 @@
+package components
+
+import (
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+
+	"tddpro/internal/mcpclient"
+)
+
+// Feature orders within each sidebar status group, cycled with s
+const (
+	featureSortServer  = ""        // as the MCP server lists them
+	featureSortName    = "name"    // A–Z, ignoring case
+	featureSortUpdated = "updated" // most recently updated first
+	featureSortCreated = "created" // oldest first
+)
+
+var featureSortOrders = []string{featureSortServer, featureSortName, featureSortUpdated, featureSortCreated}
+
+// parseFeatureSort matches a feature_sort value, where "server" and "" both
+// mean the server's order. ok is false for an unknown order.
+func parseFeatureSort(order string) (string, bool) {
+	order = strings.ToLower(strings.TrimSpace(order))
+	if order == "server" {
+		return featureSortServer, true
+	}
+	for _, known := range featureSortOrders {
+		if order == known {
+			return known, true
+		}
+	}
+	return featureSortServer, false
+}
+
+// SetFeatureSort orders features within each sidebar group by name, updated
+// or created; "" or "server" keeps the server's order. An unknown order is
+// logged and ignored. Set with feature_sort in config.yml.
+func (p *Prompt) SetFeatureSort(order string) {
+	sortOrder, ok := parseFeatureSort(order)
+	if !ok {
+		slog.Warn("unknown feature_sort, keeping the server order", "feature_sort", order)
+	}
+	p.featureSort = sortOrder
+}
+
+// featureSortLabel describes an order for the status bar
+func featureSortLabel(order string) string {
+	switch order {
+	case featureSortName:
+		return "name (A–Z)"
+	case featureSortUpdated:
+		return "recently updated"
+	case featureSortCreated:
+		return "creation order"
+	}
+	return "server order"
+}
+
+// hasFeatureTimestamps reports whether any feature carries the timestamp an
+// order sorts on. Index files written before the server stamped features have
+// none, and sorting on them would just show the server order.
+func (p *Prompt) hasFeatureTimestamps(order string) bool {
+	for _, group := range [][]mcpclient.Feature{p.FeaturesData.Approved, p.FeaturesData.Planned, p.FeaturesData.Refinement, p.FeaturesData.Backlog} {
+		for _, feature := range group {
+			if order == featureSortUpdated && !feature.UpdatedAt.IsZero() || order == featureSortCreated && !feature.CreatedAt.IsZero() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// cycleFeatureSort switches to the next sort order, keeping the selected
+// feature in view. The updated and created orders are skipped while no
+// feature has their timestamp.
+func (p *Prompt) cycleFeatureSort() {
+	start := slices.Index(featureSortOrders, p.featureSort)
+	var skipped []string
+	for i := 1; i <= len(featureSortOrders); i++ {
+		order := featureSortOrders[(start+i)%len(featureSortOrders)]
+		if (order == featureSortUpdated || order == featureSortCreated) && !p.hasFeatureTimestamps(order) {
+			skipped = append(skipped, order)
+			continue
+		}
+		p.featureSort = order
+		break
+	}
+	p.ensureFeatureVisible()
+	p.StatusBar = "Features sorted by " + featureSortLabel(p.featureSort) + " (s to change)"
+	if len(skipped) > 0 {
+		p.StatusBar += "; no feature has " + strings.Join(skipped, " or ") + " timestamps yet"
+	}
+}
+
+// untimedSortNote explains, for the status bar, that a configured updated or
+// created order is showing the server order because no feature has the
+// timestamp. It is empty otherwise.
+func (p *Prompt) untimedSortNote() string {
+	if (p.featureSort != featureSortUpdated && p.featureSort != featureSortCreated) || p.hasFeatureTimestamps(p.featureSort) {
+		return ""
+	}
+	return "No feature has " + p.featureSort + " timestamps yet; showing the server order"
+}
+
+// sortFeatures returns a group's features in the selected order. Features
+// without the timestamp being sorted on keep their server order after the
+// rest.
+func (p *Prompt) sortFeatures(features []mcpclient.Feature) []mcpclient.Feature {
+	if p.featureSort == featureSortServer || len(features) < 2 {
+		return features
+	}
+	sorted := append([]mcpclient.Feature{}, features...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		switch p.featureSort {
+		case featureSortName:
+			return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		case featureSortUpdated:
+			if a.UpdatedAt.IsZero() || b.UpdatedAt.IsZero() {
+				return !a.UpdatedAt.IsZero() && b.UpdatedAt.IsZero()
+			}
+			return a.UpdatedAt.After(b.UpdatedAt.Time)
+		default:
+			if a.CreatedAt.IsZero() || b.CreatedAt.IsZero() {
+				return !a.CreatedAt.IsZero() && b.CreatedAt.IsZero()
+			}
+			return a.CreatedAt.Before(b.CreatedAt.Time)
+		}
+	})
+	return sorted
+}
@@ -0,0 +1,86 @@
+package components
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tddpro/internal/mcpclient"
+)
+
+func TestFeatureSort_OrdersSidebarAndNavigation(t *testing.T) {
+	p := newDemoPrompt(t)
+	at := func(days int) mcpclient.Timestamp {
+		return mcpclient.Timestamp{Time: time.Date(2026, 1, days, 0, 0, 0, 0, time.UTC)}
+	}
+	p.FeaturesData.Refinement = []mcpclient.Feature{
+		{ID: "webhooks", Name: "Webhooks", Status: "refinement", CreatedAt: at(1), UpdatedAt: at(4)},
+		{ID: "audit", Name: "audit log", Status: "refinement", CreatedAt: at(3), UpdatedAt: at(9)},
+		{ID: "mentions", Name: "Mentions", Status: "refinement", CreatedAt: at(2)},
+	}
+	// order lists the refinement features as the sidebar shows them and as
+	// down steps through them
+	order := func() (shown, stepped string) {
+		sidebar := p.generateSidebarContent()
+		names := []string{"Webhooks", "audit log", "Mentions"}
+		sort.Slice(names, func(i, j int) bool { return strings.Index(sidebar, names[i]) < strings.Index(sidebar, names[j]) })
+		p.SelectedFeature = &p.statusGroups()[2].features[0]
+		stepped = p.SelectedFeature.Name
+		for i := 0; i < 2; i++ {
+			p.moveFeatureSelection(1)
+			stepped += ", " + p.SelectedFeature.Name
+		}
+		return strings.Join(names, ", "), stepped
+	}
+
+	for _, want := range []struct{ sort, order string }{
+		{"", "Webhooks, audit log, Mentions"},
+		{"name", "audit log, Mentions, Webhooks"},
+		{"updated", "audit log, Webhooks, Mentions"},
+		{"created", "Webhooks, Mentions, audit log"},
+		{"bogus", "Webhooks, audit log, Mentions"},
+	} {
+		p.SetFeatureSort(want.sort)
+		if shown, stepped := order(); shown != want.order || stepped != want.order {
+			t.Errorf("feature_sort %q: expected %s, got sidebar %s and navigation %s", want.sort, want.order, shown, stepped)
+		}
+	}
+
+	// s cycles the order from the features list
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if shown, _ := order(); shown != "audit log, Mentions, Webhooks" || !strings.Contains(p.StatusBar, "sorted by name") {
+		t.Errorf("Expected s to sort by name, got %s and %q", shown, p.StatusBar)
+	}
+}
+
+// An index written before the server stamped features has no timestamps, so
+// the updated and created orders would silently show the server order
+func TestFeatureSort_SkipsOrdersWithoutTimestamps(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.FeaturesData = mcpclient.FeaturesData{Refinement: []mcpclient.Feature{
+		{ID: "webhooks", Name: "Webhooks", Status: "refinement"},
+		{ID: "audit", Name: "audit log", Status: "refinement"},
+	}}
+	p.SetFeatureSort("name")
+	p.cycleFeatureSort()
+	if p.featureSort != featureSortServer || !strings.Contains(p.StatusBar, "no feature has updated or created timestamps") {
+		t.Errorf("Expected s to skip to the server order and say why, got %q and %q", p.featureSort, p.StatusBar)
+	}
+
+	p.FeaturesData.Refinement[1].CreatedAt = mcpclient.Timestamp{Time: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}
+	p.SetFeatureSort("name")
+	p.cycleFeatureSort()
+	if p.featureSort != featureSortCreated || !strings.Contains(p.StatusBar, "no feature has updated timestamps") {
+		t.Errorf("Expected s to skip only the updated order, got %q and %q", p.featureSort, p.StatusBar)
+	}
+	if note := p.untimedSortNote(); note != "" {
+		t.Errorf("Expected no note once features are stamped, got %q", note)
+	}
+	p.SetFeatureSort("updated")
+	if note := p.untimedSortNote(); !strings.Contains(note, "server order") {
+		t.Errorf("Expected a configured updated order to explain the fallback, got %q", note)
+	}
+}
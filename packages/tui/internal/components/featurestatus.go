@@ -0,0 +1,101 @@
+package components
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"tddpro/internal/util"
+)
+
+// featureStatusSavedMsg is sent when a feature's status change has been
+// written via MCP
+type featureStatusSavedMsg struct {
+	featureID string
+	status    string
+	err       error
+}
+
+// pendingFeatureStatus returns the status chosen for the selected feature in
+// the feature data panel, starting from its saved status
+func (p *Prompt) pendingFeatureStatus() string {
+	if p.SelectedFeature == nil {
+		return ""
+	}
+	if p.statusEditID != p.SelectedFeature.ID {
+		p.statusEditID = p.SelectedFeature.ID
+		p.statusEdit = p.SelectedFeature.Status
+	}
+	return p.statusEdit
+}
+
+// cycleFeatureStatus selects the next valid status; enter saves it
+func (p *Prompt) cycleFeatureStatus() {
+	if p.SelectedFeature == nil || p.blockedByReadOnly() {
+		return
+	}
+	current := p.pendingFeatureStatus()
+	next := 0
+	for i, status := range util.FeatureStatuses {
+		if status == current {
+			next = (i + 1) % len(util.FeatureStatuses)
+			break
+		}
+	}
+	p.statusEdit = util.FeatureStatuses[next]
+	if p.statusEdit == p.SelectedFeature.Status {
+		p.StatusBar = "Status: " + p.statusEdit
+	} else {
+		p.StatusBar = fmt.Sprintf("Status: %s → %s (enter to save)", p.SelectedFeature.Status, p.statusEdit)
+	}
+}
+
+// featureStatusView renders the status line of the feature data panel: a
+// selector while the panel has focus, plain text otherwise
+func (p *Prompt) featureStatusView() string {
+	status := p.pendingFeatureStatus()
+	if p.focusState != 1 || p.readOnly {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Text)).Render(status)
+	}
+	selector := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Bold(true).Render("‹ " + status + " ›")
+	hint := "(ctrl+t to change)"
+	if p.SelectedFeature != nil && status != p.SelectedFeature.Status {
+		hint = "(enter to save)"
+	}
+	return selector + " " + lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render(hint)
+}
+
+// saveFeatureStatus writes the feature's new status via MCP
+func (p *Prompt) saveFeatureStatus(featureID, status string) tea.Cmd {
+	return func() tea.Msg {
+		return featureStatusSavedMsg{featureID: featureID, status: status, err: p.MCP.UpdateFeatureStatusViaStdio(featureID, status)}
+	}
+}
+
+// updateFeatureStatus moves a feature whose status was saved into its new
+// sidebar group, keeping it selected
+func (p *Prompt) updateFeatureStatus(msg tea.Msg) (tea.Cmd, bool) {
+	saved, ok := msg.(featureStatusSavedMsg)
+	if !ok {
+		return nil, false
+	}
+	p.StatusBar = ""
+	if saved.err != nil {
+		// The pending status stays so the save can be retried with enter
+		return p.toastResult(saved.err, "Error saving status", ""), true
+	}
+	selected := p.SelectedFeature != nil && p.SelectedFeature.ID == saved.featureID
+	moved, ok := p.FeaturesData.SetFeatureStatus(saved.featureID, saved.status)
+	if !ok {
+		return nil, true
+	}
+	if selected {
+		p.SelectedFeature = moved
+		// A status filter would hide the feature in its new group
+		if p.featureStatusFilter != "" && p.featureStatusFilter != saved.status {
+			p.featureStatusFilter = ""
+		}
+	}
+	return p.showToast(toastSuccess, fmt.Sprintf("%s moved to %s", moved.Name, saved.status)), true
+}
@@ -0,0 +1,40 @@
+package components
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFeatureStatus_CycleAndSave(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.focusState = 1
+	p.FeaturesTab = 0
+	p.WindowWidth, p.WindowHeight = 120, 40
+	p.View() // rendering syncs the name and description inputs
+
+	p.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	if got := p.pendingFeatureStatus(); got != "planned" {
+		t.Fatalf("Expected ctrl+t to select planned, got %q", got)
+	}
+	if p.SelectedFeature.Status != "approved" {
+		t.Errorf("Expected the status to change only on save, got %q", p.SelectedFeature.Status)
+	}
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatalf("Expected a save command, status %q", p.StatusBar)
+	}
+	p.Update(cmd())
+
+	if p.SelectedFeature == nil || p.SelectedFeature.ID != "user-auth" || p.SelectedFeature.Status != "planned" {
+		t.Fatalf("Expected user-auth to stay selected as planned, got %+v", p.SelectedFeature)
+	}
+	if len(p.FeaturesData.Approved) != 0 || len(p.FeaturesData.Planned) != 2 {
+		t.Errorf("Expected the feature to move to the planned group, got %d approved, %d planned", len(p.FeaturesData.Approved), len(p.FeaturesData.Planned))
+	}
+	data, _ := p.MCP.ListFeaturesViaStdio()
+	if len(data.Planned) != 2 {
+		t.Errorf("Expected the status to be persisted via MCP, got %d planned", len(data.Planned))
+	}
+}
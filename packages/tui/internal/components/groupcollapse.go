@@ -0,0 +1,68 @@
+package components
+
+import (
+	"fmt"
+
+	"tddpro/internal/util"
+)
+
+// toggleGroupCollapse collapses a sidebar status group to a one-line summary,
+// or expands it again. Collapsed groups stay collapsed for the session and
+// their features are skipped when navigating.
+func (p *Prompt) toggleGroupCollapse(status string) {
+	if statusRank(status) < 0 {
+		return
+	}
+	label := p.statusGroups()[statusRank(status)].label
+	if p.collapsedGroups[status] {
+		delete(p.collapsedGroups, status)
+		p.StatusBar = "Expanded " + label
+		p.ensureFeatureVisible()
+		return
+	}
+	if p.collapsedGroups == nil {
+		p.collapsedGroups = map[string]bool{}
+	}
+	p.collapsedGroups[status] = true
+	p.StatusBar = "Collapsed " + label
+	if p.SelectedFeature != nil && p.featureStatusRank(p.SelectedFeature.ID) == statusRank(status) {
+		p.selectAfterCollapse(statusRank(status))
+	}
+	p.sidebarScroll = min(p.sidebarScroll, p.getMaxSidebarScroll())
+	p.ensureFeatureVisible()
+}
+
+// toggleSelectedGroupCollapse collapses the group holding the selected feature
+func (p *Prompt) toggleSelectedGroupCollapse() {
+	if p.SelectedFeature == nil {
+		return
+	}
+	if rank := p.featureStatusRank(p.SelectedFeature.ID); rank >= 0 {
+		p.toggleGroupCollapse(util.FeatureStatuses[rank])
+	}
+}
+
+// selectAfterCollapse moves the selection out of a group that was just
+// collapsed: to the first visible feature of a later group, otherwise the
+// last visible one. With nothing visible the selection is kept.
+func (p *Prompt) selectAfterCollapse(rank int) {
+	visible := p.visibleFeatures()
+	if len(visible) == 0 {
+		return
+	}
+	for i := range visible {
+		if p.featureStatusRank(visible[i].ID) > rank {
+			p.SelectedFeature = &visible[i]
+			return
+		}
+	}
+	p.SelectedFeature = &visible[len(visible)-1]
+}
+
+// collapsedGroupSummary is shown after a collapsed group's label, e.g. "▸ 4 features"
+func collapsedGroupSummary(count int) string {
+	if count == 1 {
+		return "▸ 1 feature"
+	}
+	return fmt.Sprintf("▸ %d features", count)
+}
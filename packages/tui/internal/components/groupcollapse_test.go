@@ -0,0 +1,48 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSidebarGroups_CollapseToSummaryAndSkipWhenNavigating(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.WindowWidth, p.WindowHeight = 120, 40
+	p.focusState = 0
+	key := func(k string) { p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}) }
+
+	key("4")
+	sidebar := p.generateSidebarContent()
+	if !strings.Contains(sidebar, "Backlog ▸ 1 feature") || strings.Contains(sidebar, "Dark Mode") {
+		t.Errorf("Expected backlog collapsed to a summary line, got:\n%s", sidebar)
+	}
+	for i := 0; i < 6; i++ {
+		p.Update(tea.KeyMsg{Type: tea.KeyDown})
+		if p.SelectedFeature.ID == "dark-mode" {
+			t.Fatal("Expected navigation to skip the collapsed backlog")
+		}
+	}
+
+	// Collapsing the selection's own group moves the selection out of it
+	p.SelectedFeature, _ = p.FeaturesData.FindFeature("user-auth")
+	key("c")
+	if !p.collapsedGroups["approved"] || p.SelectedFeature.ID == "user-auth" {
+		t.Errorf("Expected approved collapsed and the selection moved, got %s", p.SelectedFeature.ID)
+	}
+	if !strings.Contains(p.generateSidebarContent(), "Accepted ▸ 1 feature") {
+		t.Error("Expected the accepted group summarized")
+	}
+
+	key("4")
+	if p.collapsedGroups["backlog"] || !strings.Contains(p.generateSidebarContent(), "Dark Mode") {
+		t.Error("Expected 4 to expand the backlog again")
+	}
+
+	// The state lasts for the session, across reopening the view
+	openFeatures(p, "")
+	if !strings.Contains(p.generateSidebarContent(), "Accepted ▸ 1 feature") {
+		t.Error("Expected collapsed groups kept after /features")
+	}
+}
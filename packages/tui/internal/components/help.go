@@ -0,0 +1,126 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+// HelpOverlay lists key bindings and narrows them as the user types
+type HelpOverlay struct {
+	Active   bool
+	ReadOnly bool // grays out bindings that edit the project
+	bindings []KeyBinding
+	filter   textinput.Model
+	scroll   int
+}
+
+// Open shows the overlay with the given bindings and an empty filter
+func (h *HelpOverlay) Open(bindings []KeyBinding) {
+	h.filter = textinput.New()
+	h.filter.Placeholder = "Type to filter (e.g. edit task)"
+	h.filter.Prompt = "/ "
+	h.filter.Focus()
+	h.bindings = bindings
+	h.scroll = 0
+	h.Active = true
+}
+
+// Close hides the overlay
+func (h *HelpOverlay) Close() {
+	h.Active = false
+	h.filter.Blur()
+}
+
+// Filtered returns the bindings matching the filter, best matches first
+func (h *HelpOverlay) Filtered() []KeyBinding {
+	query := strings.TrimSpace(h.filter.Value())
+	if query == "" {
+		return h.bindings
+	}
+	matches := fuzzy.FindFrom(query, helpSource(h.bindings))
+	result := make([]KeyBinding, len(matches))
+	for i, match := range matches {
+		result[i] = h.bindings[match.Index]
+	}
+	return result
+}
+
+// helpSource adapts key bindings for fuzzy matching
+type helpSource []KeyBinding
+
+func (s helpSource) String(i int) string { return s[i].searchText() }
+func (s helpSource) Len() int            { return len(s) }
+
+// Update handles keys while the overlay is open: esc closes, up/down scroll,
+// everything else edits the filter
+func (h *HelpOverlay) Update(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		h.Close()
+		return nil
+	case "up":
+		if h.scroll > 0 {
+			h.scroll--
+		}
+		return nil
+	case "down":
+		if h.scroll < len(h.Filtered())-1 {
+			h.scroll++
+		}
+		return nil
+	}
+	var cmd tea.Cmd
+	h.filter, cmd = h.filter.Update(msg)
+	h.scroll = 0
+	return cmd
+}
+
+// View renders the filter and matching bindings within maxLines
+func (h *HelpOverlay) View(width, maxLines int, theme Theme) string {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Focus)).Bold(true)
+	contextStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Muted)).Width(14)
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Text)).Bold(true).Width(18)
+	descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Value))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Muted)).Italic(true)
+
+	filtered := h.Filtered()
+	lines := []string{titleStyle.Render("Keyboard Shortcuts"), h.filter.View(), ""}
+	if len(filtered) == 0 {
+		lines = append(lines, hintStyle.Render("No matching shortcuts"))
+	}
+
+	visible := maxLines - 5 // title, filter, spacing and hint
+	if visible < 3 {
+		visible = 3
+	}
+	end := h.scroll + visible
+	if end > len(filtered) {
+		end = len(filtered)
+	}
+	disabledStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Border))
+	for i, b := range filtered[h.scroll:end] {
+		// Each context is named once, on the first of its shortcuts in view
+		context := ""
+		if i == 0 || filtered[h.scroll+i-1].Context != b.Context {
+			context = b.Context
+		}
+		if h.ReadOnly && b.Mutates {
+			lines = append(lines, contextStyle.Render(context)+disabledStyle.Width(18).Render(b.KeysLabel())+disabledStyle.Render(b.Description+" (read-only)"))
+			continue
+		}
+		lines = append(lines, contextStyle.Render(context)+keyStyle.Render(b.KeysLabel())+descStyle.Render(b.Description))
+	}
+
+	lines = append(lines, "", hintStyle.Render(fmt.Sprintf("%d of %d shortcuts · ↑↓ scroll · esc close", len(filtered), len(h.bindings))))
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(theme.Focus)).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,37 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tddpro/internal/mcpclient"
+)
+
+func TestHelpOverlay_QuestionMarkOnEmptyPrompt(t *testing.T) {
+	t.Chdir(t.TempDir())
+	p := NewPromptWithClient(mcpclient.NewDemoClient(), "http://localhost:4111", "test")
+	p.WindowWidth, p.WindowHeight = 120, 60
+	question := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")}
+
+	p.textInput.SetValue("why")
+	p.Update(question)
+	if p.help.Active || p.textInput.Value() != "why?" {
+		t.Fatalf("Expected ? to be typed into a non-empty prompt, got %q", p.textInput.Value())
+	}
+
+	p.textInput.SetValue("")
+	p.Update(question)
+	if !p.help.Active {
+		t.Fatal("Expected ? on an empty prompt to open the shortcuts")
+	}
+	// Contexts head their group instead of repeating on every row
+	if view := p.help.View(100, 200, p.theme); strings.Count(view, "│ Tasks ") != 1 {
+		t.Errorf("Expected the Tasks context once, got:\n%s", view)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.help.Active {
+		t.Error("Expected esc to close the shortcuts")
+	}
+}
@@ -0,0 +1,46 @@
+package components
+
+// History keeps previously submitted inputs so they can be recalled with up/down
+type History struct {
+	entries []string
+	index   int    // position while navigating; len(entries) when not navigating
+	draft   string // input being typed before navigation started
+}
+
+// Add records a submitted input, skipping empty entries and consecutive duplicates
+func (h *History) Add(entry string) {
+	if entry != "" && (len(h.entries) == 0 || h.entries[len(h.entries)-1] != entry) {
+		h.entries = append(h.entries, entry)
+	}
+	h.index = len(h.entries)
+	h.draft = ""
+}
+
+// Previous steps back in history, remembering current as the draft on the first step
+func (h *History) Previous(current string) (string, bool) {
+	if h.index == 0 || len(h.entries) == 0 {
+		return "", false
+	}
+	if h.index == len(h.entries) {
+		h.draft = current
+	}
+	h.index--
+	return h.entries[h.index], true
+}
+
+// Next steps forward in history, returning the draft once past the newest entry
+func (h *History) Next() (string, bool) {
+	if h.index >= len(h.entries) {
+		return "", false
+	}
+	h.index++
+	if h.index == len(h.entries) {
+		return h.draft, true
+	}
+	return h.entries[h.index], true
+}
+
+// Entries returns the recorded inputs, oldest first
+func (h *History) Entries() []string {
+	return h.entries
+}
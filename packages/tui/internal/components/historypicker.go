@@ -0,0 +1,118 @@
+package components
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+// HistoryCompletionProvider offers previous inputs, newest first, fuzzy
+// filtered by the query
+type HistoryCompletionProvider struct {
+	entries []string // unique inputs, newest first
+}
+
+// NewHistoryCompletionProvider lists history's entries once each, keeping the newest use
+func NewHistoryCompletionProvider(history *History) *HistoryCompletionProvider {
+	seen := map[string]bool{}
+	provider := &HistoryCompletionProvider{}
+	entries := history.Entries()
+	for i := len(entries) - 1; i >= 0; i-- {
+		if !seen[entries[i]] {
+			seen[entries[i]] = true
+			provider.entries = append(provider.entries, entries[i])
+		}
+	}
+	return provider
+}
+
+func (h *HistoryCompletionProvider) GetID() string {
+	return "history"
+}
+
+func (h *HistoryCompletionProvider) GetCompletions(query string) ([]CompletionItem, error) {
+	var items []CompletionItem
+	if query == "" {
+		for _, entry := range h.entries {
+			items = append(items, CompletionItem{Title: entry, Value: entry})
+		}
+		return items, nil
+	}
+	// Best matches first; ties keep the newer entry first since fuzzy sorts stably
+	for _, match := range fuzzy.Find(query, h.entries) {
+		items = append(items, CompletionItem{Title: match.Str, Value: match.Str})
+	}
+	return items, nil
+}
+
+// historyPicker is the ctrl+r search over previous inputs
+type historyPicker struct {
+	query  string
+	dialog *CompletionDialog
+}
+
+// openHistoryPicker starts searching previous inputs
+func (p *Prompt) openHistoryPicker() {
+	if len(p.history.Entries()) == 0 {
+		p.StatusBar = "No previous inputs to search yet"
+		return
+	}
+	dialog := NewCompletionDialog()
+	dialog.SetTheme(p.theme)
+	dialog.SetProvider(NewHistoryCompletionProvider(&p.history))
+	dialog.Show()
+	dialog.UpdateQuery("")
+	if p.completionDialog != nil {
+		p.completionDialog.Hide()
+	}
+	p.historyPicker = &historyPicker{dialog: dialog}
+}
+
+// updateHistoryPicker filters the picker as the query is typed. Enter or tab
+// puts the chosen input in the prompt for editing; it isn't sent.
+func (p *Prompt) updateHistoryPicker(msg tea.KeyMsg) (*Prompt, tea.Cmd) {
+	picker := p.historyPicker
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlR, tea.KeyCtrlC:
+		p.historyPicker = nil
+		return p, nil
+	case tea.KeyUp, tea.KeyDown:
+		picker.dialog.Update(msg)
+		return p, nil
+	case tea.KeyEnter, tea.KeyTab:
+		p.historyPicker = nil
+		if item := picker.dialog.GetSelectedItem(); item != nil {
+			p.textInput.SetValue(item.Value)
+			p.textInput.CursorEnd()
+			p.updateCompletions()
+		}
+		return p, textinput.Blink
+	case tea.KeyBackspace:
+		if runes := []rune(picker.query); len(runes) > 0 {
+			picker.query = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		picker.query += " "
+	case tea.KeyRunes:
+		picker.query += string(msg.Runes)
+	default:
+		return p, nil
+	}
+	picker.dialog.UpdateQuery(picker.query)
+	return p, nil
+}
+
+// historyPickerView renders the query above the matching inputs
+func (p *Prompt) historyPickerView() string {
+	picker := p.historyPicker
+	label := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Bold(true).Render("History search: ")
+	hint := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Italic(true)
+	view := label + picker.query + "▏\n"
+	if matches := picker.dialog.View(); matches != "" {
+		view += matches + "\n"
+	} else {
+		view += hint.Render("No matching inputs") + "\n"
+	}
+	return view + hint.Render("↑↓ select · enter insert for editing · esc cancel") + "\n"
+}
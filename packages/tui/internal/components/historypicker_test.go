@@ -0,0 +1,60 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tddpro/internal/mcpclient"
+)
+
+func TestHistoryPicker_FuzzyFiltersAndInsertsForEditing(t *testing.T) {
+	prompt := NewPromptWithClient(mcpclient.NewDemoClient(), "http://localhost:4111", "test")
+	p := &prompt
+	p.WindowWidth, p.WindowHeight = 100, 30
+	ctrlR := tea.KeyMsg{Type: tea.KeyCtrlR}
+
+	p.Update(ctrlR)
+	if p.historyPicker != nil || !strings.Contains(p.StatusBar, "No previous inputs") {
+		t.Fatalf("Expected an empty history to be reported, got %q", p.StatusBar)
+	}
+
+	for _, entry := range []string{"/features", "Write tests for the login flow", "/help", "/features"} {
+		p.history.Add(entry)
+	}
+	p.Update(ctrlR)
+	if p.historyPicker == nil {
+		t.Fatal("Expected ctrl+r to open the history picker")
+	}
+	if first := p.historyPicker.dialog.GetSelectedItem(); first == nil || first.Value != "/features" {
+		t.Errorf("Expected the newest input first, got %+v", first)
+	}
+	if items := p.historyPicker.dialog.items; len(items) != 3 {
+		t.Errorf("Expected repeated inputs listed once, got %d items", len(items))
+	}
+
+	for _, r := range "login" {
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if view := p.View(); !strings.Contains(view, "History search: login") || !strings.Contains(view, "Write tests for the login flow") {
+		t.Errorf("Expected the query and its match in the view, got:\n%s", view)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.historyPicker != nil {
+		t.Error("Expected enter to close the picker")
+	}
+	if got := p.textInput.Value(); got != "Write tests for the login flow" {
+		t.Errorf("Expected the chosen input in the prompt, got %q", got)
+	}
+	if !p.Conversation.IsEmpty() {
+		t.Error("Expected the input to be inserted for editing, not sent")
+	}
+
+	p.Update(ctrlR)
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.historyPicker != nil || p.textInput.Value() != "Write tests for the login flow" {
+		t.Errorf("Expected esc to close the picker and leave the input alone, got %q", p.textInput.Value())
+	}
+}
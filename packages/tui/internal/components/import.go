@@ -0,0 +1,238 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"tddpro/internal/mcpclient"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// importPreviewLines is how much of the file the confirmation shows
+	importPreviewLines = 8
+	// minFeatureDescription is the shortest description create-feature accepts
+	minFeatureDescription = 50
+)
+
+// pendingImport is a Markdown file waiting for the user to confirm /import
+type pendingImport struct {
+	path    string
+	content string
+	target  *mcpclient.Feature // feature whose PRD is replaced, nil for a new feature
+	feature mcpclient.Feature  // the feature to create when target is nil
+}
+
+// featureImportedMsg reports the result of a confirmed /import
+type featureImportedMsg struct {
+	feature mcpclient.Feature
+	created bool
+	err     error
+}
+
+// importTitle is the file's first "# " heading, or its name without the extension
+func importTitle(path, content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		if title, ok := strings.CutPrefix(strings.TrimSpace(line), "# "); ok && strings.TrimSpace(title) != "" {
+			return strings.TrimSpace(title)
+		}
+	}
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+// importDescription takes paragraphs of body text (headings skipped) until it
+// reaches the length create-feature requires, noting the source if it's
+// still too short
+func importDescription(path, content string) string {
+	var paragraphs []string
+	length := 0
+	for _, paragraph := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n\n") {
+		paragraph = strings.Join(strings.Fields(paragraph), " ")
+		if paragraph == "" || strings.HasPrefix(paragraph, "#") {
+			continue
+		}
+		paragraphs = append(paragraphs, paragraph)
+		if length += len(paragraph); length >= minFeatureDescription {
+			break
+		}
+	}
+	description := strings.Join(paragraphs, " ")
+	if len(description) < minFeatureDescription {
+		description = strings.TrimSpace(description + " Imported from " + filepath.Base(path) + ", see the PRD for details.")
+	}
+	return description
+}
+
+// uniqueFeatureID returns base, or base-2, base-3... when it's taken
+func (p *Prompt) uniqueFeatureID(base string) string {
+	id := base
+	for n := 2; ; n++ {
+		if _, taken := p.FeaturesData.FindFeature(id); !taken {
+			return id
+		}
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+}
+
+// handleImport reads a Markdown file as a PRD: /import [--new] <path>. With a
+// feature selected it replaces that feature's PRD, otherwise (or with --new)
+// it creates a feature in refinement. Either way the user confirms a preview
+// first.
+func handleImport(p *Prompt, arg string) (*Prompt, tea.Cmd) {
+	p.textInput.SetValue("")
+	if p.blockedByReadOnly() {
+		return p, nil
+	}
+	if p.MCP == nil {
+		p.StatusBar = "MCP client not available"
+		return p, nil
+	}
+	arg = strings.TrimSpace(arg)
+	asNew := false
+	if rest, ok := strings.CutPrefix(arg, "--new"); ok && (rest == "" || rest[0] == ' ') {
+		asNew, arg = true, strings.TrimSpace(rest)
+	}
+	path := arg
+	if path == "" {
+		p.StatusBar = "Usage: /import [--new] <path.md>"
+		return p, nil
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		p.StatusBar = "File not found: " + path
+		return p, nil
+	case err != nil:
+		p.StatusBar = "Can't read " + path + ": " + err.Error()
+		return p, nil
+	}
+	content := string(data)
+	if strings.TrimSpace(content) == "" {
+		p.StatusBar = path + " is empty; nothing to import"
+		return p, nil
+	}
+
+	pending := &pendingImport{path: path, content: content}
+	if p.SelectedFeature != nil && !asNew {
+		target := *p.SelectedFeature
+		pending.target = &target
+	} else {
+		name := importTitle(path, content)
+		slug := featureSlug(name)
+		if slug == "" {
+			slug = "imported-feature"
+		}
+		pending.feature = mcpclient.Feature{
+			ID:          p.uniqueFeatureID(slug),
+			Name:        name,
+			Description: importDescription(path, content),
+			Status:      "refinement",
+		}
+	}
+	p.pendingImport = pending
+	p.StatusBar = ""
+	return p, nil
+}
+
+// handleImportConfirmKey answers the import confirmation: y imports, n or esc cancels
+func (p *Prompt) handleImportConfirmKey(msg tea.KeyMsg) tea.Cmd {
+	pending := p.pendingImport
+	switch msg.String() {
+	case "y", "Y":
+		p.pendingImport = nil
+		client := p.MCP
+		if pending.target != nil {
+			feature := *pending.target
+			p.StatusBar = "Replacing the PRD of " + feature.Name + "..."
+			return func() tea.Msg {
+				return featureImportedMsg{feature: feature, err: client.UpdateFeatureDocumentViaStdio(feature.ID, pending.content)}
+			}
+		}
+		feature := pending.feature
+		p.StatusBar = "Creating " + feature.Name + "..."
+		return func() tea.Msg {
+			if err := client.CreateFeatureViaStdio(feature); err != nil {
+				return featureImportedMsg{feature: feature, err: err}
+			}
+			if err := client.UpdateFeatureDocumentViaStdio(feature.ID, pending.content); err != nil {
+				return featureImportedMsg{feature: feature, created: true, err: fmt.Errorf("feature created but PRD not saved: %w", err)}
+			}
+			return featureImportedMsg{feature: feature, created: true}
+		}
+	case "n", "N", "esc":
+		p.pendingImport = nil
+		p.StatusBar = "Import cancelled"
+	}
+	return nil
+}
+
+// updateFeatureImport reports a confirmed /import and lists a new feature
+// under refinement
+func (p *Prompt) updateFeatureImport(msg tea.Msg) (tea.Cmd, bool) {
+	imported, ok := msg.(featureImportedMsg)
+	if !ok {
+		return nil, false
+	}
+	p.StatusBar = ""
+	if imported.created {
+		p.FeaturesData.Refinement = append(p.FeaturesData.Refinement, imported.feature)
+	}
+	p.prds.invalidate(imported.feature.ID)
+	switch {
+	case imported.err != nil:
+		return p.toastResult(imported.err, "Import failed", ""), true
+	case imported.created:
+		return p.showToast(toastSuccess, fmt.Sprintf("Imported %s as a new feature (%s)", imported.feature.Name, imported.feature.ID)), true
+	default:
+		return p.showToast(toastSuccess, "PRD of "+imported.feature.Name+" replaced"), true
+	}
+}
+
+// importConfirmView renders the import confirmation with the start of the file
+func (p *Prompt) importConfirmView() string {
+	pending := p.pendingImport
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted))
+	focus := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus))
+
+	border, title, action := p.theme.Focus, "IMPORT PRD AS NEW FEATURE", muted.Render("Create ")+focus.Render(pending.feature.Name)+muted.Render(" ("+pending.feature.ID+") in refinement")
+	if pending.target != nil {
+		border, title, action = p.theme.Warning, "REPLACE PRD", muted.Render("Replace the PRD of ")+focus.Render(pending.target.Name)
+	}
+
+	lines := strings.Split(strings.TrimSpace(pending.content), "\n")
+	preview := lines
+	if len(preview) > importPreviewLines {
+		preview = preview[:importPreviewLines]
+	}
+	previewText := lipgloss.NewStyle().Width(54).MaxHeight(importPreviewLines * 2).Render(strings.Join(preview, "\n"))
+	if more := len(lines) - len(preview); more > 0 {
+		previewText += "\n" + muted.Render(fmt.Sprintf("… %d more lines", more))
+	}
+
+	content := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Text)).Bold(true).Render(title) + "\n\n" +
+		action + "\n" +
+		muted.Render("from "+pending.path) + "\n\n" +
+		lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, false, false, true).BorderForeground(lipgloss.Color(p.theme.Border)).PaddingLeft(1).Render(previewText) + "\n\n" +
+		muted.Render("Import? ") +
+		lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Success)).Bold(true).Render("[Y]es") + " / " +
+		lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Error)).Bold(true).Render("[N]o")
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(border)).
+		Padding(1, 2).
+		Width(60).
+		Render(content)
+}
@@ -0,0 +1,60 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestImport_ConfirmsBeforeWritingPRD(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.WindowWidth, p.WindowHeight = 120, 40
+	yes := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "spec.md")
+	os.WriteFile(spec, []byte("# Audit Log\n\nRecord every sign-in and permission change so admins can review them later.\n"), 0644)
+
+	// With a feature selected the PRD is replaced, after a preview
+	handleImport(p, spec)
+	if p.pendingImport == nil || !strings.Contains(p.View(), "Record every sign-in") {
+		t.Fatalf("Expected a confirmation with a preview, status %q", p.StatusBar)
+	}
+	_, cmd := p.Update(yes)
+	p.Update(cmd())
+	if prd, _ := p.MCP.GetFeatureDocumentViaStdio("user-auth"); !strings.HasPrefix(prd, "# Audit Log") {
+		t.Errorf("Expected the PRD to be replaced, got %q", prd)
+	}
+
+	// --new creates a feature named after the heading
+	handleImport(p, "--new "+spec)
+	_, cmd = p.Update(yes)
+	p.Update(cmd())
+	created, ok := p.FeaturesData.FindFeature("audit-log")
+	if !ok || created.Status != "refinement" || len(created.Description) < minFeatureDescription {
+		t.Fatalf("Expected audit-log in refinement, got %+v", created)
+	}
+	if prd, _ := p.MCP.GetFeatureDocumentViaStdio("audit-log"); !strings.HasPrefix(prd, "# Audit Log") {
+		t.Errorf("Expected the new feature's PRD, got %q", prd)
+	}
+
+	// Esc cancels without writing
+	handleImport(p, "--new "+spec)
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.pendingImport != nil || p.StatusBar != "Import cancelled" {
+		t.Errorf("Expected the import to be cancelled, status %q", p.StatusBar)
+	}
+
+	handleImport(p, filepath.Join(dir, "missing.md"))
+	if !strings.HasPrefix(p.StatusBar, "File not found") {
+		t.Errorf("Expected a missing file error, got %q", p.StatusBar)
+	}
+	empty := filepath.Join(dir, "empty.md")
+	os.WriteFile(empty, []byte("\n  \n"), 0644)
+	handleImport(p, empty)
+	if p.pendingImport != nil || !strings.HasSuffix(p.StatusBar, "nothing to import") {
+		t.Errorf("Expected empty files to be refused, got %q", p.StatusBar)
+	}
+}
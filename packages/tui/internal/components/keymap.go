@@ -0,0 +1,130 @@
+package components
+
+import "strings"
+
+// KeyBinding describes a key (or command) and what it does in a given context
+type KeyBinding struct {
+	Keys        []string
+	Description string
+	Context     string // where the binding applies, e.g. "Tasks"
+	Mutates     bool   // edits the project, so it's disabled in read-only mode
+}
+
+// KeysLabel renders the binding's keys for display
+func (k KeyBinding) KeysLabel() string {
+	return strings.Join(k.Keys, " / ")
+}
+
+// searchText is the text the help filter matches against
+func (k KeyBinding) searchText() string {
+	return k.Context + " " + k.KeysLabel() + " " + k.Description
+}
+
+// KeyMap lists every key binding and command the TUI understands. The help
+// overlay is generated from it, so update it whenever a key handler changes.
+var KeyMap = []KeyBinding{
+	{Keys: []string{"enter"}, Description: "Send message or run command", Context: "Prompt"},
+	{Keys: []string{"up", "down"}, Description: "Recall previous inputs", Context: "Prompt"},
+	{Keys: []string{"tab"}, Description: "Complete command (enter runs it)", Context: "Prompt"},
+	{Keys: []string{"tab"}, Description: "Complete directory after /plan, /init or /destroy", Context: "Prompt"},
+	{Keys: []string{"?"}, Description: "Show keyboard shortcuts (on an empty prompt)", Context: "Prompt"},
+	{Keys: []string{"ctrl+o"}, Description: "Focus conversation history", Context: "Prompt"},
+	{Keys: []string{"ctrl+r"}, Description: "Search previous inputs and insert one for editing", Context: "Prompt"},
+	{Keys: []string{"esc"}, Description: "Cancel the pending agent request and restore its text", Context: "Prompt"},
+	{Keys: []string{"ctrl+l"}, Description: "Show full thinking log", Context: "Prompt"},
+	{Keys: []string{"ctrl+t"}, Description: "Show every /plan workflow event with timestamps", Context: "Prompt"},
+	{Keys: []string{"ctrl+p"}, Description: "Command palette: run any command or shortcut", Context: "Prompt"},
+	{Keys: []string{"ctrl+c"}, Description: "Cancel a pending request, else clear input; press again to quit (asks first if edits are unsaved)", Context: "Prompt"},
+	{Keys: []string{"up", "down", "pgup", "pgdown"}, Description: "Scroll thinking log", Context: "Thinking Log"},
+	{Keys: []string{"ctrl+l", "esc"}, Description: "Close thinking log", Context: "Thinking Log"},
+	{Keys: []string{"up", "down", "pgup", "pgdown"}, Description: "Scroll back while the workflow keeps running", Context: "Workflow Events"},
+	{Keys: []string{"G", "end"}, Description: "Jump to the newest event and follow new ones", Context: "Workflow Events"},
+	{Keys: []string{"ctrl+t", "esc"}, Description: "Close the event log", Context: "Workflow Events"},
+
+	{Keys: []string{"up", "k", "down", "j"}, Description: "Select message", Context: "History"},
+	{Keys: []string{"y", "c"}, Description: "Copy selected message", Context: "History"},
+	{Keys: []string{"r"}, Description: "Re-send selected message", Context: "History"},
+	{Keys: []string{"esc", "ctrl+o"}, Description: "Return to input", Context: "History"},
+	{Keys: []string{"up", "down"}, Description: "Select action", Context: "Palette"},
+	{Keys: []string{"enter"}, Description: "Run the selected command or key (commands needing arguments go to the prompt)", Context: "Palette"},
+	{Keys: []string{"click", "wheel"}, Description: "Run the clicked action; the wheel moves the selection", Context: "Palette"},
+	{Keys: []string{"esc", "ctrl+p"}, Description: "Close the palette", Context: "Palette"},
+
+	{Keys: []string{"/help"}, Description: "Show this help", Context: "Commands"},
+	{Keys: []string{"/features"}, Description: "List and manage project features", Context: "Commands"},
+	{Keys: []string{"/features <status>"}, Description: "Show only approved, planned, refinement or backlog features", Context: "Commands"},
+	{Keys: []string{"/plan [dir]"}, Description: "Run the planning workflow to generate features", Context: "Commands", Mutates: true},
+	{Keys: []string{"/plan --watch [dir]"}, Description: "Run it with the workflow event log open", Context: "Commands", Mutates: true},
+	{Keys: []string{"/export <id> [path] [--force]"}, Description: "Write a feature's PRD and tasks to Markdown (default ./<feature-name>.md; --force overwrites)", Context: "Commands"},
+	{Keys: []string{"/import [--new] <path>"}, Description: "Import Markdown as the selected feature's PRD, or as a new feature", Context: "Commands", Mutates: true},
+	{Keys: []string{"/save-transcript [path] [--force]"}, Description: "Save this session's conversation to Markdown (default ~/.config/tdd-pro/transcripts/; --force overwrites)", Context: "Commands"},
+	{Keys: []string{"/status"}, Description: "Show connection and MCP server version compatibility", Context: "Commands"},
+	{Keys: []string{"/doctor"}, Description: "Check the whole environment and suggest fixes", Context: "Commands"},
+	{Keys: []string{"/version-check"}, Description: "Compare this build with the latest GitHub release", Context: "Commands"},
+	{Keys: []string{"/tool <name> <json>"}, Description: "Call an MCP tool and show its JSON result (--log-level debug only)", Context: "Commands", Mutates: true},
+	{Keys: []string{"/workflow <id> <json>"}, Description: "Call a workflow and show its raw response and HTTP status (--log-level debug only)", Context: "Commands", Mutates: true},
+	{Keys: []string{"/clear"}, Description: "Clear conversation and status (add 'session' to reset the session)", Context: "Commands"},
+	{Keys: []string{"/undo"}, Description: "Revert the last task or feature edit", Context: "Commands", Mutates: true},
+	{Keys: []string{"/init"}, Description: "Initialize TDD-Pro in current directory", Context: "Commands", Mutates: true},
+	{Keys: []string{"/init --repair"}, Description: "Recreate a missing features/index.yml", Context: "Commands", Mutates: true},
+	{Keys: []string{"/mcp"}, Description: "Create or repair .mcp.json files for Claude Code, Cursor and VS Code", Context: "Commands", Mutates: true},
+	{Keys: []string{"/mcp remove"}, Description: "Remove tdd-pro from those files, keeping other servers (empty files are deleted)", Context: "Commands", Mutates: true},
+	{Keys: []string{"/auth"}, Description: "Configure Claude API key for TDD-Pro agents", Context: "Commands"},
+	{Keys: []string{"/auth show"}, Description: "Show the Claude API key masked; r reveals it briefly", Context: "Commands"},
+	{Keys: []string{"/destroy"}, Description: "Remove TDD-Pro from current directory", Context: "Commands", Mutates: true},
+	{Keys: []string{"/destroy --dry-run"}, Description: "Show what /destroy would remove without deleting", Context: "Commands"},
+	{Keys: []string{"/quit"}, Description: "Quit to the shell (asks first if edits are unsaved)", Context: "Commands"},
+
+	{Keys: []string{"left", "right", "tab"}, Description: "Move focus between panels", Context: "Features"},
+	{Keys: []string{"up", "down"}, Description: "Select feature, select task or scroll", Context: "Features"},
+	{Keys: []string{"[", "]", "pgup", "pgdown"}, Description: "Jump to the previous or next status group", Context: "Features"},
+	{Keys: []string{"c"}, Description: "Collapse or expand the selected feature's status group", Context: "Features"},
+	{Keys: []string{"s"}, Description: "Sort each status group by server order, name, recently updated or creation", Context: "Features"},
+	{Keys: []string{"1", "2", "3", "4"}, Description: "Collapse or expand the approved, planned, refinement or backlog group", Context: "Features"},
+	{Keys: []string{"n"}, Description: "Create a new feature (starts in refinement)", Context: "Features", Mutates: true},
+	{Keys: []string{"p"}, Description: "Run /plan when the project has no features yet", Context: "Features", Mutates: true},
+	{Keys: []string{"v"}, Description: "Mark or unmark the feature for a batch status change (esc clears)", Context: "Features", Mutates: true},
+	{Keys: []string{"shift+right", "shift+left"}, Description: "Move marked features forward (towards approved) or back", Context: "Features", Mutates: true},
+	{Keys: []string{"t"}, Description: "Switch to Tasks view", Context: "Features"},
+	{Keys: []string{"d"}, Description: "Switch to Feature Data view", Context: "Features"},
+	{Keys: []string{"y i"}, Description: "Copy selected feature ID", Context: "Features"},
+	{Keys: []string{"y p"}, Description: "Copy selected feature's PRD", Context: "Features"},
+	{Keys: []string{"u"}, Description: "Undo the last task or feature edit", Context: "Features", Mutates: true},
+	{Keys: []string{"ctrl+r"}, Description: "Refresh cached tasks (cached for 30s otherwise)", Context: "Features"},
+	{Keys: []string{"?"}, Description: "Show this help", Context: "Features"},
+	{Keys: []string{"ctrl+p"}, Description: "Command palette (Feature Data keeps ctrl+p for the pager)", Context: "Features"},
+	{Keys: []string{"esc"}, Description: "Back out one level: clear marked features, then close features view", Context: "Features"},
+
+	{Keys: []string{"e"}, Description: "Edit PRD document", Context: "Feature Data", Mutates: true},
+	{Keys: []string{"ctrl+p"}, Description: "Open PRD in $PAGER (default less -R)", Context: "Feature Data"},
+	{Keys: []string{"ctrl+t"}, Description: "Cycle feature status", Context: "Feature Data", Mutates: true},
+	{Keys: []string{"enter"}, Description: "Save feature name, description and status", Context: "Feature Data", Mutates: true},
+	{Keys: []string{"ctrl+k"}, Description: "Edit dependency links", Context: "Feature Data", Mutates: true},
+	{Keys: []string{"esc"}, Description: "Back to the features list", Context: "Feature Data"},
+	{Keys: []string{"up", "k", "down", "j"}, Description: "Select dependency", Context: "Dependencies"},
+	{Keys: []string{"enter"}, Description: "Jump to the linked feature", Context: "Dependencies"},
+	{Keys: []string{"a"}, Description: "Link another feature (warns about cycles)", Context: "Dependencies"},
+	{Keys: []string{"x", "delete"}, Description: "Remove selected link", Context: "Dependencies"},
+	{Keys: []string{"esc", "ctrl+k"}, Description: "Back to the feature data", Context: "Dependencies"},
+
+	{Keys: []string{"e"}, Description: "Edit selected task", Context: "Tasks", Mutates: true},
+	{Keys: []string{"a", "n"}, Description: "Create new task", Context: "Tasks", Mutates: true},
+	{Keys: []string{"<number> g", "<number> enter"}, Description: "Jump to task by number", Context: "Tasks"},
+	{Keys: []string{"/"}, Description: "Search task titles and descriptions (enter keeps, esc clears)", Context: "Tasks"},
+	{Keys: []string{"f"}, Description: "Show all, incomplete or complete tasks", Context: "Tasks"},
+	{Keys: []string{"!"}, Description: "Show only tasks without acceptance criteria", Context: "Tasks"},
+	{Keys: []string{"z"}, Description: "Toggle compact tasks (one line each, criteria shown for the selected task)", Context: "Tasks"},
+	{Keys: []string{"r"}, Description: "Refresh acceptance criteria test results (✓ pass, ✗ fail, ⧖ pending)", Context: "Tasks"},
+	{Keys: []string{"esc"}, Description: "Clear the task filter, else back to the features list", Context: "Tasks"},
+
+	{Keys: []string{"ctrl+g"}, Description: "Edit acceptance criteria line by line", Context: "Task Form"},
+	{Keys: []string{"esc"}, Description: "Cancel task edit", Context: "Task Form"},
+	{Keys: []string{"up", "k", "down", "j"}, Description: "Select criterion", Context: "Criteria"},
+	{Keys: []string{"enter"}, Description: "Edit selected criterion in place (blank removes it)", Context: "Criteria"},
+	{Keys: []string{"d"}, Description: "Delete selected criterion", Context: "Criteria"},
+	{Keys: []string{"a"}, Description: "Append a criterion", Context: "Criteria"},
+	{Keys: []string{"esc", "ctrl+g"}, Description: "Back to the task form", Context: "Criteria"},
+
+	{Keys: []string{"ctrl+s"}, Description: "Save PRD", Context: "PRD Editor"},
+	{Keys: []string{"esc"}, Description: "Cancel PRD edit", Context: "PRD Editor"},
+}
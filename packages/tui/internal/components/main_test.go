@@ -0,0 +1,23 @@
+package components
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestMain points HOME at a scratch directory so tests never read or write
+// the developer's ~/.tdd-pro (command usage, input history) or config dir
+func TestMain(m *testing.M) {
+	home, err := os.MkdirTemp("", "tdd-pro-test-home")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Setenv("HOME", home)
+	os.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	code := m.Run()
+	os.RemoveAll(home)
+	os.Exit(code)
+}
@@ -0,0 +1,27 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+
+	"tddpro/internal/mcpclient"
+)
+
+// errorText renders err for the status bar or a toast. A failed MCP tool
+// call gets a message for its category rather than the raw server text.
+func errorText(err error) string {
+	var mcpErr *mcpclient.MCPError
+	if !errors.As(err, &mcpErr) {
+		return err.Error()
+	}
+	switch mcpErr.Category {
+	case mcpclient.MCPToolNotFound:
+		return fmt.Sprintf("the MCP server has no %s tool - update TDD-Pro so the TUI and server match", mcpErr.Tool)
+	case mcpclient.MCPInvalidArgs:
+		return fmt.Sprintf("the MCP server rejected %s: %s", mcpErr.Tool, mcpErr.Message)
+	case mcpclient.MCPParseError:
+		return fmt.Sprintf("couldn't read the MCP server's reply to %s: %s", mcpErr.Tool, mcpErr.Message)
+	default:
+		return fmt.Sprintf("%s failed on the MCP server: %s", mcpErr.Tool, mcpErr.Message)
+	}
+}
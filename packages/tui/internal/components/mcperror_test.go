@@ -0,0 +1,27 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"tddpro/internal/mcpclient"
+)
+
+func TestErrorText_DescribesMCPFailuresByCategory(t *testing.T) {
+	cases := map[mcpclient.MCPErrorCategory]string{
+		mcpclient.MCPToolNotFound: "the MCP server has no update-task tool - update TDD-Pro so the TUI and server match",
+		mcpclient.MCPInvalidArgs:  "the MCP server rejected update-task: taskId is required",
+		mcpclient.MCPParseError:   "couldn't read the MCP server's reply to update-task: taskId is required",
+		mcpclient.MCPServerError:  "update-task failed on the MCP server: taskId is required",
+	}
+	for category, want := range cases {
+		err := fmt.Errorf("saving: %w", &mcpclient.MCPError{Tool: "update-task", Category: category, Message: "taskId is required"})
+		if got := errorText(err); got != want {
+			t.Errorf("errorText(%s) = %q, want %q", category, got, want)
+		}
+	}
+	if got := errorText(errors.New("disk full")); got != "disk full" {
+		t.Errorf("Expected other errors unchanged, got %q", got)
+	}
+}
@@ -0,0 +1,46 @@
+package components
+
+import (
+	"tddpro/internal/mcpclient"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// missingCriteria reports a task with no acceptance criteria, which can't be
+// test-driven until its spec is finished
+func missingCriteria(task mcpclient.Task) bool {
+	return len(task.EvaluationCriteria) == 0
+}
+
+// missingCriteriaBadge flags a task without acceptance criteria
+func (p *Prompt) missingCriteriaBadge() string {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Error)).Bold(true).Render("! no criteria")
+}
+
+// missingCriteriaCount returns how many of a feature's tasks lack acceptance
+// criteria, from cached details only like taskProgress
+func (p *Prompt) missingCriteriaCount(featureID string) int {
+	if p.featureDetails == nil {
+		return 0
+	}
+	detail, ok := p.featureDetails.peek(featureID)
+	if !ok {
+		return 0
+	}
+	missing := 0
+	for _, task := range detail.Tasks {
+		if missingCriteria(task) {
+			missing++
+		}
+	}
+	return missing
+}
+
+// missingCriteriaView renders the sidebar's "!" for a feature with tasks
+// missing acceptance criteria, "" otherwise
+func (p *Prompt) missingCriteriaView(featureID string) string {
+	if p.missingCriteriaCount(featureID) == 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Error)).Bold(true).Render("!")
+}
@@ -0,0 +1,61 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTaskFilter_FlagsTasksWithoutCriteria(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.focusState, p.FeaturesTab = 2, 1
+	p.WindowWidth, p.WindowHeight = 120, 40
+
+	view := p.renderTasksForFeature(p.SelectedFeature)
+	if strings.Count(view, "! no criteria") != 1 {
+		t.Errorf("Expected only the sign-out task flagged, got %q", view)
+	}
+	p.compactTasks = true
+	if view := p.renderTasksForFeature(p.SelectedFeature); strings.Count(view, "! no criteria") != 1 {
+		t.Errorf("Expected the compact badge to flag the sign-out task, got %q", view)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	if got := p.visibleTaskIndices(); len(got) != 1 || got[0] != 2 || p.selectedTaskIndex != 2 {
+		t.Fatalf("Expected only task 3 shown and selected, got %v selected %d", got, p.selectedTaskIndex)
+	}
+	if p.View(); !strings.Contains(p.View(), "Tasks (t) · no criteria") {
+		t.Error("Expected the filter in the Tasks tab title")
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if got := p.visibleTaskIndices(); len(got) != 0 {
+		t.Errorf("Expected no complete tasks without criteria, got %v", got)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.taskFilter.active() || len(p.visibleTaskIndices()) != 3 {
+		t.Error("Expected esc to clear the criteria filter too")
+	}
+
+	// The sidebar flags features with criteria-less tasks once they're cached
+	sidebar := p.generateSidebarContent()
+	userAuth := sidebarLine(sidebar, "User Authentication")
+	if !strings.HasSuffix(userAuth, "done !") {
+		t.Errorf("Expected user-auth flagged in the sidebar, got %q", userAuth)
+	}
+	p.tasksFor("search")
+	if search := sidebarLine(p.generateSidebarContent(), "Full-Text Search"); strings.Contains(search, "!") {
+		t.Errorf("Expected search not flagged, got %q", search)
+	}
+}
+
+// sidebarLine returns the sidebar line naming a feature
+func sidebarLine(sidebar, name string) string {
+	for _, line := range strings.Split(sidebar, "\n") {
+		if strings.Contains(line, name) {
+			return line
+		}
+	}
+	return ""
+}
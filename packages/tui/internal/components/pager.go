@@ -0,0 +1,62 @@
+package components
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultPager is used when $PAGER is unset
+const defaultPager = "less -R"
+
+// prdPagerClosedMsg is sent when the pager showing the PRD exits
+type prdPagerClosedMsg struct {
+	err error
+}
+
+// pagerCommand splits $PAGER (or the default) into a command, checking that the binary exists
+func pagerCommand() ([]string, error) {
+	pager := strings.TrimSpace(os.Getenv("PAGER"))
+	if pager == "" {
+		pager = defaultPager
+	}
+	args := strings.Fields(pager)
+	if _, err := exec.LookPath(args[0]); err != nil {
+		return nil, fmt.Errorf("pager %q not found", args[0])
+	}
+	return args, nil
+}
+
+// openPRDPager pipes the selected feature's PRD into $PAGER, falling back to
+// inline scrolling when no pager is available. The PRD comes from the cache,
+// or is loaded first.
+func (p *Prompt) openPRDPager() (*Prompt, tea.Cmd) {
+	if p.SelectedFeature == nil || p.MCP == nil {
+		p.StatusBar = "No feature selected"
+		return p, nil
+	}
+	return p, p.withPRD(p.SelectedFeature.ID, p.pagePRD)
+}
+
+// pagePRD runs $PAGER on a loaded PRD
+func (p *Prompt) pagePRD(prdContent string) tea.Cmd {
+	if prdContent == "" {
+		p.StatusBar = "No PRD document available"
+		return nil
+	}
+	args, err := pagerCommand()
+	if err != nil {
+		p.StatusBar = fmt.Sprintf("%v - scroll the PRD inline with ↑↓", err)
+		return nil
+	}
+
+	p.StatusBar = ""
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(prdContent)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return prdPagerClosedMsg{err: err}
+	})
+}
@@ -0,0 +1,46 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"tddpro/internal/mcpclient"
+)
+
+func TestOpenPRDPager_MissingPagerFallsBackInline(t *testing.T) {
+	p := newDemoPrompt(t)
+	client := &countingClient{DemoClient: mcpclient.NewDemoClient()}
+	p.MCP = client
+	p.focusState = 1
+	t.Setenv("PAGER", "tdd-pro-no-such-pager")
+
+	// An uncached PRD is loaded in the background before the pager runs
+	p.prds.invalidate(p.SelectedFeature.ID)
+	_, cmd := p.openPRDPager()
+	if cmd == nil || client.docs != 0 || p.StatusBar != "Loading PRD..." {
+		t.Fatalf("Expected the PRD loaded off the event loop, got %d fetches and %q", client.docs, p.StatusBar)
+	}
+	_, cmd = p.Update(prdLoadedMsg{featureID: p.SelectedFeature.ID, content: "# PRD"})
+	if cmd != nil {
+		t.Error("Expected no pager command when the pager binary is missing")
+	}
+	if !strings.Contains(p.StatusBar, "tdd-pro-no-such-pager") || !strings.Contains(p.StatusBar, "inline") {
+		t.Errorf("Expected status to explain the inline fallback, got %q", p.StatusBar)
+	}
+
+	// Once cached the PRD isn't fetched again
+	if _, cmd = p.openPRDPager(); cmd != nil || client.docs != 0 || !strings.Contains(p.StatusBar, "inline") {
+		t.Errorf("Expected the cached PRD to be used, got %d fetches and %q", client.docs, p.StatusBar)
+	}
+}
+
+func TestPagerCommand_DefaultsToLess(t *testing.T) {
+	t.Setenv("PAGER", "")
+	args, err := pagerCommand()
+	if err != nil {
+		t.Skipf("less not installed: %v", err)
+	}
+	if strings.Join(args, " ") != defaultPager {
+		t.Errorf("Expected %q, got %v", defaultPager, args)
+	}
+}
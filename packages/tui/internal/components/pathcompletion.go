@@ -0,0 +1,100 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sahilm/fuzzy"
+)
+
+// dirCommands are the commands whose argument is a directory
+var dirCommands = map[string]bool{
+	"/plan":    true,
+	"/init":    true,
+	"/destroy": true,
+}
+
+// maxPathCompletions caps how many directories the dialog lists
+const maxPathCompletions = 50
+
+// PathCompletionProvider completes the directory argument of dirCommands,
+// relative to the working directory
+type PathCompletionProvider struct{}
+
+func NewPathCompletionProvider() *PathCompletionProvider {
+	return &PathCompletionProvider{}
+}
+
+func (c *PathCompletionProvider) GetID() string {
+	return "paths"
+}
+
+// isDirArgument reports whether input is a dirCommand followed by a space,
+// i.e. the user is typing its directory argument
+func isDirArgument(input string) bool {
+	cmd, _, found := strings.Cut(input, " ")
+	return found && dirCommands[cmd]
+}
+
+// GetCompletions lists directories matching the last word of the argument.
+// The query is the input without its leading slash, as the dialog passes it.
+func (c *PathCompletionProvider) GetCompletions(query string) ([]CompletionItem, error) {
+	input := "/" + strings.TrimPrefix(query, "/")
+	if !isDirArgument(input) {
+		return nil, nil
+	}
+
+	// Complete the word being typed, leaving earlier ones (e.g. --dry-run) alone
+	word := input[strings.LastIndex(input, " ")+1:]
+	if strings.HasPrefix(word, "-") {
+		return nil, nil
+	}
+	prefix := strings.TrimSuffix(input, word)
+	dirPart, base := "", word
+	if i := strings.LastIndex(word, "/"); i >= 0 {
+		dirPart, base = word[:i+1], word[i+1:]
+	}
+
+	dir := dirPart
+	if dir == "" {
+		dir = "."
+	} else if rest, ok := strings.CutPrefix(dir, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, rest)
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil // nothing to offer for a directory that doesn't exist (yet)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		// Hidden directories only when asked for
+		if !entry.IsDir() || (strings.HasPrefix(entry.Name(), ".") && !strings.HasPrefix(base, ".")) {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	if base != "" {
+		matches := fuzzy.Find(base, names)
+		names = names[:0:0]
+		for _, match := range matches {
+			names = append(names, match.Str)
+		}
+	} else {
+		sort.Strings(names)
+	}
+	if len(names) > maxPathCompletions {
+		names = names[:maxPathCompletions]
+	}
+
+	items := make([]CompletionItem, len(names))
+	for i, name := range names {
+		path := dirPart + name + "/"
+		items[i] = CompletionItem{Title: path, Description: "directory", Value: prefix + path}
+	}
+	return items, nil
+}
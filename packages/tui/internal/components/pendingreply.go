@@ -0,0 +1,76 @@
+package components
+
+import (
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// newReplySpinner builds the spinner shown while a reply is awaited
+func newReplySpinner(theme Theme) spinner.Model {
+	return spinner.New(
+		spinner.WithSpinner(spinner.Dot),
+		spinner.WithStyle(lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Focus))),
+	)
+}
+
+// isPendingReply reports whether the message numbered seq is still awaiting
+// a reply, i.e. it wasn't cancelled, cleared or superseded
+func (p *Prompt) isPendingReply(seq int) bool {
+	return p.awaitingReply && p.replySeq == seq
+}
+
+// cancelReply stops waiting for the pending reply and puts the message back
+// in the input so it can be edited and resent. A listen in progress is
+// aborted, closing the session so the abandoned reply can't be read as the
+// next message's; a send in progress finishes and its session is closed when
+// it lands, holding back the next message's send until then.
+func (p *Prompt) cancelReply() {
+	if !p.awaitingReply {
+		return
+	}
+	p.awaitingReply = false
+	p.reconnecting = false
+	if p.replyCancel != nil {
+		p.replyCancel()
+		p.replyCancel = nil
+	}
+	if p.replyIndex < len(p.Conversation.Messages) {
+		p.Conversation.SetState(p.replyIndex, MessageCancelled)
+		// Don't clobber anything typed while waiting
+		if p.isEmpty() {
+			p.textInput.SetValue(p.Conversation.Messages[p.replyIndex].Content)
+			p.textInput.CursorEnd()
+		}
+	}
+	p.StatusBar = "Request cancelled"
+}
+
+// spinnerActive reports whether something is being waited for: a reply, or
+// tasks or a PRD loading
+func (p *Prompt) spinnerActive() bool {
+	return p.awaitingReply || len(p.tasksLoading) > 0 || len(p.prds.loading) > 0
+}
+
+// updateReplySpinner animates the spinner while a reply is awaited or tasks
+// or a PRD load, and lets it stop once there's nothing to wait for
+func (p *Prompt) updateReplySpinner(msg tea.Msg) (tea.Cmd, bool) {
+	tick, ok := msg.(spinner.TickMsg)
+	if !ok {
+		return nil, false
+	}
+	if !p.spinnerActive() {
+		return nil, true
+	}
+	var cmd tea.Cmd
+	p.spinner, cmd = p.spinner.Update(tick)
+	return cmd, true
+}
+
+// replyStatus prefixes the status with the spinner while a reply is awaited
+func (p *Prompt) replyStatus(status string) string {
+	if !p.awaitingReply {
+		return status
+	}
+	return p.spinner.View() + status
+}
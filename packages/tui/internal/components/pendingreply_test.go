@@ -0,0 +1,126 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSubmitMessage_SendsInBackgroundAndEscCancels(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.FeaturesViewActive = false
+
+	// Submitting only queues the send; nothing reaches the client inside Update
+	cmd := p.submitMessage("Plan the login flow")
+	if cmd == nil || !p.awaitingReply {
+		t.Fatalf("Expected the prompt to await a reply after submitting")
+	}
+	if !strings.Contains(p.replyStatus(p.StatusBar), p.spinner.View()) {
+		t.Errorf("Expected the spinner in the status while waiting, got %q", p.replyStatus(p.StatusBar))
+	}
+
+	var sendCmd tea.Cmd
+	for _, c := range cmd().(tea.BatchMsg) {
+		if msg, ok := c().(messageSendMsg); ok {
+			_, sendCmd = p.Update(msg)
+		}
+	}
+	if sendCmd == nil {
+		t.Fatalf("Expected the send to run as a command")
+	}
+	sent := sendCmd()
+	_, waitCmd := p.Update(sent)
+	if waitCmd == nil || !p.awaitingReply {
+		t.Fatalf("Expected to keep waiting for the reply after the send, status %q", p.StatusBar)
+	}
+
+	// The UI still takes keys while the reply is pending
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.awaitingReply || p.StatusBar != "Request cancelled" {
+		t.Fatalf("Expected esc to cancel the pending reply, status %q", p.StatusBar)
+	}
+
+	// The aborted listen's result is dropped
+	p.Update(waitCmd())
+	for _, msg := range p.Conversation.Messages {
+		if msg.Role == RoleAgent {
+			t.Fatalf("Expected the cancelled reply to be ignored, got %q", msg.Content)
+		}
+	}
+}
+
+func TestCancelReply_RestoresInputAndKeepsSessionUsable(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.FeaturesViewActive = false
+
+	var waitCmd tea.Cmd
+	for _, c := range p.submitMessage("Plan the login flow")().(tea.BatchMsg) {
+		if msg, ok := c().(messageSendMsg); ok {
+			_, sendCmd := p.Update(msg)
+			_, waitCmd = p.Update(sendCmd())
+		}
+	}
+	// ctrl+c cancels the request rather than clearing or quitting
+	if _, cmd := p.Update(tea.KeyMsg{Type: tea.KeyCtrlC}); cmd != nil || p.awaitingReply {
+		t.Fatalf("Expected ctrl+c to cancel the pending request, status %q", p.StatusBar)
+	}
+	if p.StatusBar != "Request cancelled" || p.textInput.Value() != "Plan the login flow" {
+		t.Fatalf("Expected the message back in the input, got %q (status %q)", p.textInput.Value(), p.StatusBar)
+	}
+	if state := p.Conversation.Messages[0].State; state != MessageCancelled {
+		t.Errorf("Expected the message to be marked cancelled, got %v", state)
+	}
+	if !strings.Contains(p.Conversation.View(80, 10, p.theme), "(cancelled)") {
+		t.Errorf("Expected the conversation to show the cancellation")
+	}
+	// The aborted listen finishes in the background
+	p.Update(waitCmd())
+
+	// The edited message goes out on its own, without the cancelled reply
+	p.textInput.SetValue("Plan the signup flow")
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	runMessageCmds(p, cmd)
+	last := p.Conversation.Messages[len(p.Conversation.Messages)-1]
+	if last.Role != RoleAgent || last.Content != "(demo agent) You said: Plan the signup flow" {
+		t.Fatalf("Expected the resent message to be answered, got %+v (status %q)", last, p.StatusBar)
+	}
+}
+
+func TestCancelDuringSend_ResendWaitsForTheSendInFlight(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.FeaturesViewActive = false
+
+	var firstSend tea.Cmd
+	for _, c := range p.submitMessage("Plan the login flow")().(tea.BatchMsg) {
+		if msg, ok := c().(messageSendMsg); ok {
+			_, firstSend = p.Update(msg)
+		}
+	}
+	if firstSend == nil {
+		t.Fatalf("Expected the first send to run as a command")
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	// The resend is held back while the cancelled send is still running
+	p.textInput.SetValue("Plan the signup flow")
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	runMessageCmds(p, cmd)
+	if p.queuedSend == nil || !p.awaitingReply {
+		t.Fatalf("Expected the resend queued behind the send in flight, status %q", p.StatusBar)
+	}
+
+	// Once the cancelled send lands its session is closed and the resend goes out on a fresh one
+	_, cmd = p.Update(firstSend())
+	if cmd == nil {
+		t.Fatalf("Expected the queued send to start")
+	}
+	runMessageCmds(p, cmd)
+	last := p.Conversation.Messages[len(p.Conversation.Messages)-1]
+	if last.Role != RoleAgent || last.Content != "(demo agent) You said: Plan the signup flow" {
+		t.Fatalf("Expected the resent message to be answered, got %+v (status %q)", last, p.StatusBar)
+	}
+	if p.sendingSeq != 0 || p.queuedSend != nil {
+		t.Errorf("Expected no send left in flight, sendingSeq=%d", p.sendingSeq)
+	}
+}
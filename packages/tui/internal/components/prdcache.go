@@ -0,0 +1,137 @@
+package components
+
+import (
+	"errors"
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// errPRDLoading is returned by prdFor while a feature's PRD is being fetched
+// in the background
+var errPRDLoading = errors.New("PRD is loading")
+
+// prdCache holds PRD documents by feature ID so the feature data panel is
+// drawn without starting an MCP server per frame. It's only touched on the
+// event loop; fetches report back with prdLoadedMsg.
+type prdCache struct {
+	docs    map[string]string
+	loading map[string]bool  // features whose PRD is being fetched
+	errs    map[string]error // why a feature's PRD failed to load
+	// Actions waiting for a feature's PRD, run when it arrives; see withPRD
+	waiting map[string][]func(content string) tea.Cmd
+}
+
+// prdLoadedMsg delivers a PRD fetched by fetchPRD
+type prdLoadedMsg struct {
+	featureID string
+	content   string
+	err       error
+}
+
+// invalidate drops one feature's PRD, or every PRD when featureID is "", so
+// it's fetched again the next time it's shown
+func (c *prdCache) invalidate(featureID string) {
+	if featureID == "" {
+		c.docs, c.errs = nil, nil
+		return
+	}
+	delete(c.docs, featureID)
+	delete(c.errs, featureID)
+}
+
+// prdFor returns a feature's cached PRD for rendering. It never fetches: a
+// PRD that hasn't been loaded yet reports errPRDLoading (or why its load
+// failed) until fetchPRD delivers it.
+func (p *Prompt) prdFor(featureID string) (string, error) {
+	if content, ok := p.prds.docs[featureID]; ok {
+		return content, nil
+	}
+	if err := p.prds.errs[featureID]; err != nil {
+		return "", err
+	}
+	return "", errPRDLoading
+}
+
+// loadVisiblePRD starts fetching the selected feature's PRD when the Feature
+// Data tab is showing and it isn't cached, e.g. after moving to another
+// feature or saving an edit. A failed load isn't retried until ctrl+r.
+func (p *Prompt) loadVisiblePRD() tea.Cmd {
+	if !p.FeaturesViewActive || p.FeaturesTab != 0 || p.SelectedFeature == nil || p.MCP == nil {
+		return nil
+	}
+	featureID := p.SelectedFeature.ID
+	if _, ok := p.prds.docs[featureID]; ok || p.prds.errs[featureID] != nil || p.prds.loading[featureID] {
+		return nil
+	}
+	return p.fetchPRD(featureID)
+}
+
+// fetchPRD fetches a feature's PRD off the event loop, animating the spinner
+// until it arrives
+func (p *Prompt) fetchPRD(featureID string) tea.Cmd {
+	if p.prds.loading == nil {
+		p.prds.loading = map[string]bool{}
+	}
+	var tick tea.Cmd
+	if !p.spinnerActive() {
+		tick = p.spinner.Tick
+	}
+	p.prds.loading[featureID] = true
+	client := p.MCP
+	return tea.Batch(tick, func() tea.Msg {
+		content, err := client.GetFeatureDocumentViaStdio(featureID)
+		return prdLoadedMsg{featureID: featureID, content: content, err: err}
+	})
+}
+
+// withPRD runs use with a feature's PRD: straight away when it's cached,
+// otherwise once it has been fetched off the event loop. A PRD that failed to
+// load before is fetched again, since the user asked for it.
+func (p *Prompt) withPRD(featureID string, use func(content string) tea.Cmd) tea.Cmd {
+	if content, ok := p.prds.docs[featureID]; ok {
+		return use(content)
+	}
+	if p.prds.waiting == nil {
+		p.prds.waiting = map[string][]func(string) tea.Cmd{}
+	}
+	p.prds.waiting[featureID] = append(p.prds.waiting[featureID], use)
+	p.StatusBar = "Loading PRD..."
+	if p.prds.loading[featureID] {
+		return nil
+	}
+	return p.fetchPRD(featureID)
+}
+
+// updatePRDLoaded caches a PRD delivered by fetchPRD, or remembers why it
+// couldn't be loaded, and runs the actions waiting for it
+func (p *Prompt) updatePRDLoaded(msg tea.Msg) (tea.Cmd, bool) {
+	loaded, ok := msg.(prdLoadedMsg)
+	if !ok {
+		return nil, false
+	}
+	delete(p.prds.loading, loaded.featureID)
+	waiting := p.prds.waiting[loaded.featureID]
+	delete(p.prds.waiting, loaded.featureID)
+	if loaded.err != nil {
+		slog.Debug("loading PRD failed", "feature", loaded.featureID, "err", loaded.err)
+		if p.prds.errs == nil {
+			p.prds.errs = map[string]error{}
+		}
+		p.prds.errs[loaded.featureID] = loaded.err
+		if len(waiting) > 0 {
+			p.StatusBar = "Error getting PRD: " + errorText(loaded.err)
+		}
+		return nil, true
+	}
+	if p.prds.docs == nil {
+		p.prds.docs = map[string]string{}
+	}
+	p.prds.docs[loaded.featureID] = loaded.content
+	delete(p.prds.errs, loaded.featureID)
+	var cmds []tea.Cmd
+	for _, use := range waiting {
+		cmds = append(cmds, use(loaded.content))
+	}
+	return tea.Batch(cmds...), true
+}
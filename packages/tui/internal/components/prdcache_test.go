@@ -0,0 +1,49 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tddpro/internal/mcpclient"
+)
+
+func TestPRD_CachedPerFeatureAndRefetchedAfterSave(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.WindowWidth, p.WindowHeight = 120, 40
+	client := &countingClient{DemoClient: mcpclient.NewDemoClient()}
+	p.MCP = client
+	p.prds.invalidate("")
+	p.focusState = 1
+
+	_, cmd := p.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	if view := p.renderPRDDocument(p.SelectedFeature); client.docs != 0 || !strings.Contains(view, "Loading PRD…") {
+		t.Fatalf("Expected a placeholder while the PRD is fetched in the background, got %d fetches and %q", client.docs, view)
+	}
+	runMessageCmds(p, cmd)
+	if view := p.renderPRDDocument(p.SelectedFeature); client.docs != 1 || !strings.Contains(view, "# User Authentication") {
+		t.Fatalf("Expected the fetched PRD, got %d fetches and %q", client.docs, view)
+	}
+
+	// Scrolling redraws from the cache
+	for i := 0; i < 3; i++ {
+		_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyDown})
+		runMessageCmds(p, cmd)
+		p.View()
+	}
+	if client.docs != 1 {
+		t.Errorf("Expected scrolling to reuse the PRD, got %d fetches", client.docs)
+	}
+
+	// A saved edit and ctrl+r each fetch it again
+	runMessageCmds(p, p.savePRD("# Sign-in\n"))
+	if view := p.renderPRDDocument(p.SelectedFeature); client.docs != 2 || !strings.Contains(view, "# Sign-in") {
+		t.Errorf("Expected the saved PRD refetched, got %d fetches and %q", client.docs, view)
+	}
+	_, cmd = p.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	runMessageCmds(p, cmd)
+	if client.docs != 3 {
+		t.Errorf("Expected ctrl+r to refetch the PRD, got %d fetches", client.docs)
+	}
+}
@@ -0,0 +1,24 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxPRDEditLines is as many lines as the inline PRD textarea holds; it
+// silently drops anything past that
+const maxPRDEditLines = 10000
+
+// pastePRD inserts a bracketed paste into the inline PRD editor as one block.
+// Pasted text never reaches the save and cancel keys, and a paste the editor
+// can't hold is refused whole rather than cut off.
+func (p *Prompt) pastePRD(runes []rune) {
+	text := string(runes)
+	lines := strings.Count(text, "\n") + 1
+	if p.prdEditTextarea.LineCount()+lines-1 > maxPRDEditLines {
+		p.StatusBar = fmt.Sprintf("Paste not inserted: the inline editor holds %d lines and this paste has %d. Edit the PRD with an external editor instead (editor in config.yml)", maxPRDEditLines, lines)
+		return
+	}
+	p.prdEditTextarea.InsertString(text)
+	p.StatusBar = fmt.Sprintf("Pasted %d lines - Press Ctrl+S (or Cmd+S) to save, Esc to cancel", lines)
+}
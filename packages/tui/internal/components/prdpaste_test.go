@@ -0,0 +1,41 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestInlinePRDEdit_PasteIsOneBlockInsert(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.startInlinePRDEdit("# PRD\n")
+	p.prdEditTextarea.CursorEnd()
+
+	// Control characters in a paste must not cancel or save the edit
+	pasted := "## Goals\n- fast\x1b\n- safe\x13\n"
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(pasted), Paste: true})
+	if cmd != nil || !p.editingPRD {
+		t.Fatalf("Expected the paste to leave the PRD editor open, status %q", p.StatusBar)
+	}
+	if got := p.prdEditTextarea.Value(); !strings.Contains(got, "## Goals\n- fast\n- safe") {
+		t.Errorf("Expected the paste inserted as a block, got %q", got)
+	}
+	if !strings.HasPrefix(p.StatusBar, "Pasted 4 lines") {
+		t.Errorf("Expected the paste to be reported, got %q", p.StatusBar)
+	}
+
+	// Long PRDs keep accepting new lines
+	p.prdEditTextarea.SetValue(strings.Repeat("line\n", 150))
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if lines := p.prdEditTextarea.LineCount(); lines != 152 {
+		t.Errorf("Expected enter to add a line to a long PRD, got %d lines", lines)
+	}
+
+	// A paste the editor can't hold is refused whole
+	before := p.prdEditTextarea.Value()
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(strings.Repeat("x\n", maxPRDEditLines)), Paste: true})
+	if p.prdEditTextarea.Value() != before || !strings.HasPrefix(p.StatusBar, "Paste not inserted") {
+		t.Errorf("Expected an oversized paste to be refused, status %q", p.StatusBar)
+	}
+}
@@ -0,0 +1,22 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+)
+
+// readingWordsPerMinute is the reading speed behind the PRD reading-time estimate
+const readingWordsPerMinute = 200
+
+// documentStats summarizes a document's length as "N words · M min read".
+// Words are whitespace-separated runs, so markdown markup counts too; the
+// reading time rounds up and is at least a minute.
+func documentStats(content string) string {
+	words := len(strings.Fields(content))
+	minutes := max((words+readingWordsPerMinute-1)/readingWordsPerMinute, 1)
+	unit := "words"
+	if words == 1 {
+		unit = "word"
+	}
+	return fmt.Sprintf("%d %s · %d min read", words, unit, minutes)
+}
@@ -0,0 +1,29 @@
+package components
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDocumentStats(t *testing.T) {
+	tests := map[string]string{
+		"":                              "0 words · 1 min read",
+		"# Title":                       "2 words · 1 min read",
+		"one":                           "1 word · 1 min read",
+		strings.Repeat("word\n\t", 201): "201 words · 2 min read",
+		"über  naïve façade — déjà-vu ok": "6 words · 1 min read",
+	}
+	for content, want := range tests {
+		if got := documentStats(content); got != want {
+			t.Errorf("documentStats(%q) = %q, want %q", content, got, want)
+		}
+	}
+
+	p := newDemoPrompt(t)
+	p.focusState = 1
+	p.WindowWidth, p.WindowHeight = 120, 40
+	prd, _ := p.MCP.GetFeatureDocumentViaStdio("user-auth")
+	if view := p.renderPRDDocument(p.SelectedFeature); !strings.Contains(view, documentStats(prd)) {
+		t.Errorf("Expected the PRD panel to show %q", documentStats(prd))
+	}
+}
@@ -1,17 +1,26 @@
 package components
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"os"
 	"os/exec"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"tddpro/internal/commands"
 	"tddpro/internal/mcpclient"
 	"tddpro/internal/streams"
 	"tddpro/internal/util"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -25,18 +34,42 @@ type Prompt struct {
 
 	ctrlCPressed     bool
 	APIURL           string
-	MCP              *mcpclient.MCPClient
+	WorkflowURL      string // workflow API base URL, defaults to APIURL when empty
+	MCP              mcpclient.Client
 	StatusBar        string // New field for status messages
 	promptingForCWD  bool
 	awaitingCWDInput bool
 	cwdCandidate     string
 	version          string // Dynamic version string
+	theme            Theme  // Colors used by all render helpers
 
 	// New completion system
 	completionManager *CompletionManager
 	completionDialog  *CompletionDialog
 
 	ThinkingState      []string // last 3 thinking/tool call messages
+	ThinkingLog        []string // every thinking/tool call message of the current run
+	thinkingLogOpen    bool     // the full log panel (ctrl+l) is shown
+	thinkingLogScroll  int
+	workflowLog        []workflowLogEntry // every event of the current /plan run
+	workflowLogOpen    bool               // the event log panel (ctrl+t) is shown
+	workflowLogScroll  int
+	Conversation       Conversation
+	transcript         []transcriptEntry  // every message and reply this session, kept across /clear
+	history            History            // previously submitted inputs, recalled with up/down
+	historyPicker      *historyPicker     // ctrl+r search over history, nil when closed
+	sessionTokens      int                // running estimate of tokens exchanged with the agent
+	idleTimeout        time.Duration      // close the SSE connection after this long without sending; 0 disables
+	lastActivity       time.Time          // when a message was last sent or received
+	idleDisconnected   bool               // the SSE connection was closed by the idle timeout
+	awaitingReply      bool               // a message is being sent or its reply read in the background
+	replyIndex         int                // conversation index of the message awaiting a reply
+	replySeq           int                // numbers sent messages; indexes restart after /clear
+	sendingSeq         int                // the message whose send is in flight, 0 if none
+	queuedSend         *messageSendMsg    // a send held back until the one in flight lands
+	replyCancel        context.CancelFunc // aborts the listen for the pending reply; nil while sending
+	spinner            spinner.Model      // animates while awaitingReply
+	reconnecting       bool               // the status bar shows a reconnect in progress
 	FeaturesViewActive bool
 	FeaturesData       mcpclient.FeaturesData
 	FeaturesTab        int // 0=Data, 1=Tasks
@@ -52,9 +85,35 @@ type Prompt struct {
 	focusState int
 
 	// Task selection state
-	selectedTaskIndex int  // Which task is selected in Tasks view
-	editingTask       bool // Whether we're in task edit mode
-	taskEditForm      *TaskEditForm
+	selectedTaskIndex   int            // Which task is selected in Tasks view
+	taskIndexFeature    string         // feature selectedTaskIndex belongs to
+	taskSelections      map[string]int // last selected task of each feature visited
+	compactTasks        bool           // one line per task, toggled with z
+	taskFilter          taskFilter     // search and completion filter of the Tasks view
+	pendingTaskNumber   string         // digits typed so far for jump-to-task
+	pendingCopy         bool           // y was pressed and the copy target key is next
+	featureDetails      *featureCache
+	tasksLoading        map[string]bool  // features whose tasks are being fetched
+	taskLoadErrs        map[string]error // why a feature's tasks failed to load
+	prds                prdCache         // PRDs shown in the feature data panel
+	workflows           *workflowRuns    // running workflows, cancelled by Shutdown
+	undo                []undoEntry      // recent edits, newest last
+	toast               *toast           // transient save result shown over the header
+	toastSeq            int
+	editor              string     // command PRDs are edited with; "" uses $EDITOR
+	keyReveal           *keyReveal // API key line shown by /auth show, nil when dismissed
+	keyRevealSeq        int
+	statusEdit          string            // status chosen with ctrl+t, saved with enter
+	statusEditID        string            // feature statusEdit belongs to
+	fieldsEditID        string            // feature whose name or description was typed into
+	marked              map[string]bool   // features marked with v for a batch status change
+	collapsedGroups     map[string]bool   // sidebar status groups collapsed for the session
+	featureSort         string            // order within each sidebar group, see featureSortOrders
+	workflowProgress    *workflowProgress // steps of the last /plan run, nil when none
+	featureStatusFilter string            // status group /features was opened with, "" for all
+	deps                *dependencyEditor // dependency list with the keyboard, nil when closed
+	editingTask         bool              // Whether we're in task edit mode
+	taskEditForm        *TaskEditForm
 
 	// PRD editing state
 	editingPRD      bool           // Whether we're in PRD edit mode
@@ -65,13 +124,44 @@ type Prompt struct {
 	featureNameEdit        textinput.Model // Always editable feature name
 	featureDescriptionEdit textinput.Model // Always editable feature description
 
+	// Searchable keyboard shortcut help
+	help HelpOverlay
+
+	// ctrl+p command palette: every command and the current view's keys
+	palette CommandPalette
+
+	statusMaxLines int  // status bar line limit, 0 for half the window
+	tabSpaces      int  // spaces Tab inserts in non-command input; 0 makes Tab a no-op
+	readOnly       bool // browsing only: every edit is refused with readOnlyStatus
+
+	checkUpdates  bool                     // look for a newer release at startup
+	debugTools    bool                     // /tool may call MCP tools directly
+	workflowPanel *workflowPanel           // raw /workflow response, nil when closed
+	releaseURL    string                   // latest release endpoint, release.LatestURL unless overridden in tests
+	configCheck   func() ([]string, error) // validates the config files for /doctor, nil to skip
+
+	quitConfirmActive bool // quitting with unsaved edits waits for y/n
+
 	// Destroy confirmation dialog
 	destroyConfirmActive bool
 	destroyTargetDir     string
+	destroyFiles         int   // files under destroyTargetDir, shown in the confirmation
+	destroySize          int64 // their total size in bytes
+
+	// /import waiting for confirmation, nil when none
+	pendingImport *pendingImport
+
+	featureForm *featureCreateForm // new feature form opened with n, nil when closed
+	featuresErr error              // why the last /features listing failed, nil when it loaded
+
+	featuresRefresh    time.Duration // re-fetch features this often while the view is open; 0 disables
+	featuresRefreshing bool          // an auto-refresh fetch is in flight
+	featuresLoading    bool          // /features is waiting for the listing
 
 	// Command handling
 	initCommand *commands.InitCommand
 	authCommand *commands.AuthCommand
+	mcpCommand  *commands.MCPCommand
 }
 
 func NewPrompt() Prompt {
@@ -83,8 +173,8 @@ func NewPrompt() Prompt {
 	ti.Prompt = "" // Remove default prompt since we'll add our own ">"
 
 	// Style the textinput to match Bagels theme without background
-	ti.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
-	ti.PlaceholderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	ti.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(DarkTheme.Text))
+	ti.PlaceholderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(DarkTheme.Muted))
 
 	// Initialize feature editing text inputs
 	nameEdit := textinput.New()
@@ -99,20 +189,32 @@ func NewPrompt() Prompt {
 
 	prdEdit := textarea.New()
 	prdEdit.Placeholder = "Edit PRD document..."
+	// PRDs can be any length. Unset before sizing, or the textarea's line
+	// cache shrinks to the limit and a long PRD is re-wrapped on every keypress.
+	prdEdit.MaxHeight = 0
 	prdEdit.SetWidth(80)
 	prdEdit.SetHeight(15)
 
 	return Prompt{
 		textInput:              ti,
+		theme:                  DarkTheme,
 		completionManager:      NewCompletionManager(),
 		completionDialog:       NewCompletionDialog(),
 		featureNameEdit:        nameEdit,
 		featureDescriptionEdit: descEdit,
 		prdEditTextarea:        prdEdit,
+		featureDetails:         newFeatureCache(),
+		workflows:              newWorkflowRuns(),
+		spinner:                newReplySpinner(DarkTheme),
 	}
 }
 
 func NewPromptWithAPI(apiURL string, version string) Prompt {
+	return NewPromptWithClient(mcpclient.NewMCPClient(apiURL), apiURL, version)
+}
+
+// NewPromptWithClient creates a prompt backed by the given client, e.g. a DemoClient
+func NewPromptWithClient(client mcpclient.Client, apiURL string, version string) Prompt {
 	ti := textinput.New()
 	ti.Placeholder = "Type a command or message..."
 	ti.Focus()
@@ -121,8 +223,8 @@ func NewPromptWithAPI(apiURL string, version string) Prompt {
 	ti.Prompt = "" // Remove default prompt since we'll add our own ">"
 
 	// Style the textinput to match Bagels theme without background
-	ti.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
-	ti.PlaceholderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	ti.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(DarkTheme.Text))
+	ti.PlaceholderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(DarkTheme.Muted))
 
 	// Initialize feature editing text inputs
 	nameEdit := textinput.New()
@@ -137,21 +239,38 @@ func NewPromptWithAPI(apiURL string, version string) Prompt {
 
 	prdEdit := textarea.New()
 	prdEdit.Placeholder = "Edit PRD document..."
+	// PRDs can be any length. Unset before sizing, or the textarea's line
+	// cache shrinks to the limit and a long PRD is re-wrapped on every keypress.
+	prdEdit.MaxHeight = 0
 	prdEdit.SetWidth(80)
 	prdEdit.SetHeight(15)
 
-	mcp := mcpclient.NewMCPClient(apiURL)
 	return Prompt{
 		textInput:              ti,
 		APIURL:                 apiURL,
-		MCP:                    mcp,
+		MCP:                    client,
 		StatusBar:              "",
 		version:                version,
+		theme:                  DarkTheme,
 		completionManager:      NewCompletionManager(),
 		completionDialog:       NewCompletionDialog(),
 		featureNameEdit:        nameEdit,
 		featureDescriptionEdit: descEdit,
 		prdEditTextarea:        prdEdit,
+		featureDetails:         newFeatureCache(),
+		workflows:              newWorkflowRuns(),
+		spinner:                newReplySpinner(DarkTheme),
+	}
+}
+
+// SetTheme switches the colors used to render the prompt and its dialogs
+func (p *Prompt) SetTheme(theme Theme) {
+	p.theme = theme
+	p.textInput.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Text))
+	p.textInput.PlaceholderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Muted))
+	p.spinner.Style = lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Focus))
+	if p.completionDialog != nil {
+		p.completionDialog.SetTheme(theme)
 	}
 }
 
@@ -161,81 +280,155 @@ type CommandHandler func(*Prompt, string) (*Prompt, tea.Cmd)
 
 // Command registry
 var commandHandlers = map[string]CommandHandler{
-	"/help":     handleHelp,
-	"/init":     handleInit,
-	"/auth":     handleAuth,
-	"/destroy":  handleDestroy,
-	"/features": handleFeatures,
-	"/quit":     handleQuit,
+	"/help":            handleHelp,
+	"/clear":           handleClear,
+	"/status":          handleStatus,
+	"/doctor":          handleDoctor,
+	"/tool":            handleTool,
+	"/workflow":        handleWorkflow,
+	"/version-check":   handleVersionCheck,
+	"/init":            handleInit,
+	"/auth":            handleAuth,
+	"/mcp":             handleMCP,
+	"/destroy":         handleDestroy,
+	"/undo":            handleUndo,
+	"/features":        handleFeatures,
+	"/plan":            handlePlan,
+	"/export":          handleExport,
+	"/save-transcript": handleSaveTranscript,
+	"/import":          handleImport,
+	"/quit":            handleQuit,
 }
 
+// handlePlan starts the tddPlanning workflow. Its events are delivered as
+// messages to the event log (ctrl+t), which --watch opens straight away.
 func handlePlan(p *Prompt, arg string) (*Prompt, tea.Cmd) {
-	cwd := arg
-	if cwd == "" {
-		var err error
-		cwd, err = os.Getwd()
-		if err != nil {
-			p.StatusBar = "Error getting current directory: " + err.Error()
-			p.textInput.SetValue("")
-			return p, nil
+	// The workflow writes the features it generates
+	if p.blockedByReadOnly() {
+		p.textInput.SetValue("")
+		return p, nil
+	}
+	watch := false
+	dir := ""
+	for _, field := range strings.Fields(arg) {
+		if field == "--watch" {
+			watch = true
+		} else {
+			dir = field
 		}
 	}
+	cwd, err := commandDir(dir)
+	if err != nil {
+		p.StatusBar = "Error: " + err.Error()
+		p.textInput.SetValue("")
+		return p, nil
+	}
 	p.StatusBar = "Running tddPlanning workflow..."
+	p.workflowProgress = &workflowProgress{}
 	p.ThinkingState = nil
+	p.ThinkingLog = nil
+	p.workflowLog = nil
+	p.workflowLogScroll = 0
+	if watch {
+		p.workflowLogOpen = true
+	}
 
-	// Start the workflow run and watcher
-	go func(p *Prompt, cwd string) {
-		wr, err := streams.NewWorkflowRun(cwd)
-		if err != nil {
-			p.StatusBar = "Error: " + err.Error()
-			return
-		}
-		wr.Watch()
-		err = wr.StartWorkflow(cwd)
-		if err != nil {
-			p.StatusBar = "Error: " + err.Error()
-			return
-		}
-		for evt := range wr.Events {
-			// Parse event type and payload
-			var payload map[string]interface{}
-			json.Unmarshal(evt.Payload, &payload)
-			// Example: handle 'thinking', 'clarification', 'result', etc.
-			if step, ok := payload["step"].(string); ok && step == "thinking" {
-				msg := payload["msg"].(string)
-				p.ThinkingState = append(p.ThinkingState, msg)
-				if len(p.ThinkingState) > 3 {
-					p.ThinkingState = p.ThinkingState[len(p.ThinkingState)-3:]
-				}
-				p.StatusBar = "Workflow is thinking..."
-			} else if step == "clarification" {
-				prompt := payload["prompt"].(string)
-				p.StatusBar = prompt
-				// Optionally yield prompt to user for input
-			} else if step == "finished" {
-				result := payload["result"].(string)
-				p.StatusBar = "Workflow finished: " + result
-				p.ThinkingState = nil
-				p.textInput.SetValue("")
-			}
-		}
-	}(p, cwd)
+	var headers map[string]string
+	var client *http.Client
+	if p.MCP != nil {
+		headers = p.MCP.RequestHeaders()
+		client = p.MCP.HTTPClient()
+	}
+	return p, p.startWorkflow(cwd, streams.WithBaseURL(p.workflowBaseURL()), streams.WithHeaders(headers), streams.WithHTTPClient(client))
+}
 
+func handleHelp(p *Prompt, arg string) (*Prompt, tea.Cmd) {
+	p.help.Open(KeyMap)
+	p.textInput.SetValue("")
+	return p, textinput.Blink
+}
+
+// handleStatus shows the backend connection and MCP server version, flagging
+// a server that doesn't match this TUI build
+func handleStatus(p *Prompt, arg string) (*Prompt, tea.Cmd) {
+	p.textInput.SetValue("")
+	if p.MCP == nil {
+		p.StatusBar = "MCP client not available"
+		return p, nil
+	}
+	lines := []string{
+		fmt.Sprintf("API:        %s (%s)", p.APIURL, p.MCP.ConnectionState()),
+		fmt.Sprintf("TUI:        %s", p.version),
+		apiKeyStatus(),
+	}
+	info, err := p.MCP.ServerInfoViaStdio()
+	if info != nil {
+		lines = append(lines,
+			fmt.Sprintf("MCP server: %s %s (protocol %s)", info.Name, info.Version, info.ProtocolVersion),
+			fmt.Sprintf("Path:       %s", info.Path),
+		)
+	}
+	switch {
+	case err != nil:
+		lines = append(lines, "⚠ MCP server: "+err.Error())
+	default:
+		if problems := mcpclient.CheckCompatibility(info, p.version); len(problems) > 0 {
+			lines = append(lines, "⚠ Incompatible: "+strings.Join(problems, "; "))
+		} else {
+			lines = append(lines, "✓ Server is compatible with this TUI")
+		}
+	}
+	p.StatusBar = strings.Join(lines, "\n")
 	return p, nil
 }
 
-func handleHelp(p *Prompt, arg string) (*Prompt, tea.Cmd) {
-	p.StatusBar = "Commands:\n" +
-		"/init     Initialize TDD-Pro in current directory\n" +
-		"/auth     Configure Claude API key for TDD-Pro agents\n" +
-		"/destroy  Remove TDD-Pro from current directory\n" +
-		"/features List and manage project features\n" +
-		"/quit     Exit the TDD-Pro TUI"
+// handleClear resets the conversation, thinking log and status bar.
+// "/clear session" also drops the MCP session so the next message starts fresh.
+func handleClear(p *Prompt, arg string) (*Prompt, tea.Cmd) {
+	// A reply still on its way would land in the cleared conversation
+	pending := p.awaitingReply
+	p.cancelReply()
+	p.ThinkingState = nil
+	p.ThinkingLog = nil
+	p.thinkingLogScroll = 0
+	p.workflowLog = nil
+	p.workflowLogScroll = 0
+	p.workflowProgress = nil
+	p.Conversation = Conversation{}
+	p.sessionTokens = 0
+	p.StatusBar = ""
+	if pending {
+		p.StatusBar = "Cleared; the pending reply was cancelled"
+	}
 	p.textInput.SetValue("")
+
+	if strings.TrimSpace(arg) == "session" && p.MCP != nil {
+		if pending {
+			// Cancelling already ends the session, once a send in flight lands
+			p.StatusBar = "Cleared; the pending reply was cancelled and a new session starts with your next message"
+			return p, nil
+		}
+		p.MCP.Close()
+		p.StatusBar = "Cleared; a new session starts with your next message"
+	}
 	return p, nil
 }
 
+func handleMCP(p *Prompt, arg string) (*Prompt, tea.Cmd) {
+	if p.blockedByReadOnly() {
+		p.textInput.SetValue("")
+		return p, nil
+	}
+	p.mcpCommand = commands.NewMCPCommand()
+	_, cmd := p.mcpCommand.Execute(arg)
+	p.textInput.SetValue("")
+	return p, cmd
+}
+
 func handleAuth(p *Prompt, arg string) (*Prompt, tea.Cmd) {
+	if strings.TrimSpace(arg) == "show" {
+		return handleAuthShow(p)
+	}
 	// Initialize the auth command
 	p.authCommand = commands.NewAuthCommand()
 
@@ -247,32 +440,92 @@ func handleAuth(p *Prompt, arg string) (*Prompt, tea.Cmd) {
 }
 
 func handleFeatures(p *Prompt, arg string) (*Prompt, tea.Cmd) {
-	var featuresData mcpclient.FeaturesData
-	if p.MCP != nil {
-		data, err := p.MCP.ListFeaturesViaStdio()
-		if err == nil && data != nil {
-			featuresData = *data
-		}
-	}
-	// Pick first feature as selected
-	var selected *mcpclient.Feature
-	if len(featuresData.Approved) > 0 {
-		selected = &featuresData.Approved[0]
-	} else if len(featuresData.Planned) > 0 {
-		selected = &featuresData.Planned[0]
-	} else if len(featuresData.Refinement) > 0 {
-		selected = &featuresData.Refinement[0]
-	} else if len(featuresData.Backlog) > 0 {
-		selected = &featuresData.Backlog[0]
-	}
-	p.FeaturesData = featuresData
+	// A project without its index can't list features; explain instead of showing an empty view
+	if cwd, err := os.Getwd(); err == nil {
+		if indexPath, missing := util.MissingFeaturesIndex(cwd); missing {
+			p.StatusBar = fmt.Sprintf("Found .tdd-pro but %s is missing. Run /init --repair to recreate it.", indexPath)
+			return p, nil
+		}
+		// A malformed index would otherwise show up as an empty feature list
+		if indexPath := util.FeaturesIndexPath(cwd); indexPath != "" {
+			if _, err := util.LoadFeaturesIndex(indexPath); err != nil {
+				p.StatusBar = "Invalid features index: " + err.Error()
+				return p, nil
+			}
+		}
+	}
+
+	// An unknown status still opens the view, just unfiltered
+	status, ok := parseFeatureStatus(arg)
+	note := ""
+	if !ok {
+		note = fmt.Sprintf("Unknown status %q; showing all features (use %s)", strings.TrimSpace(arg), strings.Join(util.FeatureStatuses, ", "))
+	}
+	p.featureStatusFilter = status
+	p.FeaturesData = mcpclient.FeaturesData{}
+	p.featuresErr = nil
 	p.FeaturesViewActive = true
 	p.FeaturesTab = 0
-	p.SelectedFeature = selected
-	return p, nil
+	p.SelectedFeature = nil
+	p.featureDetails.invalidate("")
+	p.undo = nil // entries refer to the data being replaced
+	if p.MCP == nil {
+		p.StatusBar = note
+		return p, nil
+	}
+	// The listing starts the MCP server, so it runs off the event loop
+	p.featuresLoading = true
+	p.StatusBar = "Loading features..."
+	client := p.MCP
+	return p, func() tea.Msg {
+		data, details, err := listFeatures(client)
+		return featuresListedMsg{data: data, details: details, err: err, note: note}
+	}
+}
+
+// featuresListedMsg carries the listing /features fetched in the background
+type featuresListedMsg struct {
+	data    *mcpclient.FeaturesData
+	details map[string]*mcpclient.FeatureDetail
+	err     error
+	note    string // status to show once listed, e.g. an unknown status filter
+}
+
+// updateFeaturesListed fills the features view opened by /features,
+// selecting the first visible feature
+func (p *Prompt) updateFeaturesListed(msg tea.Msg) (tea.Cmd, bool) {
+	listed, ok := msg.(featuresListedMsg)
+	if !ok {
+		return nil, false
+	}
+	p.featuresLoading = false
+	if listed.err != nil {
+		p.featuresErr = listed.err
+		p.StatusBar = "Error listing features: " + errorText(listed.err)
+		return nil, true
+	}
+	if listed.data != nil {
+		p.FeaturesData = *listed.data
+	}
+	for id, detail := range listed.details {
+		detail.ID = id
+		p.featureDetails.put(detail)
+	}
+	if visible := p.visibleFeatures(); len(visible) > 0 {
+		p.SelectedFeature = &visible[0]
+	}
+	p.StatusBar = listed.note
+	if note := p.untimedSortNote(); note != "" && listed.note == "" && p.SelectedFeature != nil {
+		p.StatusBar = note
+	}
+	return nil, true
 }
 
 func handleInit(p *Prompt, arg string) (*Prompt, tea.Cmd) {
+	if p.blockedByReadOnly() {
+		p.textInput.SetValue("")
+		return p, nil
+	}
 	// Initialize the init command
 	p.initCommand = commands.NewInitCommand()
 
@@ -284,16 +537,22 @@ func handleInit(p *Prompt, arg string) (*Prompt, tea.Cmd) {
 }
 
 func handleDestroy(p *Prompt, arg string) (*Prompt, tea.Cmd) {
-	// Get current working directory or use provided argument
-	cwd := arg
-	if cwd == "" {
-		var err error
-		cwd, err = os.Getwd()
-		if err != nil {
-			p.StatusBar = "Error getting current directory: " + err.Error()
-			p.textInput.SetValue("")
-			return p, nil
-		}
+	// --dry-run, first or last, only reports what would be removed; the rest
+	// of the argument is the directory, spaces and all
+	cwd := strings.TrimSpace(arg)
+	dryRun := false
+	if cwd == "--dry-run" {
+		cwd, dryRun = "", true
+	} else if rest, ok := strings.CutPrefix(cwd, "--dry-run "); ok {
+		cwd, dryRun = strings.TrimSpace(rest), true
+	} else if rest, ok := strings.CutSuffix(cwd, " --dry-run"); ok {
+		cwd, dryRun = strings.TrimSpace(rest), true
+	}
+	cwd, err := commandDir(cwd)
+	if err != nil {
+		p.StatusBar = "Error: " + err.Error()
+		p.textInput.SetValue("")
+		return p, nil
 	}
 
 	// Find the .tdd-pro directory (check current and parent directories)
@@ -304,22 +563,119 @@ func handleDestroy(p *Prompt, arg string) (*Prompt, tea.Cmd) {
 		return p, nil
 	}
 
+	files, size, err := util.DirUsage(tddProDir)
+	if err != nil {
+		p.StatusBar = "Error reading " + tddProDir + ": " + err.Error()
+		p.textInput.SetValue("")
+		return p, nil
+	}
+	p.textInput.SetValue("")
+	if dryRun {
+		p.StatusBar = fmt.Sprintf("Dry run: /destroy would remove %s (%d files, %s)", tddProDir, files, util.FormatBytes(size))
+		return p, nil
+	}
+	if p.blockedByReadOnly() {
+		return p, nil
+	}
+
 	// Show confirmation dialog
 	p.destroyConfirmActive = true
 	p.destroyTargetDir = tddProDir
+	p.destroyFiles = files
+	p.destroySize = size
 	p.StatusBar = ""
-	p.textInput.SetValue("")
 	return p, nil
 }
 
+// commandDir resolves a command's directory argument, expanding ~ and
+// relative paths, and rejects one that doesn't exist. Empty means the
+// working directory.
+func commandDir(arg string) (string, error) {
+	if arg == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("getting current directory: %w", err)
+		}
+		return cwd, nil
+	}
+	return util.ExistingDir(arg)
+}
+
 func handleQuit(p *Prompt, arg string) (*Prompt, tea.Cmd) {
-	return p, tea.Quit
+	return p, p.quit()
 }
 
+// Update handles a message, then starts fetching any tasks or PRD the next
+// frame shows that haven't been loaded, so rendering never waits on the MCP
+// server
 func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
+	p, cmd := p.update(msg)
+	return p, tea.Batch(cmd, p.loadVisibleTasks(), p.loadVisiblePRD())
+}
+
+func (p *Prompt) update(msg tea.Msg) (*Prompt, tea.Cmd) {
 	if m, ok := msg.(tea.WindowSizeMsg); ok {
 		p.WindowHeight = m.Height
 		p.WindowWidth = m.Width
+		p.resizePRDEditor()
+	}
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && p.help.Active {
+		return p, p.help.Update(keyMsg)
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && p.palette.Active {
+		return p.updatePalette(keyMsg)
+	}
+	if mouseMsg, ok := msg.(tea.MouseMsg); ok && p.palette.Active {
+		return p.updatePaletteMouse(mouseMsg)
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && p.thinkingLogOpen {
+		return p.updateThinkingLog(keyMsg)
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && p.workflowLogOpen {
+		return p.updateWorkflowLog(keyMsg)
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && p.workflowPanel != nil {
+		return p.updateWorkflowPanel(keyMsg)
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && p.keyReveal != nil {
+		if cmd, handled := p.updateKeyReveal(keyMsg); handled {
+			return p, cmd
+		}
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && p.quitConfirmActive {
+		return p, p.updateQuitConfirm(keyMsg)
+	}
+	// ctrl+c quits from the editors too, asking first if anything is unsaved
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "ctrl+c" &&
+		(p.editingPRD || p.editingTask && p.taskEditForm != nil && p.taskEditForm.IsVisible()) {
+		return p, p.quit()
+	}
+
+	if cmd, ok := p.updateFeaturesRefresh(msg); ok {
+		return p, cmd
+	}
+	if cmd, ok := p.updateFeaturesListed(msg); ok {
+		return p, cmd
+	}
+	if cmd, ok := p.updateFeatureDetailsFetched(msg); ok {
+		return p, cmd
+	}
+	if cmd, ok := p.updateWorkflowRun(msg); ok {
+		return p, cmd
+	}
+	if p.updateTasksLoaded(msg) {
+		return p, nil
+	}
+	if cmd, ok := p.updatePRDLoaded(msg); ok {
+		return p, cmd
+	}
+	if cmd, ok := p.updateReplySpinner(msg); ok {
+		return p, cmd
+	}
+	if _, ok := msg.(idleCheckMsg); ok {
+		p.checkIdle()
+		return p, p.scheduleIdleCheck()
 	}
 
 	// Handle command result messages
@@ -327,6 +683,72 @@ func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
 		p.StatusBar = cmdMsg.Message
 		p.initCommand = nil // Clear the init command
 		p.authCommand = nil // Clear the auth command
+		p.mcpCommand = nil
+		return p, nil
+	}
+	if cmd, ok := p.updateAuthResult(msg); ok {
+		return p, cmd
+	}
+
+	// Handle the phases of sending a message to the agent. Sending and
+	// waiting both run off the event loop so the UI keeps rendering and esc
+	// can cancel; results for a cancelled message are dropped.
+	if sendMsg, ok := msg.(messageSendMsg); ok {
+		if !p.isPendingReply(sendMsg.seq) {
+			return p, nil
+		}
+		// The MCP session can't take two sends at once, so a message sent
+		// after cancelling one mid-send waits for that send to land
+		if p.sendingSeq != 0 {
+			p.queuedSend = &sendMsg
+			return p, nil
+		}
+		p.sendingSeq = sendMsg.seq
+		return p, p.sendToBackend(sendMsg)
+	}
+	if sentMsg, ok := msg.(messageSentMsg); ok {
+		if sentMsg.seq == p.sendingSeq {
+			p.sendingSeq = 0
+		}
+		if !p.isPendingReply(sentMsg.seq) {
+			// The reply to a message sent after cancelling must not be read as the next one's
+			if sentMsg.err == nil && p.MCP != nil {
+				p.MCP.CancelReply()
+			}
+			return p, p.sendQueued()
+		}
+		if sentMsg.err != nil {
+			p.awaitingReply = false
+			p.Conversation.SetState(sentMsg.index, MessageFailed)
+			p.StatusBar = "Error: " + sentMsg.err.Error()
+			return p, nil
+		}
+		p.Conversation.SetState(sentMsg.index, MessageSent)
+		p.recordTranscript(RoleUser, sentMsg.text)
+		outTokens := util.EstimateTokens(sentMsg.text)
+		p.sessionTokens += outTokens
+		p.StatusBar = fmt.Sprintf("Waiting for reply... (~%d tokens sent, esc cancels)", outTokens)
+		return p, p.awaitReply(replyWaitMsg{index: sentMsg.index, seq: sentMsg.seq, outTokens: outTokens})
+	}
+	if replyMsg, ok := msg.(replyReceivedMsg); ok {
+		if !p.isPendingReply(replyMsg.seq) {
+			return p, nil
+		}
+		p.awaitingReply = false
+		p.reconnecting = false
+		p.replyCancel = nil
+		p.lastActivity = time.Now()
+		if replyMsg.err != nil {
+			p.StatusBar = "Error: " + replyMsg.err.Error()
+			return p, nil
+		}
+		p.Conversation.Append(RoleAgent, replyMsg.reply, MessageSent)
+		p.recordTranscript(RoleAgent, replyMsg.reply)
+		p.StatusBar = "Reply received! " + p.tokenSummary(replyMsg.outTokens, replyMsg.reply)
+		return p, nil
+	}
+	if _, ok := msg.(ConnectionStateMsg); ok {
+		p.updateConnectionStatus()
 		return p, nil
 	}
 
@@ -342,6 +764,17 @@ func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
 		return p, cmd
 	}
 
+	// Handle mcp command updates
+	if p.mcpCommand != nil && p.mcpCommand.IsActive() {
+		_, cmd := p.mcpCommand.Update(msg)
+		return p, cmd
+	}
+
+	// Handle new feature form updates
+	if p.featureForm != nil {
+		return p, p.updateFeatureForm(msg)
+	}
+
 	// Handle task edit form updates
 	if p.editingTask && p.taskEditForm != nil && p.taskEditForm.IsVisible() {
 		_, cmd := p.taskEditForm.Update(msg)
@@ -352,6 +785,10 @@ func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
 	if p.editingPRD {
 		switch keyMsg := msg.(type) {
 		case tea.KeyMsg:
+			if keyMsg.Paste {
+				p.pastePRD(keyMsg.Runes)
+				return p, nil
+			}
 			switch keyMsg.String() {
 			case "esc":
 				// Exit PRD editing mode
@@ -361,18 +798,9 @@ func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
 			case "ctrl+s", "cmd+s":
 				// Save PRD changes
 				newContent := p.prdEditTextarea.Value()
-				if p.SelectedFeature != nil && p.MCP != nil {
-					go func() {
-						err := p.MCP.UpdateFeatureDocumentViaStdio(p.SelectedFeature.ID, newContent)
-						if err != nil {
-							p.StatusBar = fmt.Sprintf("Error saving PRD: %v", err)
-						} else {
-							p.StatusBar = "PRD saved successfully"
-						}
-					}()
-				}
 				p.editingPRD = false
-				return p, nil
+				p.StatusBar = ""
+				return p, p.savePRD(newContent)
 			default:
 				// Handle text input
 				var cmd tea.Cmd
@@ -384,51 +812,142 @@ func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
 	}
 
 	// Handle task edit completion/cancellation
-	if editCompleteMsg, ok := msg.(TaskEditCompleteMsg); ok {
+	if editCompleteMsg, ok := msg.(TaskEditCompleteMsg); ok && editCompleteMsg.Creating {
 		p.editingTask = false
 		p.taskEditForm = nil
+		if p.SelectedFeature == nil || p.MCP == nil {
+			p.StatusBar = "Cannot create task: no feature selected or MCP unavailable"
+			return p, nil
+		}
+		if strings.TrimSpace(editCompleteMsg.Title) == "" {
+			p.StatusBar = "Task not created: title is required"
+			return p, nil
+		}
+		featureID := p.SelectedFeature.ID
+		task := mcpclient.Task{
+			Title:              editCompleteMsg.Title,
+			Description:        editCompleteMsg.Description,
+			EvaluationCriteria: editCompleteMsg.Criteria,
+			Estimate:           editCompleteMsg.Estimate,
+		}
+		p.StatusBar = "Creating task: " + task.Title
+		return p, func() tea.Msg {
+			created, err := p.MCP.CreateTaskViaStdio(featureID, task)
+			return taskCreatedMsg{task: created, err: err}
+		}
+	}
 
-		// Save the task changes via MCP
+	if created, ok := msg.(taskCreatedMsg); ok {
+		p.StatusBar = ""
+		if created.err != nil {
+			return p, p.toastResult(created.err, "Error creating task", "")
+		}
+		// Select the new task, which the server appends to the end of the list
 		if p.SelectedFeature != nil && p.MCP != nil {
-			go func() {
-				// Get the current task being edited
-				if featureDetail, err := p.MCP.GetFeatureViaStdio(p.SelectedFeature.ID); err == nil && p.selectedTaskIndex < len(featureDetail.Tasks) {
-					task := featureDetail.Tasks[p.selectedTaskIndex]
-
-					// Create updates map with the edited values
-					updates := map[string]interface{}{
-						"name":                editCompleteMsg.Title,
-						"description":         editCompleteMsg.Description,
-						"acceptance_criteria": editCompleteMsg.Criteria,
+			p.featureDetails.invalidate(p.SelectedFeature.ID)
+			if featureDetail, err := p.featureDetail(p.SelectedFeature.ID); err == nil {
+				p.selectedTaskIndex = len(featureDetail.Tasks) - 1
+				for i, task := range featureDetail.Tasks {
+					if task.ID == created.task.ID {
+						p.selectedTaskIndex = i
+						break
 					}
+				}
+				p.ensureTaskVisible()
+			}
+		}
+		return p, p.showToast(toastSuccess, "Task created: "+created.task.Title)
+	}
 
-					// Save via MCP
-					if err := p.MCP.UpdateTaskViaStdio(p.SelectedFeature.ID, task.ID, updates); err != nil {
-						// Handle error (could send error message to UI)
-						return
-					}
+	if editCompleteMsg, ok := msg.(TaskEditCompleteMsg); ok {
+		p.editingTask = false
+		p.taskEditForm = nil
 
-					// Refresh the feature data to show updated task
-					if updatedDetail, err := p.MCP.GetFeatureViaStdio(p.SelectedFeature.ID); err == nil {
-						// Update the tasks in memory
-						for i, feature := range p.FeaturesData.Approved {
-							if feature.ID == p.SelectedFeature.ID {
-								p.FeaturesData.Approved[i] = feature
-								break
-							}
-						}
-						// Store updated tasks for display
-						// Note: This is a simplified update - in practice you might want to handle this via a message
-						_ = updatedDetail
-					}
+		// Save the task changes via MCP, remembering the old values for /undo
+		if p.SelectedFeature != nil && p.MCP != nil {
+			featureID := p.SelectedFeature.ID
+			index := p.selectedTaskIndex
+			p.StatusBar = "Saving task: " + editCompleteMsg.Title
+			return p, func() tea.Msg {
+				featureDetail, err := p.featureDetail(featureID)
+				if err != nil {
+					return taskUpdatedMsg{err: err}
+				}
+				if index >= len(featureDetail.Tasks) {
+					return taskUpdatedMsg{err: fmt.Errorf("task %d no longer exists", index+1)}
+				}
+				task := featureDetail.Tasks[index]
+				updates := map[string]interface{}{
+					"name":                editCompleteMsg.Title,
+					"description":         editCompleteMsg.Description,
+					"acceptance_criteria": editCompleteMsg.Criteria,
+					"estimate":            editCompleteMsg.Estimate,
+				}
+				err = p.MCP.UpdateTaskViaStdio(featureID, task.ID, updates)
+				if mcpclient.IsTransient(err) {
+					// The server failed to start or timed out; one retry usually gets through
+					slog.Warn("retrying task save", "feature", featureID, "task", task.ID, "err", err)
+					time.Sleep(taskSaveRetryDelay)
+					err = p.MCP.UpdateTaskViaStdio(featureID, task.ID, updates)
 				}
-			}()
+				if err != nil {
+					edit := editCompleteMsg
+					return taskUpdatedMsg{featureID: featureID, index: index, edit: &edit, err: err}
+				}
+				return taskUpdatedMsg{featureID: featureID, previous: task, title: editCompleteMsg.Title}
+			}
 		}
 
 		p.StatusBar = "Task edited: " + editCompleteMsg.Title
 		return p, nil
 	}
 
+	if cmd, ok := p.updateUndo(msg); ok {
+		return p, cmd
+	}
+	if cmd, ok := p.updateToast(msg); ok {
+		return p, cmd
+	}
+	if cmd, ok := p.updateKeyRevealEnded(msg); ok {
+		return p, cmd
+	}
+	if cmd, ok := p.updateFeatureStatus(msg); ok {
+		return p, cmd
+	}
+	if cmd, ok := p.updateFeatureDependencies(msg); ok {
+		return p, cmd
+	}
+	if cmd, ok := p.updateVersionCheck(msg); ok {
+		return p, cmd
+	}
+	if cmd, ok := p.updateDoctorResult(msg); ok {
+		return p, cmd
+	}
+	if cmd, ok := p.updateToolResult(msg); ok {
+		return p, cmd
+	}
+	if cmd, ok := p.updateWorkflowResult(msg); ok {
+		return p, cmd
+	}
+	if cmd, ok := p.updateFeatureExport(msg); ok {
+		return p, cmd
+	}
+	if cmd, ok := p.updateTranscriptSaved(msg); ok {
+		return p, cmd
+	}
+	if cmd, ok := p.updateFeatureImport(msg); ok {
+		return p, cmd
+	}
+	if cmd, ok := p.updateBatchStatus(msg); ok {
+		return p, cmd
+	}
+	if cmd, ok := p.updateTestResults(msg); ok {
+		return p, cmd
+	}
+	if cmd, ok := p.updateFeatureCreated(msg); ok {
+		return p, cmd
+	}
+
 	if _, ok := msg.(TaskEditCancelMsg); ok {
 		p.editingTask = false
 		p.taskEditForm = nil
@@ -438,35 +957,50 @@ func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
 
 	// Handle external PRD edit completion
 	if prdResult, ok := msg.(PRDEditResultMsg); ok {
+		// The terminal may have been resized while the editor owned it, so
+		// reflow from the current size and ask for a fresh one
+		p.resizePRDEditor()
+		if prdResult.Unchanged {
+			if prdResult.Error != "" {
+				p.StatusBar = fmt.Sprintf("PRD not saved: %s", prdResult.Error)
+			} else {
+				p.StatusBar = "PRD unchanged"
+			}
+			return p, tea.WindowSize()
+		}
 		if prdResult.Success {
 			// Save the edited content via MCP
-			if p.SelectedFeature != nil && p.MCP != nil {
-				go func() {
-					if err := p.MCP.UpdateFeatureDocumentViaStdio(p.SelectedFeature.ID, prdResult.Content); err != nil {
-						p.StatusBar = fmt.Sprintf("Error saving PRD: %v", err)
-					} else {
-						p.StatusBar = "PRD document updated successfully"
-					}
-				}()
-			}
-		} else {
-			p.StatusBar = fmt.Sprintf("PRD edit failed: %s", prdResult.Error)
+			return p, tea.Batch(p.savePRD(prdResult.Content), tea.WindowSize())
 		}
-		return p, nil
+		return p, tea.Batch(p.showToast(toastError, "PRD edit failed: "+prdResult.Error), tea.WindowSize())
+	}
+
+	if pagerResult, ok := msg.(prdPagerClosedMsg); ok {
+		if pagerResult.err != nil {
+			p.StatusBar = fmt.Sprintf("Pager error: %v", pagerResult.err)
+		}
+		// As with the external editor, the terminal may have been resized meanwhile
+		return p, tea.WindowSize()
 	}
 
 	// Handle completion selection
 	if msg, ok := msg.(CompletionSelectedMsg); ok {
-		if msg.Item.IsCommand {
+		if msg.Item.IsCommand && msg.Execute {
 			// Execute command directly
 			cmd, arg := parseCommand(msg.Item.Value)
 			if handler, ok := commandHandlers[cmd]; ok {
+				p.recordCommand(cmd)
 				p.textInput.SetValue("")
 				return handler(p, arg)
 			}
 		} else {
 			// Insert the completion value
 			p.textInput.SetValue(msg.Item.Value)
+			p.textInput.CursorEnd()
+			// A completed directory goes straight on to its subdirectories
+			if isDirArgument(msg.Item.Value) {
+				p.updateCompletions()
+			}
 		}
 		return p, nil
 	}
@@ -496,11 +1030,28 @@ func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
 		return p, nil
 	}
 
+	// Handle import confirmation dialog
+	if p.pendingImport != nil {
+		if m, ok := msg.(tea.KeyMsg); ok {
+			return p, p.handleImportConfirmKey(m)
+		}
+		return p, nil
+	}
+
 	if p.FeaturesViewActive {
 		switch m := msg.(type) {
 		case tea.KeyMsg:
 			// Handle feature metadata editing when in feature data view
 			if p.focusState == 1 && p.SelectedFeature != nil && !p.editingPRD {
+				// The dependency list takes the keyboard while it's being edited
+				if p.deps != nil {
+					return p, p.handleDependencyKey(m.String())
+				}
+				if m.String() == "ctrl+k" {
+					p.openDependencyEditor()
+					return p, nil
+				}
+
 				// Handle Enter key to save feature changes
 				if m.String() == "enter" {
 					return p.saveFeatureChanges()
@@ -508,10 +1059,17 @@ func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
 
 				// Allow text input for feature name and description (but not for navigation keys)
 				switch m.String() {
-				case "esc", "left", "right", "up", "down", "e", "t", "d", "tab":
+				case "esc", "left", "right", "up", "down", "e", "t", "d", "tab", "ctrl+p", "ctrl+r", "ctrl+t":
 					// These keys should be handled by the main switch statement
 				default:
+					// Name and description are shown but can't be typed into
+					if p.readOnly {
+						return p, nil
+					}
 					// Handle text input for feature fields
+					if p.SelectedFeature != nil {
+						p.fieldsEditID = p.SelectedFeature.ID
+					}
 					var cmd tea.Cmd
 					p.featureNameEdit, cmd = p.featureNameEdit.Update(msg)
 					if cmd != nil {
@@ -525,11 +1083,88 @@ func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
 				}
 			}
 
+			if p.focusState == 2 && (p.taskFilter.searching || p.pendingTaskNumber == "") {
+				if cmd, ok := p.handleTaskFilterKey(m); ok {
+					return p, cmd
+				}
+			}
+			if p.focusState == 2 && p.handleTaskNumberKey(m.String()) {
+				return p, nil
+			}
+			// In the feature data view letters edit the name and description
+			if p.focusState != 1 {
+				if cmd, ok := p.handleCopyKey(m.String()); ok {
+					return p, cmd
+				}
+			}
+
 			switch m.String() {
+			case "ctrl+r":
+				return p, p.refreshFeatureDetails()
+			case "u":
+				if p.focusState != 1 {
+					return handleUndo(p, "")
+				}
+				return p, nil
 			case "esc":
+				// esc backs out one level: the Tasks and Feature Data panels
+				// return to the features list, a batch selection is cleared,
+				// and only then does the view close
+				if p.focusState != 0 {
+					p.focusState = 0
+					return p, nil
+				}
+				if p.clearFeatureMarks() {
+					return p, nil
+				}
 				p.FeaturesViewActive = false
 				p.focusState = 0 // Reset focus
 				return p, nil
+			case "z":
+				// Tasks panel: switch between full task boxes and one line per task
+				if p.focusState == 2 {
+					p.toggleCompactTasks()
+				}
+				return p, nil
+			case "r":
+				// Tasks panel: refresh the acceptance criteria test results
+				if p.focusState == 2 {
+					return p, p.refreshTestResults()
+				}
+				return p, nil
+			case "c":
+				// Workflow panel: collapse or expand the selected feature's group
+				if p.focusState == 0 {
+					p.toggleSelectedGroupCollapse()
+				}
+				return p, nil
+			case "s":
+				// Workflow panel: sort each group by name, update or creation
+				if p.focusState == 0 {
+					p.cycleFeatureSort()
+				}
+				return p, nil
+			case "1", "2", "3", "4":
+				// Workflow panel: collapse or expand approved, planned, refinement or backlog
+				if p.focusState == 0 {
+					p.toggleGroupCollapse(util.FeatureStatuses[m.String()[0]-'1'])
+				}
+				return p, nil
+			case "v":
+				// Workflow panel: mark the feature for a batch status change
+				if p.focusState == 0 {
+					p.toggleFeatureMark()
+				}
+				return p, nil
+			case "shift+right", "shift+left":
+				if p.focusState == 0 {
+					delta := -1 // forward, towards approved
+					if m.String() == "shift+left" {
+						delta = 1
+					}
+					return p, p.moveMarkedFeatures(delta)
+				}
+				return p, nil
 			case "left":
 				// Move focus left
 				if p.focusState > 0 {
@@ -549,6 +1184,7 @@ func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
 						// Moving to tasks tab, sync the tab
 						p.FeaturesTab = 1
 						p.mainPanelScroll = 0
+						return p, p.loadTasks()
 					} else if p.focusState == 1 {
 						// Moving to data tab, sync the tab
 						p.FeaturesTab = 0
@@ -587,6 +1223,29 @@ func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
 					}
 				}
 				return p, nil
+			case "[", "pgup":
+				// Workflow panel: jump to the previous status group
+				if p.focusState == 0 {
+					p.jumpFeatureGroup(-1)
+				}
+				return p, nil
+			case "]", "pgdown":
+				// Workflow panel: jump to the next status group
+				if p.focusState == 0 {
+					p.jumpFeatureGroup(1)
+				}
+				return p, nil
+			case "ctrl+p":
+				// The feature data keeps ctrl+p for the PRD pager
+				if p.focusState == 1 {
+					return p.openPRDPager()
+				}
+				return p, p.openPalette()
+			case "ctrl+t":
+				if p.focusState == 1 {
+					p.cycleFeatureStatus()
+				}
+				return p, nil
 			case "e":
 				// Edit task when in Tasks view, or edit PRD when in Feature Data view
 				if p.focusState == 2 && p.SelectedFeature != nil {
@@ -597,7 +1256,7 @@ func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
 					}
 
 					// Get tasks to verify the selected index is valid
-					if featureDetail, err := p.MCP.GetFeatureViaStdio(p.SelectedFeature.ID); err == nil {
+					if featureDetail, err := p.featureDetail(p.SelectedFeature.ID); err == nil {
 						if p.selectedTaskIndex >= len(featureDetail.Tasks) {
 							p.StatusBar = fmt.Sprintf("Task index %d out of bounds (have %d tasks)", p.selectedTaskIndex, len(featureDetail.Tasks))
 							return p, nil
@@ -605,7 +1264,7 @@ func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
 						p.StatusBar = fmt.Sprintf("Starting edit for task %d: %s", p.selectedTaskIndex, featureDetail.Tasks[p.selectedTaskIndex].Title)
 						return p.startTaskEdit()
 					} else {
-						p.StatusBar = fmt.Sprintf("Error getting tasks: %v", err)
+						p.StatusBar = "Error getting tasks: " + errorText(err)
 						return p, nil
 					}
 				} else if p.focusState == 1 && p.SelectedFeature != nil {
@@ -628,13 +1287,40 @@ func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
 					p.StatusBar = fmt.Sprintf("Cannot edit: %s", strings.Join(reasons, ", "))
 				}
 				return p, nil
+			case "a", "n":
+				// Add a new task when in Tasks view
+				if p.focusState == 2 && p.SelectedFeature != nil {
+					return p.startTaskCreate()
+				}
+				// Workflow panel: add a new feature
+				if p.focusState == 0 && m.String() == "n" {
+					return p.startFeatureCreate()
+				}
+				return p, nil
+			case "p":
+				// A project without features can be planned from the empty view
+				if p.focusState == 0 && len(p.allFeatures()) == 0 && p.featuresErr == nil && !p.featuresLoading {
+					if p.blockedByReadOnly() {
+						return p, nil
+					}
+					p.FeaturesViewActive = false
+					return handlePlan(p, "")
+				}
+				return p, nil
+			case "?":
+				// Searchable shortcut help (feature data fields take typed text instead)
+				if p.focusState != 1 {
+					p.help.Open(KeyMap)
+					return p, textinput.Blink
+				}
+				return p, nil
 			case "t":
 				// Quick switch to Tasks tab
 				p.FeaturesTab = 1
 				p.focusState = 2
 				p.mainPanelScroll = 0
 				p.StatusBar = "Switched to Tasks view"
-				return p, nil
+				return p, p.loadTasks()
 			case "d":
 				// Quick switch to Data tab
 				p.FeaturesTab = 0
@@ -651,6 +1337,7 @@ func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
 				} else if p.focusState == 2 {
 					p.FeaturesTab = 1
 					p.mainPanelScroll = 0
+					return p, p.loadTasks()
 				}
 				return p, nil
 			}
@@ -680,6 +1367,39 @@ func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
 	*/
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// Conversation history navigation takes keys while it has focus
+		if p.Conversation.Focused {
+			return p.updateConversationFocus(msg)
+		}
+		if p.historyPicker != nil {
+			return p.updateHistoryPicker(msg)
+		}
+		if msg.String() == "ctrl+r" {
+			p.openHistoryPicker()
+			return p, nil
+		}
+		if msg.String() == "ctrl+l" {
+			p.toggleThinkingLog()
+			return p, nil
+		}
+		if msg.String() == "ctrl+t" {
+			p.toggleWorkflowLog()
+			return p, nil
+		}
+		if msg.String() == "ctrl+p" {
+			return p, p.openPalette()
+		}
+		// ? on an empty prompt opens the shortcuts; anywhere else it's typed
+		if msg.String() == "?" && p.textInput.Value() == "" {
+			p.help.Open(KeyMap)
+			return p, textinput.Blink
+		}
+		if msg.String() == "ctrl+o" && !p.Conversation.IsEmpty() {
+			p.Conversation.Focus()
+			p.StatusBar = "History: ↑↓ select, y copy, r re-send, esc back to input"
+			return p, nil
+		}
+
 		// Handle completion navigation keys separately
 		if p.completionDialog != nil && p.completionDialog.IsVisible() {
 			switch msg.String() {
@@ -688,6 +1408,11 @@ func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
 				_, completionCmd = p.completionDialog.Update(msg)
 				return p, completionCmd
 			case "enter", "tab":
+				// Directory completions are inserted with tab; enter runs the command as typed
+				if msg.String() == "enter" && p.completionDialog.ProviderID() == "paths" {
+					p.completionDialog.Hide()
+					break
+				}
 				// Let completion dialog handle selection
 				_, completionCmd = p.completionDialog.Update(msg)
 				return p, completionCmd
@@ -699,107 +1424,316 @@ func (p *Prompt) Update(msg tea.Msg) (*Prompt, tea.Cmd) {
 		}
 
 		switch msg.Type {
+		case tea.KeyEsc:
+			if p.awaitingReply {
+				p.cancelReply()
+				return p, nil
+			}
 		case tea.KeyCtrlC:
+			if p.awaitingReply {
+				p.cancelReply()
+				p.ctrlCPressed = false
+				return p, nil
+			}
 			if !p.isEmpty() && !p.ctrlCPressed {
 				p.textInput.SetValue("")
 				p.ctrlCPressed = true
 				p.StatusBar = ""
 				return p, nil
 			}
-			return p, tea.Quit
+			return p, p.quit()
+		case tea.KeyTab:
+			p.handleTab()
+			return p, nil
+		case tea.KeyUp:
+			if entry, ok := p.history.Previous(p.textInput.Value()); ok {
+				p.textInput.SetValue(entry)
+				p.textInput.CursorEnd()
+			}
+			return p, nil
+		case tea.KeyDown:
+			if entry, ok := p.history.Next(); ok {
+				p.textInput.SetValue(entry)
+				p.textInput.CursorEnd()
+			}
+			return p, nil
 		case tea.KeyEnter:
 			userInput := strings.TrimSpace(p.textInput.Value())
 			if userInput != "" {
+				p.history.Add(userInput)
 				if userInput[0] == '/' {
 					cmd, arg := parseCommand(userInput)
 					if handler, ok := commandHandlers[cmd]; ok {
+						p.recordCommand(cmd)
 						p.textInput.SetValue("")
 						return handler(p, arg)
 					}
 				}
-				// ... fallback to other logic (e.g., sendToBackend) ...
-				p.StatusBar = "Waiting for reply..."
-				err := p.sendToBackend(userInput, p)
-				if err != nil {
-					p.StatusBar = "Error: " + err.Error()
-					p.textInput.SetValue("")
-				} else {
-					p.StatusBar = "Reply received!"
+				if p.awaitingReply {
+					p.StatusBar = "Still waiting for the previous reply..."
+					return p, nil
 				}
-				return p, nil
+				p.textInput.SetValue("")
+				return p, p.submitMessage(userInput)
+			}
+		}
+		if msg.Type != tea.KeyCtrlC {
+			p.ctrlCPressed = false
+		}
+
+		// Let textinput handle all other keys (including typing, backspace, etc.)
+		var cmd tea.Cmd
+		p.textInput, cmd = p.textInput.Update(msg)
+
+		p.updateCompletions()
+		return p, cmd
+	}
+
+	// Return completion command if there was one
+	if completionCmd != nil {
+		return p, completionCmd
+	}
+	return p, nil
+}
+
+// updateCompletions shows the completion dialog for the current input
+func (p *Prompt) updateCompletions() {
+	currentInput := p.textInput.Value()
+	if strings.HasPrefix(currentInput, "/") && len(currentInput) > 0 {
+		// Initialize completion components if needed
+		if p.completionManager == nil || p.completionDialog == nil {
+			p.completionManager = NewCompletionManager()
+			p.completionDialog = NewCompletionDialog()
+		}
+
+		// Show completion dialog and update
+		provider := p.completionManager.GetProvider(currentInput)
+		p.completionDialog.SetProvider(provider)
+		p.completionDialog.Show()
+		p.completionDialog.UpdateQuery(currentInput)
+	} else {
+		// Hide completion dialog if not a command
+		if p.completionDialog != nil {
+			p.completionDialog.Hide()
+		}
+	}
+}
+
+// handleTab completes a slash command in the input, or inserts the configured
+// number of spaces (none by default) for anything else
+func (p *Prompt) handleTab() {
+	value := p.textInput.Value()
+	if strings.HasPrefix(value, "/") {
+		if completed := completeCommand(value, p.commandNames()); completed != value {
+			p.textInput.SetValue(completed)
+			p.textInput.CursorEnd()
+		}
+		return
+	}
+	if p.tabSpaces <= 0 {
+		return
+	}
+	runes := []rune(value)
+	pos := p.textInput.Position()
+	if pos > len(runes) {
+		pos = len(runes)
+	}
+	inserted := string(runes[:pos]) + strings.Repeat(" ", p.tabSpaces) + string(runes[pos:])
+	p.textInput.SetValue(inserted)
+	p.textInput.SetCursor(pos + p.tabSpaces)
+}
+
+// SetTabSpaces sets how many spaces Tab inserts outside of commands (0 disables)
+func (p *Prompt) SetTabSpaces(n int) {
+	p.tabSpaces = n
+}
+
+// commandNames returns the registered slash commands in sorted order,
+// leaving out debug commands unless debug tools are enabled
+func (p *Prompt) commandNames() []string {
+	names := make([]string, 0, len(commandHandlers))
+	for name := range commandHandlers {
+		if debugCommands[name] && !p.debugTools {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// recordCommand counts a command run for completion ordering. Failing to
+// persist the counts only loses the ordering, so it's logged and ignored.
+func (p *Prompt) recordCommand(cmd string) {
+	if p.completionManager == nil {
+		return
+	}
+	if err := p.completionManager.RecordCommand(cmd); err != nil {
+		slog.Debug("could not save command usage", "err", err)
+	}
+}
+
+// parseCommand splits a command and its argument at the first run of
+// whitespace, e.g. "/plan\t /foo" => ("/plan", "/foo"). The argument is trimmed.
+func parseCommand(input string) (string, string) {
+	input = strings.TrimSpace(input)
+	i := strings.IndexFunc(input, unicode.IsSpace)
+	if i < 0 {
+		return input, ""
+	}
+	return input[:i], strings.TrimSpace(input[i:])
+}
+
+// submitMessage echoes a message into the conversation before sending so the
+// user sees it was captured; the send happens when the returned command's message arrives
+func (p *Prompt) submitMessage(text string) tea.Cmd {
+	if p.awaitingReply {
+		p.StatusBar = "Still waiting for the previous reply..."
+		return nil
+	}
+	index := p.Conversation.Append(RoleUser, text, MessageSending)
+	p.awaitingReply = true
+	p.replyIndex = index
+	p.replySeq++
+	seq := p.replySeq
+	p.StatusBar = "Sending... (esc cancels)"
+	return tea.Batch(p.spinner.Tick, func() tea.Msg {
+		return messageSendMsg{index: index, seq: seq, text: text}
+	})
+}
+
+// updateConversationFocus handles keys while the conversation history has focus
+func (p *Prompt) updateConversationFocus(msg tea.KeyMsg) (*Prompt, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		p.Conversation.MoveSelection(-1)
+	case "down", "j":
+		p.Conversation.MoveSelection(1)
+	case "y", "c":
+		if selected := p.Conversation.SelectedMessage(); selected != nil {
+			if err := util.CopyToClipboard(selected.Content); err != nil {
+				p.StatusBar = "Clipboard unavailable: " + err.Error()
+			} else {
+				p.StatusBar = "Message copied to clipboard"
 			}
 		}
-		if msg.Type != tea.KeyCtrlC {
-			p.ctrlCPressed = false
+	case "r":
+		selected := p.Conversation.SelectedMessage()
+		if selected == nil || selected.Role != RoleUser {
+			p.StatusBar = "Only your own messages can be re-sent"
+			return p, nil
 		}
+		text := selected.Content
+		p.Conversation.Blur()
+		p.history.Add(text)
+		return p, p.submitMessage(text)
+	case "esc", "ctrl+o":
+		p.Conversation.Blur()
+		p.StatusBar = ""
+	case "ctrl+c":
+		return p, p.quit()
+	}
+	return p, nil
+}
 
-		// Let textinput handle all other keys (including typing, backspace, etc.)
-		var cmd tea.Cmd
-		p.textInput, cmd = p.textInput.Update(msg)
+// messageSendMsg triggers sending an already-echoed conversation message
+type messageSendMsg struct {
+	index int
+	seq   int // the message's replySeq
+	text  string
+}
 
-		// Update completions based on current input
-		currentInput := p.textInput.Value()
-		if strings.HasPrefix(currentInput, "/") && len(currentInput) > 0 {
-			// Initialize completion components if needed
-			if p.completionManager == nil || p.completionDialog == nil {
-				p.completionManager = NewCompletionManager()
-				p.completionDialog = NewCompletionDialog()
-			}
+// messageSentMsg reports that a message reached the backend, or why it didn't
+type messageSentMsg struct {
+	index int
+	seq   int
+	text  string
+	err   error
+}
 
-			// Show completion dialog and update
-			provider := p.completionManager.GetProvider(currentInput)
-			p.completionDialog.SetProvider(provider)
-			p.completionDialog.Show()
-			p.completionDialog.UpdateQuery(currentInput)
-		} else {
-			// Hide completion dialog if not a command
-			if p.completionDialog != nil {
-				p.completionDialog.Hide()
-			}
-		}
+// replyWaitMsg describes the sent message a reply is awaited for
+type replyWaitMsg struct {
+	index     int
+	seq       int
+	outTokens int // estimated tokens of the sent message
+}
 
-		return p, cmd
+// replyReceivedMsg carries the agent's reply (or the error waiting for it)
+type replyReceivedMsg struct {
+	index     int
+	seq       int
+	outTokens int
+	reply     string
+	err       error
+}
+
+// tokenSummary adds the reply's tokens to the session total and describes the exchange.
+// Reply tokens come from the backend's reported usage when available, otherwise an estimate.
+func (p *Prompt) tokenSummary(outTokens int, reply string) string {
+	inLabel := ""
+	inTokens := 0
+	if usage := p.replyUsage(); usage != nil {
+		inTokens = usage.OutputTokens
+		inLabel = fmt.Sprintf("%d", inTokens)
+	} else {
+		inTokens = util.EstimateTokens(reply)
+		inLabel = fmt.Sprintf("~%d", inTokens)
 	}
+	p.sessionTokens += inTokens
+	return fmt.Sprintf("(~%d tokens out, %s in · session ~%d tokens)", outTokens, inLabel, p.sessionTokens)
+}
 
-	// Return completion command if there was one
-	if completionCmd != nil {
-		return p, completionCmd
+// replyUsage returns the backend-reported usage of the last reply, if any
+func (p *Prompt) replyUsage() *mcpclient.Usage {
+	if p.MCP == nil {
+		return nil
 	}
-	return p, nil
+	return p.MCP.ReplyUsage()
 }
 
-// parseCommand splits a command and its argument, e.g. "/plan /foo" => ("/plan", "/foo")
-func parseCommand(input string) (string, string) {
-	if idx := len(input); idx > 0 {
-		for i := 0; i < len(input); i++ {
-			if input[i] == ' ' {
-				return input[:i], input[i+1:]
-			}
-		}
+// sendQueued starts the send held back while another was in flight, unless
+// that message has since been cancelled too
+func (p *Prompt) sendQueued() tea.Cmd {
+	queued := p.queuedSend
+	p.queuedSend = nil
+	if queued == nil || !p.isPendingReply(queued.seq) {
+		return nil
 	}
-	return input, ""
+	p.sendingSeq = queued.seq
+	return p.sendToBackend(*queued)
 }
 
-func (p *Prompt) sendToBackend(message string, self *Prompt) error {
+// sendToBackend sends a message to the agent over the MCP session in the background
+func (p *Prompt) sendToBackend(sendMsg messageSendMsg) tea.Cmd {
 	if p.APIURL == "" || p.MCP == nil {
-		return fmt.Errorf("API URL or MCP client not set")
-	}
-	if p.MCP.SessionID == "" {
-		if err := p.MCP.OpenSSE(); err != nil {
-			return fmt.Errorf("failed to open SSE: %w", err)
+		return func() tea.Msg {
+			return messageSentMsg{index: sendMsg.index, seq: sendMsg.seq, text: sendMsg.text, err: fmt.Errorf("API URL or MCP client not set")}
 		}
 	}
-	if err := p.MCP.SendMessage("tddAgent", message); err != nil {
-		return err
+	p.lastActivity = time.Now()
+	p.idleDisconnected = false
+	client := p.MCP
+	return func() tea.Msg {
+		// SendMessage opens the session on first use and reconnects if it was dropped
+		err := client.SendMessage("tddAgent", sendMsg.text)
+		return messageSentMsg{index: sendMsg.index, seq: sendMsg.seq, text: sendMsg.text, err: err}
 	}
-	// Wait for the agent's reply from SSE
-	reply, err := p.MCP.ListenForReply()
-	if err != nil {
-		self.textInput.SetValue("(No reply received)")
-		return err
+}
+
+// awaitReply waits for the agent's reply from SSE (resends once if the
+// stream drops). cancelReply aborts the wait.
+func (p *Prompt) awaitReply(waitMsg replyWaitMsg) tea.Cmd {
+	client := p.MCP
+	ctx, cancel := context.WithCancel(context.Background())
+	p.replyCancel = cancel
+	return func() tea.Msg {
+		defer cancel()
+		if client == nil {
+			return replyReceivedMsg{index: waitMsg.index, seq: waitMsg.seq, outTokens: waitMsg.outTokens, err: fmt.Errorf("MCP client not set")}
+		}
+		reply, err := client.ListenForReplyContext(ctx)
+		return replyReceivedMsg{index: waitMsg.index, seq: waitMsg.seq, outTokens: waitMsg.outTokens, reply: reply, err: err}
 	}
-	self.textInput.SetValue(reply)
-	return nil
 }
 
 func trimSpaces(s string) string {
@@ -823,7 +1757,40 @@ func (p *Prompt) isEmpty() bool {
 	return p.textInput.Value() == ""
 }
 
+// Smallest terminal the layout is designed for
+const (
+	MinWindowWidth  = 80
+	MinWindowHeight = 20
+)
+
+// TooSmall reports whether the terminal is below the minimum size. An
+// unknown size (before the first WindowSizeMsg) doesn't count as too small.
+func (p *Prompt) TooSmall() bool {
+	if p.WindowWidth <= 0 || p.WindowHeight <= 0 {
+		return false
+	}
+	return p.WindowWidth < MinWindowWidth || p.WindowHeight < MinWindowHeight
+}
+
+// tooSmallView explains the minimum size instead of rendering a clipped layout
+func (p *Prompt) tooSmallView() string {
+	msg := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Error)).Bold(true).
+		Render(fmt.Sprintf("Terminal too small (need at least %dx%d)", MinWindowWidth, MinWindowHeight))
+	size := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).
+		Render(fmt.Sprintf("current size %dx%d", p.WindowWidth, p.WindowHeight))
+	return lipgloss.Place(p.WindowWidth, p.WindowHeight, lipgloss.Center, lipgloss.Center,
+		lipgloss.JoinVertical(lipgloss.Center, msg, size))
+}
+
 func (p *Prompt) View() string {
+	return p.withToast(p.view())
+}
+
+// view renders the screen without the toast layered on top
+func (p *Prompt) view() string {
+	if p.TooSmall() {
+		return p.tooSmallView()
+	}
 	headerHeight := 2 // header + newline
 	var availHeight int
 	if p.FeaturesViewActive {
@@ -840,23 +1807,83 @@ func (p *Prompt) View() string {
 			availHeight = 8
 		}
 	}
+	// A status message wrapping onto more lines takes them from the content
+	if extra := p.statusHeight() - 1; extra > 0 {
+		availHeight -= extra
+		if availHeight < 8 {
+			availHeight = 8
+		}
+	}
 	// Header
-	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("81")).Bold(true).Padding(0, 1)
+	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Header)).Bold(true).Padding(0, 1)
 	versionText := "TDD-Pro TUI"
 	if p.version != "" {
 		versionText += " " + p.version
 	}
-	header := headerStyle.Render(versionText)
+	header := headerStyle.Render(versionText) + p.connectionIndicator()
+
+	if p.help.Active {
+		return header + "\n" + p.help.View(70, availHeight, p.theme)
+	}
+	if p.palette.Active {
+		return header + "\n" + p.palette.View(90, availHeight, p.theme)
+	}
+	if p.thinkingLogOpen {
+		width := p.WindowWidth - 4
+		if width < 40 {
+			width = 40
+		}
+		return header + "\n" + p.thinkingLogView(width)
+	}
+	if p.workflowLogOpen {
+		width := p.WindowWidth - 4
+		if width < 40 {
+			width = 40
+		}
+		return header + "\n" + p.workflowLogView(width)
+	}
+	if p.workflowPanel != nil {
+		width := p.WindowWidth - 4
+		if width < 40 {
+			width = 40
+		}
+		return header + "\n" + p.workflowPanelView(width)
+	}
+
+	// Asking before quitting with unsaved edits covers any editor
+	if p.quitConfirmActive {
+		dialog := p.quitConfirmView()
+		verticalPadding := (availHeight - strings.Count(dialog, "\n") - 1) / 2
+		if verticalPadding < 0 {
+			verticalPadding = 0
+		}
+		return header + "\n" + strings.Repeat("\n", verticalPadding) + dialog
+	}
+
+	// Show import confirmation dialog if active, over either view
+	if p.pendingImport != nil {
+		dialog := p.importConfirmView()
+		verticalPadding := (availHeight - strings.Count(dialog, "\n") - 1) / 2
+		if verticalPadding < 0 {
+			verticalPadding = 0
+		}
+		return header + "\n" + strings.Repeat("\n", verticalPadding) + dialog
+	}
+
+	// Show the new feature form over the features view
+	if p.featureForm != nil {
+		return header + "\n" + p.featureFormView()
+	}
 
 	// If PRD editing is active, show the textarea overlay
 	if p.editingPRD {
 		editHeader := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("39")).
+			Foreground(lipgloss.Color(p.theme.Focus)).
 			Bold(true).
 			Render("Editing PRD Document")
 
 		textareaView := p.prdEditTextarea.View()
-		statusBar := lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render(p.StatusBar)
+		statusBar := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render(p.StatusBar)
 
 		return lipgloss.JoinVertical(lipgloss.Left, header, "", editHeader, "", textareaView, "", statusBar)
 	}
@@ -871,7 +1898,7 @@ func (p *Prompt) View() string {
 		if p.SelectedFeature != nil {
 			featureTitle := lipgloss.NewStyle().
 				Bold(true).
-				Foreground(lipgloss.Color("255")).
+				Foreground(lipgloss.Color(p.theme.Text)).
 				Render(p.SelectedFeature.Name)
 			main += featureTitle + "\n"
 
@@ -879,6 +1906,9 @@ func (p *Prompt) View() string {
 			// Create tab-style UI using proper lipgloss pattern
 			dataTabText := "Feature Spec (d)"
 			tasksTabText := "Tasks (t)"
+			if label := p.taskFilter.label(); label != "" {
+				tasksTabText = "Tasks (t) · " + label
+			}
 
 			// Define borders following lipgloss example
 			activeTabBorder := lipgloss.Border{
@@ -905,11 +1935,11 @@ func (p *Prompt) View() string {
 
 			tab := lipgloss.NewStyle().
 				Border(tabBorder, true).
-				BorderForeground(lipgloss.Color("240")).
+				BorderForeground(lipgloss.Color(p.theme.Border)).
 				Padding(0, 1)
 
 			activeTab := tab.Border(activeTabBorder, true).
-				BorderForeground(lipgloss.Color("240"))
+				BorderForeground(lipgloss.Color(p.theme.Border))
 
 			tabGap := tab.
 				BorderTop(false).
@@ -960,6 +1990,8 @@ func (p *Prompt) View() string {
 				// Show tasks for the selected feature
 				main += p.renderTasksForFeature(p.SelectedFeature)
 			}
+		} else {
+			main += p.emptyFeaturesView()
 		}
 
 		// Calculate responsive widths based on terminal size
@@ -990,13 +2022,13 @@ func (p *Prompt) View() string {
 		scrollableMain := renderScrollableContent(main, mainContentHeight, p.mainPanelScroll)
 
 		// Determine border colors based on focus state
-		sidebarBorderColor := "240" // Default border color
-		mainBorderColor := "240"
+		sidebarBorderColor := p.theme.Border // Default border color
+		mainBorderColor := p.theme.Border
 
 		if p.focusState == 0 {
-			sidebarBorderColor = "39" // Blue for focused workflow panel
+			sidebarBorderColor = p.theme.Focus // Highlight focused workflow panel
 		} else if p.focusState == 1 || p.focusState == 2 {
-			mainBorderColor = "39" // Blue for focused feature panel
+			mainBorderColor = p.theme.Focus // Highlight focused feature panel
 		}
 
 		// Use custom border title function for Bagels-style panels with focus colors
@@ -1008,35 +2040,40 @@ func (p *Prompt) View() string {
 
 		// Bagels-style bottom status bar with shortcuts (responsive width)
 		statusBarStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("245")).
-			Background(lipgloss.Color("236")).
+			Foreground(lipgloss.Color(p.theme.Muted)).
+			Background(lipgloss.Color(p.theme.StatusBg)).
 			Padding(0, 1).
 			Width(terminalWidth)
 
 		// Context-aware help text based on focus state
 		var shortcuts string
 		if p.focusState == 0 {
-			shortcuts = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render("esc") + " Back  " +
-				lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render("↑↓") + " Select Feature  " +
-				lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render("→") + " Enter Feature  " +
-				lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render("tab") + " Focus"
+			shortcuts = lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("esc") + " Back  " +
+				lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("↑↓") + " Select Feature  " +
+				lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("→") + " Enter Feature  " +
+				lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("tab") + " Focus  " +
+				lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("ctrl+p") + " Palette  " +
+				lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("?") + " Help"
 		} else if p.focusState == 2 {
-			shortcuts = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render("esc") + " Back  " +
-				lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render("↑↓") + " Select Task  " +
-				lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render("e") + " Edit Task  " +
-				lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render("←→") + " Switch Panel"
+			shortcuts = lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("esc") + " Back  " +
+				lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("↑↓") + " Select Task  " +
+				lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("e") + " Edit Task  " +
+				lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("a") + " New Task  " +
+				lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("←→") + " Switch Panel  " +
+				lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("?") + " Help"
 		} else {
-			shortcuts = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render("esc") + " Back  " +
-				lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render("e") + " Edit PRD  " +
-				lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render("↑↓") + " Scroll  " +
-				lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render("←→") + " Switch Panel  " +
-				lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render("tab") + " Focus"
+			shortcuts = lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("esc") + " Back  " +
+				lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("e") + " Edit PRD  " +
+				lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("↑↓") + " Scroll  " +
+				lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("ctrl+p") + " Pager  " +
+				lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("←→") + " Switch Panel  " +
+				lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("tab") + " Focus"
 		}
 
 		// Status/thinking area - simple messages without heavy styling
 		statusArea := ""
 		if p.StatusBar != "" {
-			statusArea = p.StatusBar
+			statusArea = strings.Join(p.statusLines(p.StatusBar, p.statusWidth()-2), "\n")
 		} else if len(p.ThinkingState) > 0 {
 			statusArea = p.ThinkingState[len(p.ThinkingState)-1] // Show latest thinking message
 		} else {
@@ -1047,13 +2084,15 @@ func (p *Prompt) View() string {
 		return header + "\n" + row + "\n" + statusArea + "\n" + statusView
 	}
 	statusBarStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("245")).
-		Background(lipgloss.Color("236")).
+		Foreground(lipgloss.Color(p.theme.Muted)).
+		Background(lipgloss.Color(p.theme.StatusBg)).
 		Padding(0, 1).
-		Width(60)
+		Width(p.statusWidth())
 
 	completionView := ""
-	if p.completionDialog != nil && p.completionDialog.IsVisible() {
+	if p.historyPicker != nil {
+		completionView = p.historyPickerView()
+	} else if p.completionDialog != nil && p.completionDialog.IsVisible() {
 		completionView = p.completionDialog.View() + "\n"
 	}
 
@@ -1063,25 +2102,29 @@ func (p *Prompt) View() string {
 			thinkingView += "[thinking] " + msg + "\n"
 		}
 	}
+	if bar := p.workflowProgressView(60); bar != "" {
+		thinkingView += bar + "\n"
+	}
 
 	// Show destroy confirmation dialog if active
 	if p.destroyConfirmActive {
 		dialogStyle := lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("196")). // Red border for warning
+			BorderForeground(lipgloss.Color(p.theme.Error)). // Red border for warning
 			Padding(1, 2).
 			Width(60).
 			Align(lipgloss.Center)
 
 		dialogContent := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("255")).
+			Foreground(lipgloss.Color(p.theme.Text)).
 			Bold(true).
 			Render("⚠️  DESTROY TDD-PRO PROJECT") + "\n\n" +
-			lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render("This will permanently delete:") + "\n" +
-			lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render(p.destroyTargetDir) + "\n\n" +
-			lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render("Are you sure? ") +
-			lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Bold(true).Render("[Y]es") + " / " +
-			lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true).Render("[N]o")
+			lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render("This will permanently delete:") + "\n" +
+			lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render(p.destroyTargetDir) + "\n" +
+			lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render(fmt.Sprintf("%d files, %s", p.destroyFiles, util.FormatBytes(p.destroySize))) + "\n\n" +
+			lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render("Are you sure? ") +
+			lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Success)).Bold(true).Render("[Y]es") + " / " +
+			lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Error)).Bold(true).Render("[N]o")
 
 		dialog := dialogStyle.Render(dialogContent)
 
@@ -1105,38 +2148,33 @@ func (p *Prompt) View() string {
 		return header + "\n" + p.authCommand.View()
 	}
 
+	// Show mcp command dialog if active
+	if p.mcpCommand != nil && p.mcpCommand.IsActive() {
+		return header + "\n" + p.mcpCommand.View()
+	}
+
 	// Don't show task edit form as overlay - it will be rendered inline in the task list
 
 	// Style the textinput with Bagels theme - no background for clean look
 	styledInput := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("255")).
+		Foreground(lipgloss.Color(p.theme.Text)).
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		BorderForeground(lipgloss.Color(p.theme.Border)).
 		Padding(0, 1).
 		Width(60).
 		Render("> " + p.textInput.View())
 
-	return header + "\n" + completionView + thinkingView + styledInput + "\n" + statusBarStyle.Render(p.StatusBar)
-}
-
-func gray(s string) string {
-	return lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render(s)
-}
-
-func init() {
-	if len(os.Getenv("DEBUG")) > 0 {
-		f, err := tea.LogToFile("debug.log", "debug")
-		if err != nil {
-			fmt.Println("fatal:", err)
-			os.Exit(1)
-		}
-		defer f.Close()
+	conversationView := ""
+	if !p.Conversation.IsEmpty() {
+		conversationView = p.Conversation.View(60, availHeight, p.theme) + "\n"
 	}
+
+	return header + "\n" + conversationView + completionView + thinkingView + styledInput + "\n" + statusBarStyle.Render(strings.Join(p.statusLines(p.replyStatus(p.StatusBar), p.statusWidth()-2), "\n"))
 }
 
 // renderPanelWithTitle creates a bordered panel with title embedded in the top border
-func renderPanelWithTitle(content string, title string, width int, padding int) string {
-	return renderPanelWithTitleAndColor(content, title, width, padding, "240")
+func renderPanelWithTitle(content string, title string, width int, padding int, theme Theme) string {
+	return renderPanelWithTitleAndColor(content, title, width, padding, theme.Border)
 }
 
 // renderPanelWithTitleAndColor creates a bordered panel with title and custom border color
@@ -1243,7 +2281,7 @@ func renderPanelWithTitleColorAndHeight(content string, title string, width int,
 
 func (p *Prompt) moveFeatureSelection(delta int) {
 	// Flatten all features into a list for navigation
-	all := append(append(append(p.FeaturesData.Approved, p.FeaturesData.Planned...), p.FeaturesData.Refinement...), p.FeaturesData.Backlog...)
+	all := p.visibleFeatures()
 	if len(all) == 0 || p.SelectedFeature == nil {
 		return
 	}
@@ -1256,6 +2294,11 @@ func (p *Prompt) moveFeatureSelection(delta int) {
 	}
 	idx = (idx + delta + len(all)) % len(all)
 	p.SelectedFeature = &all[idx]
+	p.ensureFeatureVisible()
+	// The Tasks tab may be showing while the sidebar is focused
+	if p.FeaturesTab == 1 {
+		p.syncTaskSelection()
+	}
 }
 
 // moveTaskSelection moves the selected task up or down
@@ -1264,15 +2307,27 @@ func (p *Prompt) moveTaskSelection(delta int) {
 		return
 	}
 
-	// Get current tasks for the feature
-	featureDetail, err := p.MCP.GetFeatureViaStdio(p.SelectedFeature.ID)
-	if err != nil || len(featureDetail.Tasks) == 0 {
+	// Step through the tasks the filter shows
+	visible := p.visibleTaskIndices()
+	if len(visible) == 0 {
 		return
 	}
+	pos := -1
+	for i, index := range visible {
+		if index == p.selectedTaskIndex {
+			pos = i
+			break
+		}
+	}
+	if pos < 0 {
+		// The filter hides the selection, so start from the first visible task
+		pos = 0
+		delta = 0
+	}
 
 	// Update selected task index with bounds checking
 	oldIndex := p.selectedTaskIndex
-	p.selectedTaskIndex = (p.selectedTaskIndex + delta + len(featureDetail.Tasks)) % len(featureDetail.Tasks)
+	p.selectedTaskIndex = visible[(pos+delta+len(visible))%len(visible)]
 
 	// Auto-scroll to keep selected task visible
 	if oldIndex != p.selectedTaskIndex {
@@ -1280,6 +2335,68 @@ func (p *Prompt) moveTaskSelection(delta int) {
 	}
 }
 
+// handleTaskNumberKey collects digits typed in the Tasks panel and jumps to
+// that task number on g or enter. Returns whether the key was consumed.
+func (p *Prompt) handleTaskNumberKey(key string) bool {
+	if len(key) == 1 && key[0] >= '0' && key[0] <= '9' {
+		if len(p.pendingTaskNumber) < 4 {
+			p.pendingTaskNumber += key
+		}
+		p.StatusBar = fmt.Sprintf("Go to task: %s (g/enter to jump, esc to cancel)", p.pendingTaskNumber)
+		return true
+	}
+	if p.pendingTaskNumber == "" {
+		return false
+	}
+
+	switch key {
+	case "g", "enter":
+		n, _ := strconv.Atoi(p.pendingTaskNumber)
+		p.pendingTaskNumber = ""
+		p.jumpToTask(n)
+		return true
+	case "backspace":
+		p.pendingTaskNumber = p.pendingTaskNumber[:len(p.pendingTaskNumber)-1]
+		if p.pendingTaskNumber == "" {
+			p.StatusBar = ""
+		} else {
+			p.StatusBar = fmt.Sprintf("Go to task: %s (g/enter to jump, esc to cancel)", p.pendingTaskNumber)
+		}
+		return true
+	case "esc":
+		p.pendingTaskNumber = ""
+		p.StatusBar = ""
+		return true
+	}
+	// Any other key abandons the jump and is handled normally
+	p.pendingTaskNumber = ""
+	return false
+}
+
+// jumpToTask selects task number n (1-based) in the selected feature
+func (p *Prompt) jumpToTask(n int) {
+	if p.SelectedFeature == nil || p.MCP == nil {
+		return
+	}
+	featureDetail, err := p.tasksFor(p.SelectedFeature.ID)
+	if err != nil {
+		p.StatusBar = "Error getting tasks: " + errorText(err)
+		return
+	}
+	if n < 1 || n > len(featureDetail.Tasks) {
+		p.StatusBar = fmt.Sprintf("No task %d (this feature has %d)", n, len(featureDetail.Tasks))
+		return
+	}
+	p.selectedTaskIndex = n - 1
+	status := fmt.Sprintf("Task %d: %s", n, featureDetail.Tasks[n-1].Title)
+	if p.taskFilter.active() && !slices.Contains(p.taskFilter.visibleTasks(featureDetail.Tasks), n-1) {
+		p.taskFilter = taskFilter{}
+		status += " (task filter cleared)"
+	}
+	p.ensureTaskVisible()
+	p.StatusBar = status
+}
+
 // ensureTaskVisible adjusts scroll to keep the selected task in view
 func (p *Prompt) ensureTaskVisible() {
 	if p.SelectedFeature == nil || p.MCP == nil {
@@ -1292,9 +2409,9 @@ func (p *Prompt) ensureTaskVisible() {
 		mainContentHeight = 1
 	}
 
-	// Get tasks to calculate task positions
-	featureDetail, err := p.MCP.GetFeatureViaStdio(p.SelectedFeature.ID)
-	if err != nil || len(featureDetail.Tasks) == 0 {
+	// Get the shown tasks to calculate task positions
+	position := slices.Index(p.visibleTaskIndices(), p.selectedTaskIndex)
+	if position < 0 {
 		return
 	}
 
@@ -1303,7 +2420,11 @@ func (p *Prompt) ensureTaskVisible() {
 	linesPerTask := 8
 
 	// Calculate position of selected task in lines
-	selectedTaskLine := p.selectedTaskIndex * linesPerTask
+	selectedTaskLine := position * linesPerTask
+	if p.compactTasks {
+		// One line per task, plus the selected task's criteria
+		selectedTaskLine, linesPerTask = position, p.compactTaskLines()
+	}
 
 	// Adjust scroll if selected task is outside visible area
 	visibleStart := p.mainPanelScroll
@@ -1332,28 +2453,40 @@ func (p *Prompt) ensureTaskVisible() {
 // renderTasksForFeature fetches and renders tasks for the given feature
 func (p *Prompt) renderTasksForFeature(feature *mcpclient.Feature) string {
 	if feature == nil {
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render("No feature selected") + "\n"
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render("No feature selected") + "\n"
 	}
 
 	// Try to get feature details with tasks from MCP
 	if p.MCP != nil {
-		featureDetail, err := p.MCP.GetFeatureViaStdio(feature.ID)
+		featureDetail, err := p.tasksFor(feature.ID)
+		if errors.Is(err, errTasksLoading) {
+			return p.spinner.View() + lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render("Loading tasks…") + "\n"
+		}
 		if err != nil {
-			return lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("Error loading tasks: "+err.Error()) + "\n"
+			return lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Error)).Render("Error loading tasks: "+errorText(err)) + "\n"
 		}
 
-		if len(featureDetail.Tasks) == 0 {
-			return lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render("No tasks defined for this feature") + "\n"
+		creating := p.editingTask && p.taskEditForm != nil && p.taskEditForm.creating
+		if len(featureDetail.Tasks) == 0 && !creating {
+			return lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render("No tasks defined for this feature (press 'a' to add one)") + "\n"
 		}
 
 		var result strings.Builder
-		for i, task := range featureDetail.Tasks {
-			isSelected := (i == p.selectedTaskIndex)
+		result.WriteString(p.taskFilterView())
+		visible := p.taskFilter.visibleTasks(featureDetail.Tasks)
+		if len(visible) == 0 && !creating {
+			result.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render("No tasks match the filter (esc to clear)") + "\n")
+		}
+		for _, i := range visible {
+			task := featureDetail.Tasks[i]
+			isSelected := (i == p.selectedTaskIndex) && !creating
 
 			// If this is the task being edited, show the form instead of the task box
 			if p.editingTask && isSelected && p.taskEditForm != nil {
 				editBox := p.renderTaskEditForm(task, i+1)
 				result.WriteString(editBox)
+			} else if p.compactTasks {
+				result.WriteString(p.renderTaskLine(task, i+1, isSelected))
 			} else {
 				taskBox := p.renderTaskBox(task, i+1, isSelected)
 				result.WriteString(taskBox)
@@ -1361,10 +2494,15 @@ func (p *Prompt) renderTasksForFeature(feature *mcpclient.Feature) string {
 			// No padding between tasks - they connect visually
 		}
 
+		// A task being created is shown as a form after the existing tasks
+		if creating {
+			result.WriteString(p.renderTaskEditForm(mcpclient.Task{}, len(featureDetail.Tasks)+1))
+		}
+
 		return result.String()
 	}
 
-	return lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render("MCP client not available") + "\n"
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render("MCP client not available") + "\n"
 }
 
 // TaskEditForm represents the form for editing a task
@@ -1375,10 +2513,19 @@ type TaskEditForm struct {
 	description  string
 	criteria     []string
 	criteriaText string // For huh form binding
+	theme        Theme
+	creating     bool          // true when the form creates a new task instead of editing one
+	estimate     string        // blank when not estimated
+	original     [4]string     // title, description, criteria and estimate the form opened with
+	criteriaList *CriteriaList // set while criteria are edited line by line (ctrl+g)
+	rebuilt      bool          // the form was rebuilt once after rendering empty
 }
 
 // startTaskEdit initiates task editing mode
 func (p *Prompt) startTaskEdit() (*Prompt, tea.Cmd) {
+	if p.blockedByReadOnly() {
+		return p, nil
+	}
 	if p.SelectedFeature == nil {
 		p.StatusBar = "No selected feature"
 		return p, nil
@@ -1389,9 +2536,9 @@ func (p *Prompt) startTaskEdit() (*Prompt, tea.Cmd) {
 	}
 
 	// Get the selected task
-	featureDetail, err := p.MCP.GetFeatureViaStdio(p.SelectedFeature.ID)
+	featureDetail, err := p.featureDetail(p.SelectedFeature.ID)
 	if err != nil {
-		p.StatusBar = fmt.Sprintf("Error getting feature: %v", err)
+		p.StatusBar = "Error getting feature: " + errorText(err)
 		return p, nil
 	}
 	if len(featureDetail.Tasks) == 0 {
@@ -1404,20 +2551,50 @@ func (p *Prompt) startTaskEdit() (*Prompt, tea.Cmd) {
 	}
 
 	selectedTask := featureDetail.Tasks[p.selectedTaskIndex]
-	p.StatusBar = fmt.Sprintf("DEBUG: Creating form for task: %s", selectedTask.Title)
 
 	// Create the edit form
 	p.taskEditForm = &TaskEditForm{
 		visible:     true,
+		theme:       p.theme,
 		title:       selectedTask.Title,
 		description: selectedTask.Description,
 		criteria:    selectedTask.EvaluationCriteria,
+		estimate:    estimateText(selectedTask.Estimate),
 	}
+	f := p.taskEditForm
+	f.original = [4]string{f.title, f.description, strings.Join(f.criteria, "\n"), f.estimate}
 
 	p.taskEditForm.buildForm()
 	p.editingTask = true
 
-	p.StatusBar = fmt.Sprintf("DEBUG: Form created, editingTask=%v, visible=%v", p.editingTask, p.taskEditForm.visible)
+	p.StatusBar = fmt.Sprintf("Editing task: %s", selectedTask.Title)
+	slog.Debug("editing task", "feature", p.SelectedFeature.ID, "task", selectedTask.ID)
+
+	return p, p.taskEditForm.Init()
+}
+
+// startTaskCreate opens a blank task form that creates a new task on completion
+func (p *Prompt) startTaskCreate() (*Prompt, tea.Cmd) {
+	if p.blockedByReadOnly() {
+		return p, nil
+	}
+	if p.SelectedFeature == nil {
+		p.StatusBar = "No selected feature"
+		return p, nil
+	}
+	if p.MCP == nil {
+		p.StatusBar = "MCP client not available"
+		return p, nil
+	}
+
+	p.taskEditForm = &TaskEditForm{
+		visible:  true,
+		theme:    p.theme,
+		creating: true,
+	}
+	p.taskEditForm.buildForm()
+	p.editingTask = true
+	p.StatusBar = "Creating new task"
 
 	return p, p.taskEditForm.Init()
 }
@@ -1451,17 +2628,26 @@ func (f *TaskEditForm) buildForm() {
 				Value(&f.criteriaText).
 				Placeholder("Enter acceptance criteria, one per line...").
 				Lines(5),
+
+			huh.NewInput().
+				Key("estimate").
+				Title("Estimate (points or hours)").
+				Value(&f.estimate).
+				Placeholder("Leave blank if not estimated").
+				Validate(func(text string) error {
+					_, err := parseEstimate(text)
+					return err
+				}),
 		),
 	).
 		WithTheme(huh.ThemeDracula()).
 		WithShowHelp(true).
 		WithShowErrors(true)
 
-	// Debug: ensure form was created
 	if f.form == nil {
-		fmt.Printf("DEBUG: Failed to create huh form\n")
+		slog.Error("failed to create task edit form", "task", f.title)
 	} else {
-		fmt.Printf("DEBUG: huh form created successfully, title='%s'\n", f.title)
+		slog.Debug("task edit form created", "task", f.title)
 	}
 }
 
@@ -1475,17 +2661,34 @@ func (f *TaskEditForm) Init() tea.Cmd {
 
 // Update handles task edit form updates
 func (f *TaskEditForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if !f.visible || f.form == nil {
+	if !f.visible {
 		return f, nil
 	}
-
-	// Handle escape to cancel
-	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "esc" {
+	// Esc must still work when the form failed, since the error view offers it
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "esc" && f.criteriaList == nil {
 		f.visible = false
 		return f, func() tea.Msg {
 			return TaskEditCancelMsg{}
 		}
 	}
+	if f.form == nil {
+		return f, nil
+	}
+
+	if f.criteriaList != nil {
+		done, cmd := f.criteriaList.Update(msg)
+		if done {
+			return f, f.closeCriteriaList()
+		}
+		return f, cmd
+	}
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		if keyMsg.String() == "ctrl+g" {
+			f.openCriteriaList()
+			return f, nil
+		}
+	}
 
 	// Update form
 	form, cmd := f.form.Update(msg)
@@ -1497,20 +2700,17 @@ func (f *TaskEditForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if f.form.State == huh.StateCompleted {
 		f.visible = false
 
-		// Parse criteria back to slice
-		criteria := []string{}
-		for _, line := range strings.Split(f.criteriaText, "\n") {
-			line = strings.TrimSpace(line)
-			if line != "" {
-				criteria = append(criteria, line)
-			}
-		}
+		criteria := parseCriteria(f.criteriaText)
+		// The field validated it already
+		estimate, _ := parseEstimate(f.form.GetString("estimate"))
 
 		return f, func() tea.Msg {
 			return TaskEditCompleteMsg{
 				Title:       f.form.GetString("title"),
 				Description: f.form.GetString("description"),
 				Criteria:    criteria,
+				Estimate:    estimate,
+				Creating:    f.creating,
 			}
 		}
 	}
@@ -1518,38 +2718,130 @@ func (f *TaskEditForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return f, cmd
 }
 
+// openCriteriaList switches the criteria field to line-by-line editing
+func (f *TaskEditForm) openCriteriaList() {
+	f.syncFocusedField()
+	f.criteriaList = NewCriteriaList(parseCriteria(f.criteriaText))
+}
+
+// closeCriteriaList writes the edited rows back and rebuilds the form with
+// the criteria field focused, since huh fields don't re-read their values
+func (f *TaskEditForm) closeCriteriaList() tea.Cmd {
+	f.criteria = f.criteriaList.Items
+	f.criteriaList = nil
+	f.buildForm()
+	return tea.Batch(f.form.Init(), f.form.NextField(), f.form.NextField())
+}
+
+// syncFocusedField copies the focused field's value to its binding. huh only
+// writes a text field's value back when it loses focus.
+func (f *TaskEditForm) syncFocusedField() {
+	field := f.form.GetFocusedField()
+	if field == nil {
+		return
+	}
+	value, ok := field.GetValue().(string)
+	if !ok {
+		return
+	}
+	switch field.GetKey() {
+	case "title":
+		f.title = value
+	case "description":
+		f.description = value
+	case "criteria":
+		f.criteriaText = value
+	case "estimate":
+		f.estimate = value
+	}
+}
+
+// parseCriteria splits newline-separated criteria, trimming lines and dropping empties
+func parseCriteria(text string) []string {
+	criteria := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			criteria = append(criteria, line)
+		}
+	}
+	return criteria
+}
+
+// dirty reports whether anything was typed since the form opened
+func (f *TaskEditForm) dirty() bool {
+	criteria := parseCriteria(f.criteriaText)
+	if f.criteriaList != nil {
+		criteria = parseCriteria(strings.Join(f.criteriaList.Items, "\n"))
+	}
+	return f.title != f.original[0] || f.description != f.original[1] ||
+		strings.Join(criteria, "\n") != f.original[2] || strings.TrimSpace(f.estimate) != f.original[3]
+}
+
 // View renders the task edit form
 func (f *TaskEditForm) View() string {
 	if !f.visible {
-		return "DEBUG: Form not visible"
-	}
-	if f.form == nil {
-		return "DEBUG: Form is nil"
+		return ""
 	}
 
 	// Add header
 	headerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("39")).
+		Foreground(lipgloss.Color(f.theme.Focus)).
 		Bold(true).
 		Padding(0, 1)
 
 	header := headerStyle.Render("📝 Edit Task")
 
-	// Get form view with debugging
-	formView := f.form.View()
-	if formView == "" {
-		return "DEBUG: huh form.View() returned empty string\nForm state: " + fmt.Sprintf("%+v", f.form.State) + "\nPress ESC to cancel"
-	}
-
 	// Style the form
 	dialogStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("39")).
+		BorderForeground(lipgloss.Color(f.theme.Focus)).
 		Padding(1, 2).
 		Width(80)
 
-	content := header + "\n\n" + formView
-	return dialogStyle.Render(content)
+	if f.criteriaList != nil {
+		return dialogStyle.Render(header + "\n\n" + f.criteriaList.View(72, f.theme))
+	}
+	formView, ok := f.formView()
+	if !ok {
+		message := lipgloss.NewStyle().Foreground(lipgloss.Color(f.theme.Error)).Render("The task form couldn't be displayed.")
+		hint := lipgloss.NewStyle().Foreground(lipgloss.Color(f.theme.Muted)).Render("Press Esc to cancel and try again.")
+		return dialogStyle.BorderForeground(lipgloss.Color(f.theme.Error)).Render(header + "\n\n" + message + "\n" + hint)
+	}
+	return dialogStyle.Render(header + "\n\n" + formView)
+}
+
+// formView renders the huh form. A missing form or an empty render is
+// recovered from once by rebuilding the form from the values entered so far;
+// ok is false when that didn't help either.
+func (f *TaskEditForm) formView() (view string, ok bool) {
+	if f.form != nil {
+		if view = f.form.View(); view != "" {
+			return view, true
+		}
+	}
+	if f.rebuilt {
+		return "", false
+	}
+	f.rebuilt = true
+	if f.form != nil {
+		slog.Warn("task edit form rendered empty, rebuilding", "task", f.title, "state", f.form.State)
+		f.syncFocusedField()
+	} else {
+		slog.Warn("task edit form missing, rebuilding", "task", f.title)
+	}
+	// buildForm refills the criteria field from f.criteria
+	if f.criteriaText != "" {
+		f.criteria = parseCriteria(f.criteriaText)
+	}
+	f.buildForm()
+	if f.form == nil {
+		return "", false
+	}
+	// Init lays the fields out; its command only starts the cursor blinking
+	f.form.Init()
+	view = f.form.View()
+	return view, view != ""
 }
 
 // IsVisible returns whether the form is visible
@@ -1562,22 +2854,20 @@ type TaskEditCompleteMsg struct {
 	Title       string
 	Description string
 	Criteria    []string
+	Estimate    float64 // 0 when not estimated
+	Creating    bool    // the form was opened to create a new task
 }
 
-type TaskEditCancelMsg struct{}
-
-// renderTaskBox creates a styled box for a single task
-func (p *Prompt) renderTaskBox(task mcpclient.Task, taskNumber int, isSelected bool) string {
-	// Use blue colors for selected task, gray for unselected
-	borderColor := "240"   // Default gray
-	headerBgColor := "240" // Default gray
+// taskCreatedMsg is sent when a new task has been created via MCP
+type taskCreatedMsg struct {
+	task mcpclient.Task
+	err  error
+}
 
-	if isSelected {
-		borderColor = "39"   // Blue border for selected task
-		headerBgColor = "39" // Blue header background for selected task
-	}
+type TaskEditCancelMsg struct{}
 
-	// Calculate available width for the task boxes
+// taskContentWidth is the width of a task box inside the main panel
+func (p *Prompt) taskContentWidth() int {
 	terminalWidth := p.WindowWidth
 	if terminalWidth < 80 {
 		terminalWidth = 80
@@ -1591,14 +2881,32 @@ func (p *Prompt) renderTaskBox(task mcpclient.Task, taskNumber int, isSelected b
 	if contentWidth < 40 {
 		contentWidth = 40
 	}
+	return contentWidth
+}
+
+// renderTaskBox creates a styled box for a single task
+func (p *Prompt) renderTaskBox(task mcpclient.Task, taskNumber int, isSelected bool) string {
+	// Use focus colors for selected task, border colors for unselected
+	borderColor := p.theme.Border
+	headerBgColor := p.theme.Border
+
+	if isSelected {
+		borderColor = p.theme.Focus
+		headerBgColor = p.theme.Focus
+	}
+
+	contentWidth := p.taskContentWidth()
 
 	var result strings.Builder
 
 	// Task header with gray background - FULL WIDTH minus internal spacing
 	headerText := fmt.Sprintf("Task %d: %s", taskNumber, task.Title)
+	if task.Estimate > 0 {
+		headerText += " · est " + formatEstimate(task.Estimate)
+	}
 	headerStyle := lipgloss.NewStyle().
 		Background(lipgloss.Color(headerBgColor)).
-		Foreground(lipgloss.Color("255")).
+		Foreground(lipgloss.Color(p.theme.SelectedText)).
 		Bold(true).
 		Padding(0, 1).
 		Width(contentWidth - 0) // -4 for box borders (2) + internal padding (2)
@@ -1607,7 +2915,7 @@ func (p *Prompt) renderTaskBox(task mcpclient.Task, taskNumber int, isSelected b
 
 	// Task description - simple styling
 	descStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("248")).
+		Foreground(lipgloss.Color(p.theme.Value)).
 		Padding(1, 1, 0, 1) // top, right, bottom, left
 
 	result.WriteString(descStyle.Render(task.Description) + "\n")
@@ -1615,20 +2923,17 @@ func (p *Prompt) renderTaskBox(task mcpclient.Task, taskNumber int, isSelected b
 	// Acceptance criteria
 	if len(task.EvaluationCriteria) > 0 {
 		criteriaHeaderStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("214")).
+			Foreground(lipgloss.Color(p.theme.Warning)).
 			Bold(true).
 			Padding(0, 1)
 
 		result.WriteString(criteriaHeaderStyle.Render("Acceptance Criteria:") + "\n")
 
-		for i, criteria := range task.EvaluationCriteria {
-			testStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("245")).
-				PaddingLeft(3)
-
-			testLine := fmt.Sprintf("⧖ Test %d: %s", i+1, criteria)
-			result.WriteString(testStyle.Render(testLine) + "\n")
+		for i := range task.EvaluationCriteria {
+			result.WriteString(p.criterionLine(task, i) + "\n")
 		}
+	} else {
+		result.WriteString(" " + p.missingCriteriaBadge() + "\n")
 	}
 
 	// Wrap everything in a simple border with consistent width
@@ -1661,9 +2966,12 @@ func (p *Prompt) renderTaskEditForm(task mcpclient.Task, taskNumber int) string
 
 	// Header showing we're editing this task
 	headerText := fmt.Sprintf("✏️ Editing Task %d", taskNumber)
+	if p.taskEditForm != nil && p.taskEditForm.creating {
+		headerText = fmt.Sprintf("✏️ New Task %d", taskNumber)
+	}
 	headerStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("39")).
-		Foreground(lipgloss.Color("255")).
+		Background(lipgloss.Color(p.theme.Focus)).
+		Foreground(lipgloss.Color(p.theme.SelectedText)).
 		Bold(true).
 		Padding(0, 1).
 		Width(contentWidth)
@@ -1673,13 +2981,13 @@ func (p *Prompt) renderTaskEditForm(task mcpclient.Task, taskNumber int) string
 	// Simple inline form using basic text styling instead of huh
 
 	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("39")).
+		Foreground(lipgloss.Color(p.theme.Focus)).
 		Bold(true).
 		Padding(0, 1)
 
 	valueStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("248")).
-		Background(lipgloss.Color("236")).
+		Foreground(lipgloss.Color(p.theme.Value)).
+		Background(lipgloss.Color(p.theme.StatusBg)).
 		Padding(0, 1).
 		Width(contentWidth - 4)
 
@@ -1697,7 +3005,9 @@ func (p *Prompt) renderTaskEditForm(task mcpclient.Task, taskNumber int) string
 
 	// Criteria field
 	result.WriteString(labelStyle.Render("Acceptance Criteria:") + "\n")
-	if p.taskEditForm != nil && len(p.taskEditForm.criteria) > 0 {
+	if p.taskEditForm != nil && p.taskEditForm.criteriaList != nil {
+		result.WriteString(p.taskEditForm.criteriaList.View(contentWidth-4, p.theme) + "\n")
+	} else if p.taskEditForm != nil && len(p.taskEditForm.criteria) > 0 {
 		for i, criteria := range p.taskEditForm.criteria {
 			criteriaLine := fmt.Sprintf("%d. %s", i+1, criteria)
 			result.WriteString(valueStyle.Render(criteriaLine) + "\n")
@@ -1706,16 +3016,16 @@ func (p *Prompt) renderTaskEditForm(task mcpclient.Task, taskNumber int) string
 
 	// Instructions
 	instructStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("245")).
+		Foreground(lipgloss.Color(p.theme.Muted)).
 		Italic(true).
 		Padding(1, 1, 0, 1)
 
-	result.WriteString(instructStyle.Render("Press ENTER to edit in external editor, ESC to cancel") + "\n")
+	result.WriteString(instructStyle.Render("Press ENTER to edit in external editor, CTRL+G to edit criteria line by line, ESC to cancel") + "\n")
 
 	// Wrap in a box with blue border to show it's being edited
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("39")).
+		BorderForeground(lipgloss.Color(p.theme.Focus)).
 		Width(contentWidth).
 		Margin(0, 0, 1, 0)
 
@@ -1826,18 +3136,34 @@ func (p *Prompt) getMaxMainPanelScroll() int {
 func (p *Prompt) generateSidebarContent() string {
 	sidebar := ""
 
-	appendGroup := func(label string, features []mcpclient.Feature, color string) {
-		groupStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Bold(true)
-		sidebar += groupStyle.Render(label) + ":\n"
+	appendGroup := func(label string, features []mcpclient.Feature, color string, collapsed bool) {
+		groupStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Bold(true)
+		if collapsed {
+			summary := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render(collapsedGroupSummary(len(features)))
+			sidebar += groupStyle.Render(label) + " " + summary + "\n\n"
+			return
+		}
+		// Collapsed groups already show their count in the summary
+		sidebar += groupStyle.Render(fmt.Sprintf("%s (%d)", label, len(features))) + ":\n"
 		for _, f := range features {
 			selected := p.SelectedFeature != nil && f.ID == p.SelectedFeature.ID
 			dot := lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render("●")
+			if p.marked[f.ID] {
+				dot = lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Bold(true).Render("✓")
+			}
+			progress := ""
+			if summary := p.taskProgressView(f.ID); summary != "" {
+				progress = " " + summary
+			}
+			if flag := p.missingCriteriaView(f.ID); flag != "" {
+				progress += " " + flag
+			}
 			if selected {
-				nameStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("255"))
-				sidebar += dot + " " + nameStyle.Render(f.Name) + "\n"
+				nameStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(p.theme.Text))
+				sidebar += dot + " " + nameStyle.Render(f.Name) + progress + "\n"
 			} else {
-				nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("248"))
-				sidebar += dot + " " + nameStyle.Render(f.Name) + "\n"
+				nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Value))
+				sidebar += dot + " " + nameStyle.Render(f.Name) + progress + "\n"
 			}
 		}
 		sidebar += "\n"
@@ -1861,10 +3187,13 @@ func (p *Prompt) generateSidebarContent() string {
 		}
 	}
 
-	appendGroup("Current", currentFeatures, "46")             // Green
-	appendGroup("Accepted", p.FeaturesData.Approved, "39")    // Blue
-	appendGroup("Refining", p.FeaturesData.Refinement, "214") // Orange
-	appendGroup("Backlog", p.FeaturesData.Backlog, "245")     // Gray
+	// A status filter shows only its own group
+	if p.featureStatusFilter == "" {
+		appendGroup("Current", p.sortFeatures(currentFeatures), p.theme.Success, false)
+	}
+	for _, group := range p.featureGroups() {
+		appendGroup(group.label, group.features, group.color, p.collapsedGroups[group.status])
+	}
 
 	return sidebar
 }
@@ -1878,13 +3207,16 @@ func (p *Prompt) generateFeatureDataContent(feature *mcpclient.Feature) string {
 	// Sync text input values with the selected feature (if not already synced)
 	p.syncFeatureInputs(feature)
 
-	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Bold(true)
-	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Bold(true)
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Text))
 
 	var content string
 
 	// ID (not editable)
 	content += labelStyle.Render("ID: ") + valueStyle.Render(feature.ID) + "\n"
+	if times := featureTimesView(feature, time.Now()); times != "" {
+		content += lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render(times) + "\n"
+	}
 
 	// Editable Name field
 	content += labelStyle.Render("Name: ") + "\n"
@@ -1906,8 +3238,17 @@ func (p *Prompt) generateFeatureDataContent(feature *mcpclient.Feature) string {
 		content += "  " + valueStyle.Render(p.featureDescriptionEdit.Value()) + "\n"
 	}
 
-	// Status (not editable for now)
-	content += labelStyle.Render("Status: ") + valueStyle.Render(feature.Status) + "\n\n"
+	// Status, changed with ctrl+t and saved with the other fields
+	content += labelStyle.Render("Status: ") + p.featureStatusView() + "\n"
+
+	// Total of the task estimates, once the tasks have been loaded
+	if rollup := p.estimateRollupView(feature.ID); rollup != "" {
+		content += labelStyle.Render("Estimate: ") + rollup + "\n"
+	}
+
+	// Features this one depends on, edited with ctrl+k
+	content += labelStyle.Render("Dependencies:") + "\n"
+	content += p.dependenciesView(feature) + "\n"
 
 	// Add PRD document section
 	content += labelStyle.Render("Product Requirements Document:") + "\n"
@@ -1933,8 +3274,23 @@ func (p *Prompt) syncFeatureInputs(feature *mcpclient.Feature) {
 	}
 }
 
-// saveFeatureChanges saves the edited feature name and description via MCP
+// savePRD writes the PRD of the selected feature via MCP, reporting the
+// result as a prdSavedMsg
+func (p *Prompt) savePRD(content string) tea.Cmd {
+	if p.SelectedFeature == nil || p.MCP == nil {
+		return nil
+	}
+	featureID := p.SelectedFeature.ID
+	return func() tea.Msg {
+		return prdSavedMsg{featureID: featureID, err: p.MCP.UpdateFeatureDocumentViaStdio(featureID, content)}
+	}
+}
+
+// saveFeatureChanges saves the edited feature name, description and status via MCP
 func (p *Prompt) saveFeatureChanges() (*Prompt, tea.Cmd) {
+	if p.blockedByReadOnly() {
+		return p, nil
+	}
 	if p.SelectedFeature == nil || p.MCP == nil {
 		p.StatusBar = "Cannot save: no feature selected or MCP unavailable"
 		return p, nil
@@ -1954,45 +3310,48 @@ func (p *Prompt) saveFeatureChanges() (*Prompt, tea.Cmd) {
 	}
 
 	// Check if anything actually changed
-	if newName == p.SelectedFeature.Name && newDescription == p.SelectedFeature.Description {
+	status := p.pendingFeatureStatus()
+	statusChanged := status != p.SelectedFeature.Status
+	fieldsChanged := newName != p.SelectedFeature.Name || newDescription != p.SelectedFeature.Description
+	if !fieldsChanged && !statusChanged {
 		p.StatusBar = "No changes to save"
 		return p, nil
 	}
 
-	// Update the feature via MCP
-	go func() {
-		updates := map[string]interface{}{}
-		if newName != p.SelectedFeature.Name {
-			updates["name"] = newName
-		}
-		if newDescription != p.SelectedFeature.Description {
-			updates["description"] = newDescription
-		}
-
-		// Note: This would need the updateFeature MCP tool, but we're using the existing structure
-		// For now, just update the local feature object
-		p.SelectedFeature.Name = newName
-		p.SelectedFeature.Description = newDescription
-		p.StatusBar = fmt.Sprintf("Feature updated: %s", newName)
-	}()
+	var cmds []tea.Cmd
+	if fieldsChanged {
+		// The edit becomes undoable once it's saved
+		previous := undoEntry{featureID: p.SelectedFeature.ID, name: p.SelectedFeature.Name, desc: p.SelectedFeature.Description}
+		p.StatusBar = "Saving feature: " + newName
+		cmds = append(cmds, p.saveFeatureFields(previous.featureID, newName, newDescription, func(err error) tea.Msg {
+			return featureSavedMsg{previous: previous, name: newName, desc: newDescription, err: err}
+		}))
+	}
+	if statusChanged {
+		p.StatusBar = "Saving status: " + status
+		cmds = append(cmds, p.saveFeatureStatus(p.SelectedFeature.ID, status))
+	}
 
-	return p, nil
+	return p, tea.Batch(cmds...)
 }
 
-// renderPRDDocument fetches and displays the PRD document with a simple border
+// renderPRDDocument displays the cached PRD document with a simple border
 func (p *Prompt) renderPRDDocument(feature *mcpclient.Feature) string {
 	if feature == nil || p.MCP == nil {
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render("No feature selected") + "\n"
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render("No feature selected") + "\n"
 	}
 
-	// Try to get the PRD document
-	prdContent, err := p.MCP.GetFeatureDocumentViaStdio(feature.ID)
+	// The PRD is fetched in the background by loadVisiblePRD
+	prdContent, err := p.prdFor(feature.ID)
+	if errors.Is(err, errPRDLoading) {
+		return p.spinner.View() + lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render("Loading PRD…") + "\n"
+	}
 	if err != nil {
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("Error loading PRD: "+err.Error()) + "\n"
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Error)).Render("Error loading PRD: "+errorText(err)) + "\n"
 	}
 
 	if prdContent == "" {
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render("No PRD document available") + "\n"
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render("No PRD document available") + "\n"
 	}
 
 	// Calculate content width
@@ -2012,7 +3371,7 @@ func (p *Prompt) renderPRDDocument(feature *mcpclient.Feature) string {
 	// Create border style
 	borderStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		BorderForeground(lipgloss.Color(p.theme.Border)).
 		Padding(1).
 		Width(contentWidth)
 
@@ -2020,15 +3379,21 @@ func (p *Prompt) renderPRDDocument(feature *mcpclient.Feature) string {
 	scrollHint := ""
 	if p.focusState == 1 { // Feature spec view focused
 		scrollHint = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("245")).
-			Render("(Press 'e' to edit PRD, ↑↓ to scroll)")
+			Foreground(lipgloss.Color(p.theme.Muted)).
+			Render("(Press 'e' to edit PRD, ↑↓ to scroll, ctrl+p to open in $PAGER)")
 	}
 
-	return borderStyle.Render(prdContent) + "\n" + scrollHint + "\n"
+	// Length at a glance, recomputed from the fetched PRD so it follows edits
+	stats := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render(documentStats(prdContent))
+
+	return stats + "\n" + borderStyle.Render(prdContent) + "\n" + scrollHint + "\n"
 }
 
 // startPRDEdit starts editing the PRD document (external editor or inline)
 func (p *Prompt) startPRDEdit() (*Prompt, tea.Cmd) {
+	if p.blockedByReadOnly() {
+		return p, nil
+	}
 	if p.SelectedFeature == nil {
 		p.StatusBar = "No feature selected"
 		return p, nil
@@ -2041,23 +3406,27 @@ func (p *Prompt) startPRDEdit() (*Prompt, tea.Cmd) {
 	// Get the current PRD content
 	prdContent, err := p.MCP.GetFeatureDocumentViaStdio(p.SelectedFeature.ID)
 	if err != nil {
-		p.StatusBar = fmt.Sprintf("Error getting PRD: %v", err)
+		p.StatusBar = "Error getting PRD: " + errorText(err)
 		return p, nil
 	}
 
-	// Check if $EDITOR is set
-	editor := os.Getenv("EDITOR")
-	if editor != "" {
-		// Use external editor
-		return p.startExternalPRDEdit(prdContent)
-	} else {
-		// Use inline editing
+	// Use the configured editor or $EDITOR, otherwise edit inline
+	editor := p.editorCommand()
+	if editor == "" {
 		return p.startInlinePRDEdit(prdContent)
 	}
+	args, err := editorArgs(editor)
+	if err != nil {
+		p, cmd := p.startInlinePRDEdit(prdContent)
+		p.StatusBar = fmt.Sprintf("%v - editing the PRD inline: Ctrl+S saves, Esc cancels", err)
+		return p, cmd
+	}
+	return p.startExternalPRDEdit(prdContent, args)
 }
 
-// startExternalPRDEdit opens the PRD in an external editor
-func (p *Prompt) startExternalPRDEdit(prdContent string) (*Prompt, tea.Cmd) {
+// startExternalPRDEdit opens the PRD in an external editor, run as args
+// followed by the file to edit
+func (p *Prompt) startExternalPRDEdit(prdContent string, args []string) (*Prompt, tea.Cmd) {
 	// Create a temporary file for editing
 	tmpFile, err := os.CreateTemp("", fmt.Sprintf("tdd-pro-%s-prd-*.md", p.SelectedFeature.ID))
 	if err != nil {
@@ -2074,29 +3443,34 @@ func (p *Prompt) startExternalPRDEdit(prdContent string) (*Prompt, tea.Cmd) {
 	}
 	tmpFile.Close()
 
-	// Get editor from environment
-	editor := os.Getenv("EDITOR")
-	p.StatusBar = fmt.Sprintf("Opening %s...", editor)
+	p.StatusBar = fmt.Sprintf("Opening %s...", args[0])
 
 	// Return a command that will open the editor
-	return p, tea.ExecProcess(exec.Command(editor, tmpFile.Name()), func(err error) tea.Msg {
+	cmd := exec.Command(args[0], append(args[1:], tmpFile.Name())...)
+	return p, tea.ExecProcess(cmd, func(err error) tea.Msg {
 		defer os.Remove(tmpFile.Name())
 
+		// Read the edited content
+		editedContent, readErr := os.ReadFile(tmpFile.Name())
+		unchanged := readErr == nil && string(editedContent) == prdContent
+
 		if err != nil {
+			// A non-zero exit (e.g. vim's :cq) means the edit was abandoned
 			return PRDEditResultMsg{
-				Success: false,
-				Error:   fmt.Sprintf("Editor error: %v", err),
+				Success:   false,
+				Unchanged: unchanged,
+				Error:     fmt.Sprintf("Editor error: %v", err),
 			}
 		}
-
-		// Read the edited content
-		editedContent, err := os.ReadFile(tmpFile.Name())
-		if err != nil {
+		if readErr != nil {
 			return PRDEditResultMsg{
 				Success: false,
-				Error:   fmt.Sprintf("Error reading edited file: %v", err),
+				Error:   fmt.Sprintf("Error reading edited file: %v", readErr),
 			}
 		}
+		if unchanged {
+			return PRDEditResultMsg{Unchanged: true}
+		}
 
 		return PRDEditResultMsg{
 			Success: true,
@@ -2119,7 +3493,25 @@ func (p *Prompt) startInlinePRDEdit(prdContent string) (*Prompt, tea.Cmd) {
 
 // PRDEditResultMsg is sent when external PRD editing is complete
 type PRDEditResultMsg struct {
-	Success bool
-	Content string
-	Error   string
+	Success   bool
+	Unchanged bool // the file was left as it was, so there is nothing to save
+	Content   string
+	Error     string
+}
+
+// resizePRDEditor fits the inline PRD textarea to the current window
+func (p *Prompt) resizePRDEditor() {
+	if p.WindowWidth <= 0 || p.WindowHeight <= 0 {
+		return
+	}
+	width := p.WindowWidth - 4
+	if width < 40 {
+		width = 40
+	}
+	height := p.WindowHeight - 8 // header, edit title, status bar and spacing
+	if height < 10 {
+		height = 10
+	}
+	p.prdEditTextarea.SetWidth(width)
+	p.prdEditTextarea.SetHeight(height)
 }
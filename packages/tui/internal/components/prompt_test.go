@@ -0,0 +1,482 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tddpro/internal/mcpclient"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newDemoPrompt returns a prompt backed by the in-memory demo client with the
+// first sample feature selected
+func newDemoPrompt(t *testing.T) *Prompt {
+	t.Helper()
+	p := NewPromptWithClient(mcpclient.NewDemoClient(), "http://localhost:4111", "test")
+	data, err := p.MCP.ListFeaturesViaStdio()
+	if err != nil {
+		t.Fatalf("ListFeaturesViaStdio failed: %v", err)
+	}
+	p.FeaturesData = *data
+	p.FeaturesViewActive = true
+	p.SelectedFeature = &p.FeaturesData.Approved[0]
+	// As /features does, so the tasks render without waiting for a fetch
+	runMessageCmds(&p, p.prefetchFeatureDetails())
+	// and as the first frame does for the selected feature's PRD
+	runMessageCmds(&p, p.loadVisiblePRD())
+	return &p
+}
+
+func TestStartTaskEdit_NoDebugStatus(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.focusState = 2
+	p.FeaturesTab = 1
+
+	p.startTaskEdit()
+	if !p.editingTask || p.taskEditForm == nil {
+		t.Fatal("Expected task edit form to open")
+	}
+	if strings.Contains(p.StatusBar, "DEBUG") {
+		t.Errorf("Status bar leaked debug output: %q", p.StatusBar)
+	}
+	if !strings.HasPrefix(p.StatusBar, "Editing task: ") {
+		t.Errorf("Expected an editing status, got %q", p.StatusBar)
+	}
+	if view := p.taskEditForm.View(); strings.Contains(view, "DEBUG") {
+		t.Errorf("Task form view leaked debug output: %q", view)
+	}
+}
+
+func TestTasksPanel_JumpToTaskNumber(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.focusState = 2
+	p.FeaturesTab = 1
+
+	press := func(key tea.KeyMsg) { p.Update(key) }
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	press(runes("3"))
+	if !strings.Contains(p.StatusBar, "Go to task: 3") {
+		t.Errorf("Expected pending number in status bar, got %q", p.StatusBar)
+	}
+	press(runes("g"))
+	if p.selectedTaskIndex != 2 {
+		t.Errorf("Expected task 3 (index 2) selected, got index %d", p.selectedTaskIndex)
+	}
+
+	press(runes("9"))
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.selectedTaskIndex != 2 {
+		t.Errorf("Expected out-of-range jump to keep selection, got index %d", p.selectedTaskIndex)
+	}
+	if !strings.Contains(p.StatusBar, "No task 9") {
+		t.Errorf("Expected out-of-range message, got %q", p.StatusBar)
+	}
+	if p.pendingTaskNumber != "" {
+		t.Errorf("Expected pending number cleared, got %q", p.pendingTaskNumber)
+	}
+}
+
+func TestHandleClear(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.Conversation.Append(RoleUser, "hello", MessageSent)
+	p.ThinkingState = []string{"thinking"}
+	p.StatusBar = "Reply received!"
+
+	handleClear(p, "")
+	if !p.Conversation.IsEmpty() || p.ThinkingState != nil || p.StatusBar != "" {
+		t.Errorf("Expected conversation, thinking and status cleared, got %+v / %v / %q", p.Conversation, p.ThinkingState, p.StatusBar)
+	}
+}
+
+// A reply that arrives after /clear must not land in the cleared view, nor be
+// taken for the reply to a message sent after it
+func TestHandleClear_CancelsThePendingReply(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.FeaturesViewActive = false
+
+	var waitCmd tea.Cmd
+	for _, c := range p.submitMessage("Plan the login flow")().(tea.BatchMsg) {
+		if msg, ok := c().(messageSendMsg); ok {
+			_, sendCmd := p.Update(msg)
+			_, waitCmd = p.Update(sendCmd())
+		}
+	}
+	handleClear(p, "")
+	if p.awaitingReply || !p.Conversation.IsEmpty() || !strings.Contains(p.StatusBar, "pending reply was cancelled") {
+		t.Fatalf("Expected /clear to cancel the pending reply, got %+v and %q", p.Conversation, p.StatusBar)
+	}
+
+	// The next message takes the cleared one's index
+	cmd := p.submitMessage("Plan the signup flow")
+	late := replyReceivedMsg{index: 0, seq: p.replySeq - 1, reply: "(demo agent) You said: Plan the login flow"}
+	p.Update(late)
+	p.Update(waitCmd())
+	if !p.awaitingReply || len(p.Conversation.Messages) != 1 {
+		t.Fatalf("Expected the late reply dropped, got %+v", p.Conversation.Messages)
+	}
+	runMessageCmds(p, cmd)
+	if last := p.Conversation.Messages[len(p.Conversation.Messages)-1]; last.Content != "(demo agent) You said: Plan the signup flow" {
+		t.Errorf("Expected only the new message answered, got %+v", p.Conversation.Messages)
+	}
+}
+
+func TestView_TooSmallTerminal(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.WindowWidth, p.WindowHeight = 60, 15
+	if view := p.View(); !strings.Contains(view, "Terminal too small (need at least 80x20)") {
+		t.Errorf("Expected too-small message, got:\n%s", view)
+	}
+
+	p.WindowWidth, p.WindowHeight = 120, 40
+	if view := p.View(); strings.Contains(view, "Terminal too small") {
+		t.Error("Expected the normal view once the terminal is large enough")
+	}
+}
+
+// countingClient counts feature detail fetches made through the demo client
+type countingClient struct {
+	*mcpclient.DemoClient
+	single, batch int
+	docs          int   // PRD fetches
+	fail          error // returned by single fetches when set
+}
+
+func (c *countingClient) GetFeatureViaStdio(featureId string) (*mcpclient.FeatureDetail, error) {
+	c.single++
+	if c.fail != nil {
+		return nil, c.fail
+	}
+	return c.DemoClient.GetFeatureViaStdio(featureId)
+}
+
+func (c *countingClient) GetFeatureDocumentViaStdio(featureId string) (string, error) {
+	c.docs++
+	return c.DemoClient.GetFeatureDocumentViaStdio(featureId)
+}
+
+func (c *countingClient) GetFeaturesViaStdio(featureIds []string) (map[string]*mcpclient.FeatureDetail, error) {
+	c.batch++
+	return c.DemoClient.GetFeaturesViaStdio(featureIds)
+}
+
+func TestHandleDestroy_DryRunKeepsProject(t *testing.T) {
+	project := t.TempDir()
+	tddPro := filepath.Join(project, ".tdd-pro")
+	os.MkdirAll(filepath.Join(tddPro, "features"), 0755)
+	os.WriteFile(filepath.Join(tddPro, "features", "index.yml"), []byte("approved: []\n"), 0644)
+
+	p := newDemoPrompt(t)
+	handleDestroy(p, "--dry-run "+project)
+	if p.destroyConfirmActive {
+		t.Error("Expected dry run not to ask for confirmation")
+	}
+	if !strings.Contains(p.StatusBar, "1 files") || !strings.Contains(p.StatusBar, tddPro) {
+		t.Errorf("Expected dry run summary, got %q", p.StatusBar)
+	}
+	if _, err := os.Stat(tddPro); err != nil {
+		t.Errorf("Expected .tdd-pro to survive a dry run: %v", err)
+	}
+
+	handleDestroy(p, project)
+	if !p.destroyConfirmActive || p.destroyFiles != 1 {
+		t.Errorf("Expected confirmation listing 1 file, got active=%v files=%d", p.destroyConfirmActive, p.destroyFiles)
+	}
+}
+
+// The directory is the whole argument but --dry-run, so paths with spaces
+// aren't cut to their last word and resolved somewhere else
+func TestHandleDestroy_KeepsSpacesInTheDirectory(t *testing.T) {
+	project := filepath.Join(t.TempDir(), "my project")
+	tddPro := filepath.Join(project, ".tdd-pro")
+	os.MkdirAll(filepath.Join(tddPro, "features"), 0755)
+	os.WriteFile(filepath.Join(tddPro, "features", "index.yml"), []byte("approved: []\n"), 0644)
+
+	p := newDemoPrompt(t)
+	for _, arg := range []string{"--dry-run " + project, project + " --dry-run"} {
+		handleDestroy(p, arg)
+		if p.destroyConfirmActive || !strings.Contains(p.StatusBar, "would remove "+tddPro) {
+			t.Errorf("/destroy %s: expected a dry run of %s, got %q", arg, tddPro, p.StatusBar)
+		}
+	}
+	handleDestroy(p, project)
+	if !p.destroyConfirmActive || p.destroyFiles != 1 {
+		t.Errorf("Expected confirmation for %s, got active=%v files=%d", project, p.destroyConfirmActive, p.destroyFiles)
+	}
+}
+
+func TestDirectoryArgs_ExpandHomeAndRelativePaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	tddPro := filepath.Join(home, "projects", "foo", ".tdd-pro")
+	os.MkdirAll(filepath.Join(tddPro, "features"), 0755)
+	os.WriteFile(filepath.Join(tddPro, "features", "index.yml"), []byte("approved: []\n"), 0644)
+	t.Chdir(filepath.Join(home, "projects", "foo"))
+
+	p := newDemoPrompt(t)
+	handleDestroy(p, "--dry-run ~/projects/foo")
+	if !strings.Contains(p.StatusBar, "would remove "+tddPro) {
+		t.Errorf("Expected ~ expanded, got %q", p.StatusBar)
+	}
+	handleDestroy(p, "--dry-run ../foo/./")
+	if !strings.Contains(p.StatusBar, "would remove "+tddPro) {
+		t.Errorf("Expected the relative path resolved, got %q", p.StatusBar)
+	}
+
+	missing := filepath.Join(home, "projects", "bar")
+	handleDestroy(p, "../bar")
+	if p.destroyConfirmActive || p.StatusBar != "Error: directory "+missing+" does not exist" {
+		t.Errorf("Expected /destroy to reject a missing directory, got %q", p.StatusBar)
+	}
+	if _, cmd := handlePlan(p, "~/projects/bar"); cmd != nil || p.StatusBar != "Error: directory "+missing+" does not exist" {
+		t.Errorf("Expected /plan to reject a missing directory, got %q", p.StatusBar)
+	}
+	if p.workflowProgress != nil {
+		t.Errorf("Expected no workflow to start for a missing directory")
+	}
+}
+
+// flakyClient fails task updates with the queued errors before passing them
+// on to the demo client
+type flakyClient struct {
+	*mcpclient.DemoClient
+	failures []error
+	calls    int
+}
+
+func (c *flakyClient) UpdateTaskViaStdio(featureId, taskId string, updates map[string]interface{}) error {
+	c.calls++
+	if len(c.failures) > 0 {
+		err := c.failures[0]
+		c.failures = c.failures[1:]
+		return err
+	}
+	return c.DemoClient.UpdateTaskViaStdio(featureId, taskId, updates)
+}
+
+func TestTaskSave_RetriesTransientAndKeepsEditsOnFailure(t *testing.T) {
+	taskSaveRetryDelay = 0
+	edit := TaskEditCompleteMsg{Title: "Hash with argon2", Description: "Switch hashes", Criteria: []string{"Old hashes still verify"}}
+	newPrompt := func(failures ...error) (*Prompt, *flakyClient) {
+		client := &flakyClient{DemoClient: mcpclient.NewDemoClient(), failures: failures}
+		p := newDemoPrompt(t)
+		p.MCP = client
+		p.focusState, p.FeaturesTab = 2, 1
+		return p, client
+	}
+
+	p, client := newPrompt(fmt.Errorf("update-task timed out: %w", context.DeadlineExceeded))
+	_, cmd := p.Update(edit)
+	p.Update(cmd())
+	if client.calls != 2 || p.toast == nil || p.toast.kind != toastSuccess {
+		t.Fatalf("Expected a timed out save to be retried once and succeed, got %d calls, toast %+v", client.calls, p.toast)
+	}
+	detail, _ := client.GetFeatureViaStdio("user-auth")
+	if detail.Tasks[0].Title != edit.Title {
+		t.Errorf("Expected the retry to save the edit, got %q", detail.Tasks[0].Title)
+	}
+
+	p, client = newPrompt(errors.New("task not found"))
+	_, cmd = p.Update(edit)
+	p.Update(cmd())
+	if client.calls != 1 {
+		t.Errorf("Expected a permanent failure not to be retried, got %d calls", client.calls)
+	}
+	if p.toast == nil || p.toast.kind != toastError || !strings.Contains(p.toast.text, "task not found") {
+		t.Errorf("Expected the error to be shown, got %+v", p.toast)
+	}
+	if !p.editingTask || p.taskEditForm == nil || p.taskEditForm.title != edit.Title || p.taskEditForm.description != edit.Description {
+		t.Fatalf("Expected the form to reopen with the edits, got %+v", p.taskEditForm)
+	}
+	if len(p.undo) != 0 {
+		t.Errorf("Expected a failed save not to be undoable, got %d entries", len(p.undo))
+	}
+}
+
+type featureListClient struct {
+	*mcpclient.DemoClient
+	data *mcpclient.FeaturesData
+	err  error
+}
+
+func (c *featureListClient) ListFeaturesViaStdio() (*mcpclient.FeaturesData, error) {
+	return c.data, c.err
+}
+
+func TestEmptyFeatures_OnboardingAndLoadErrors(t *testing.T) {
+	t.Chdir(t.TempDir())
+	client := &featureListClient{DemoClient: mcpclient.NewDemoClient(), data: &mcpclient.FeaturesData{}}
+	prompt := NewPromptWithClient(client, "http://localhost:4111", "test")
+	p := &prompt
+	p.WindowWidth, p.WindowHeight = 120, 40
+
+	openFeatures(p, "")
+	if view := p.View(); !strings.Contains(view, "No features yet.") || !strings.Contains(view, "to run /plan") {
+		t.Errorf("Expected onboarding guidance, got:\n%s", view)
+	}
+
+	// n opens the new feature form, esc closes it
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if p.featureForm == nil || !strings.Contains(p.View(), "New Feature") {
+		t.Fatal("Expected n to open the new feature form")
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.featureForm != nil {
+		t.Fatal("Expected esc to close the new feature form")
+	}
+
+	// A created feature is listed and selected
+	p.Update(featureCreatedMsg{feature: mcpclient.Feature{ID: "audit-log", Name: "Audit Log", Status: "refinement"}})
+	if p.SelectedFeature == nil || p.SelectedFeature.ID != "audit-log" || strings.Contains(p.View(), "No features yet.") {
+		t.Errorf("Expected the new feature to be selected, got %+v", p.SelectedFeature)
+	}
+
+	// A failed listing isn't mistaken for an empty project
+	client.data, client.err = nil, errors.New("server exited")
+	openFeatures(p, "")
+	view := p.View()
+	if !strings.Contains(view, "Couldn't load features: server exited") || strings.Contains(view, "No features yet.") {
+		t.Errorf("Expected a load error, got:\n%s", view)
+	}
+}
+
+// openFeatures runs /features with arg and waits for the listing
+func openFeatures(p *Prompt, arg string) {
+	_, cmd := handleFeatures(p, arg)
+	runMessageCmds(p, cmd)
+}
+
+// runMessageCmds runs cmd and the commands its messages lead to, expanding
+// batches and skipping spinner ticks, which would otherwise repeat forever
+func runMessageCmds(p *Prompt, cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			runMessageCmds(p, c)
+		}
+	case spinner.TickMsg:
+	default:
+		_, next := p.Update(msg)
+		runMessageCmds(p, next)
+	}
+}
+
+func TestTaskEditForm_RecoversFromEmptyView(t *testing.T) {
+	f := &TaskEditForm{visible: true, title: "Hash passwords", criteriaText: "bcrypt cost >= 12", theme: DarkTheme}
+
+	// A missing form is rebuilt once with the values entered so far
+	if view := f.View(); !strings.Contains(view, "Task Title") {
+		t.Fatalf("Expected the rebuilt form, got:\n%s", view)
+	}
+	if f.form == nil || !f.rebuilt || f.criteriaText != "bcrypt cost >= 12" {
+		t.Fatalf("Expected the form rebuilt with its criteria, rebuilt=%v", f.rebuilt)
+	}
+
+	// A form that renders empty (huh stops drawing once aborted) gets a clean error after the one rebuild
+	f.form.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	view := f.View()
+	if !strings.Contains(view, "couldn't be displayed") || !strings.Contains(view, "Press Esc") {
+		t.Errorf("Expected an error with an escape hint, got:\n%s", view)
+	}
+	if strings.Contains(view, "DEBUG") || strings.Contains(view, "huh") {
+		t.Errorf("Error view leaked internal diagnostics:\n%s", view)
+	}
+
+	// Esc still cancels when there's no form to pass keys to
+	f.form = nil
+	_, cmd := f.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil {
+		t.Fatal("Expected esc to cancel the broken form")
+	}
+	if _, ok := cmd().(TaskEditCancelMsg); !ok || f.visible {
+		t.Errorf("Expected a cancel message and the form hidden, visible=%v", f.visible)
+	}
+}
+
+func TestParseCommand_SplitsOnWhitespace(t *testing.T) {
+	tests := []struct {
+		input, cmd, arg string
+	}{
+		{"/plan /foo", "/plan", "/foo"},
+		{"/plan\t/foo", "/plan", "/foo"},
+		{"/plan   /foo", "/plan", "/foo"},
+		{"/plan \t /foo  ", "/plan", "/foo"},
+		{"/export user-auth ./spec.md", "/export", "user-auth ./spec.md"},
+		{"/help", "/help", ""},
+		{"/help   ", "/help", ""},
+		{"  /clear\tsession", "/clear", "session"},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		cmd, arg := parseCommand(tt.input)
+		if cmd != tt.cmd || arg != tt.arg {
+			t.Errorf("parseCommand(%q) = (%q, %q), want (%q, %q)", tt.input, cmd, arg, tt.cmd, tt.arg)
+		}
+	}
+}
+
+func TestSidebarGroups_ShowFeatureCounts(t *testing.T) {
+	p := newDemoPrompt(t)
+	// Only current features that resolve to a listed feature are counted
+	p.FeaturesData.CurrentFeatures = []string{"user-auth", "no-such-feature"}
+
+	sidebar := p.generateSidebarContent()
+	for _, header := range []string{"Current (1):", "Accepted (1):", "Refining (1):", "Backlog (1):"} {
+		if !strings.Contains(sidebar, header) {
+			t.Errorf("Expected group header %q in the sidebar, got:\n%s", header, sidebar)
+		}
+	}
+
+	p.toggleGroupCollapse("backlog")
+	if sidebar := p.generateSidebarContent(); strings.Contains(sidebar, "Backlog (1)") || !strings.Contains(sidebar, "▸ 1 feature") {
+		t.Errorf("Expected a collapsed group to show its count only in the summary, got:\n%s", sidebar)
+	}
+}
+
+func TestFeaturesView_EscBacksOutOneLevel(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.WindowWidth, p.WindowHeight = 120, 40
+	key := func(s string) {
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+	}
+	esc := func() {
+		p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	}
+
+	key("v")
+	key("t")
+	key("!")
+	esc()
+	if p.taskFilter.active() || p.focusState != 2 {
+		t.Fatal("Expected esc to clear the task filter first")
+	}
+	esc()
+	if !p.FeaturesViewActive || p.focusState != 0 || len(p.marked) == 0 {
+		t.Fatal("Expected esc to return from the Tasks panel to the features list")
+	}
+	esc()
+	if !p.FeaturesViewActive || len(p.marked) != 0 {
+		t.Fatal("Expected esc to clear the marked features next")
+	}
+	esc()
+	if p.FeaturesViewActive {
+		t.Error("Expected esc to close the features view last")
+	}
+
+	p.FeaturesViewActive = true
+	key("d")
+	esc()
+	if !p.FeaturesViewActive || p.focusState != 0 {
+		t.Error("Expected esc to return from the Feature Data panel to the features list")
+	}
+}
@@ -0,0 +1,76 @@
+package components
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// unsavedEdits names the edits quitting would lose: an open task form, the
+// inline PRD editor, or the selected feature's name, description or status
+func (p *Prompt) unsavedEdits() []string {
+	var edits []string
+	if p.editingTask && p.taskEditForm != nil && p.taskEditForm.IsVisible() && p.taskEditForm.dirty() {
+		edits = append(edits, "task")
+	}
+	if p.editingPRD && p.prdEditTextarea.Value() != p.prdOriginal {
+		edits = append(edits, "PRD")
+	}
+	if feature := p.SelectedFeature; feature != nil && !p.readOnly {
+		fieldsChanged := p.statusEditID == feature.ID && p.statusEdit != feature.Status
+		// The inputs only follow the selection when Feature Data renders, so
+		// they only count once typed into for this feature
+		if p.fieldsEditID == feature.ID {
+			if strings.TrimSpace(p.featureNameEdit.Value()) != feature.Name ||
+				strings.TrimSpace(p.featureDescriptionEdit.Value()) != feature.Description {
+				fieldsChanged = true
+			}
+		}
+		if fieldsChanged {
+			edits = append(edits, "feature")
+		}
+	}
+	return edits
+}
+
+// quit exits at once unless there are unsaved edits, in which case it asks first
+func (p *Prompt) quit() tea.Cmd {
+	if len(p.unsavedEdits()) == 0 {
+		return tea.Quit
+	}
+	p.quitConfirmActive = true
+	return nil
+}
+
+// updateQuitConfirm handles keys while the quit confirmation is open: y
+// quits, n or esc goes back to editing
+func (p *Prompt) updateQuitConfirm(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "y", "Y", "ctrl+c":
+		return tea.Quit
+	case "n", "N", "esc":
+		p.quitConfirmActive = false
+		p.StatusBar = "Quit cancelled"
+	}
+	return nil
+}
+
+// quitConfirmView renders the quit confirmation dialog
+func (p *Prompt) quitConfirmView() string {
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(p.theme.Warning)).
+		Padding(1, 2).
+		Width(60).
+		Align(lipgloss.Center)
+
+	edits := p.unsavedEdits()
+	slices.Sort(edits)
+	content := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Text)).Bold(true).Render("You have unsaved changes. Quit anyway?") + "\n\n" +
+		lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render("Unsaved: "+strings.Join(edits, ", ")) + "\n\n" +
+		lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Success)).Bold(true).Render("[Y]es") + " / " +
+		lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Error)).Bold(true).Render("[N]o")
+	return dialogStyle.Render(content)
+}
@@ -0,0 +1,92 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestQuit_ConfirmsOnlyWithUnsavedEdits(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.WindowWidth, p.WindowHeight = 120, 40
+	isQuit := func(cmd tea.Cmd) bool {
+		if cmd == nil {
+			return false
+		}
+		_, ok := cmd().(tea.QuitMsg)
+		return ok
+	}
+	key := func(s string) tea.Cmd {
+		_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+		return cmd
+	}
+
+	if _, cmd := handleQuit(p, ""); !isQuit(cmd) || p.quitConfirmActive {
+		t.Fatal("Expected /quit without edits to quit at once")
+	}
+
+	p.focusState = 1
+	p.startInlinePRDEdit("# PRD")
+	if _, cmd := p.Update(tea.KeyMsg{Type: tea.KeyCtrlC}); !isQuit(cmd) {
+		t.Error("Expected ctrl+c in an unchanged PRD to quit at once")
+	}
+	key("x")
+	if _, cmd := p.Update(tea.KeyMsg{Type: tea.KeyCtrlC}); cmd != nil || !p.quitConfirmActive {
+		t.Fatal("Expected ctrl+c with an edited PRD to ask first")
+	}
+	if view := p.View(); !strings.Contains(view, "You have unsaved changes. Quit anyway?") || !strings.Contains(view, "Unsaved: PRD") {
+		t.Errorf("Expected the confirmation dialog, got %q", view)
+	}
+	if cmd := key("x"); cmd != nil || !p.quitConfirmActive {
+		t.Error("Expected other keys to leave the dialog open")
+	}
+	key("n")
+	if p.quitConfirmActive || !p.editingPRD || p.prdEditTextarea.Value() != "# PRDx" {
+		t.Errorf("Expected n to return to the edit unchanged, got %q", p.prdEditTextarea.Value())
+	}
+	if _, cmd := handleQuit(p, ""); cmd != nil || !p.quitConfirmActive {
+		t.Fatal("Expected /quit with an edited PRD to ask first")
+	}
+	if !isQuit(key("y")) {
+		t.Error("Expected y to quit")
+	}
+
+	// An open task form counts once something is typed in it
+	p = newDemoPrompt(t)
+	p.focusState, p.FeaturesTab = 2, 1
+	p.startTaskEdit()
+	if !isQuit(p.quit()) {
+		t.Error("Expected an untouched task form to quit at once")
+	}
+	key("!")
+	if p.quit(); !p.quitConfirmActive || !strings.Contains(p.View(), "Unsaved: task") {
+		t.Error("Expected an edited task form to ask first")
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.quitConfirmActive || !p.editingTask {
+		t.Error("Expected esc to keep the task form open")
+	}
+}
+
+func TestUnsavedEdits_FeatureFieldsOnlyOnceTyped(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.WindowWidth, p.WindowHeight = 120, 40
+
+	// Moving to another feature on the Tasks tab leaves the inputs synced to the first
+	p.focusState, p.FeaturesTab = 1, 0
+	p.View()
+	p.FeaturesTab = 1
+	p.SelectedFeature = &p.FeaturesData.Planned[0]
+	if edits := p.unsavedEdits(); len(edits) != 0 {
+		t.Errorf("Expected no unsaved edits after moving to another feature, got %v", edits)
+	}
+
+	p.FeaturesTab = 0
+	p.View()
+	p.featureNameEdit.Focus()
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	if edits := p.unsavedEdits(); len(edits) != 1 || edits[0] != "feature" {
+		t.Errorf("Expected the typed name to count as unsaved, got %v", edits)
+	}
+}
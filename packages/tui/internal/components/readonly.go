@@ -0,0 +1,22 @@
+package components
+
+// readOnlyStatus is shown when an editing key or command is used in read-only mode
+const readOnlyStatus = "Read-only mode: editing is disabled"
+
+// SetReadOnly disables every edit (tasks, PRDs, feature fields, status,
+// dependency links, undo, /init, /mcp and /destroy) while leaving browsing,
+// search and scrolling untouched. Set with --read-only or read_only in config.yml.
+func (p *Prompt) SetReadOnly(readOnly bool) {
+	p.readOnly = readOnly
+	p.help.ReadOnly = readOnly
+}
+
+// blockedByReadOnly reports whether an edit must be refused, explaining why in
+// the status bar
+func (p *Prompt) blockedByReadOnly() bool {
+	if !p.readOnly {
+		return false
+	}
+	p.StatusBar = readOnlyStatus
+	return true
+}
@@ -0,0 +1,62 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestReadOnly_RefusesEditsButBrowses(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.SetReadOnly(true)
+	p.WindowWidth, p.WindowHeight = 120, 40
+
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if p.SelectedFeature.ID == "user-auth" {
+		t.Error("Expected feature navigation to keep working")
+	}
+	p.SelectedFeature = &p.FeaturesData.Approved[0]
+
+	p.focusState, p.FeaturesTab = 1, 0
+	p.View()
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if got := p.featureNameEdit.Value(); got != "User Authentication" {
+		t.Errorf("Expected the name to ignore typing, got %q", got)
+	}
+	for _, key := range []tea.KeyMsg{{Type: tea.KeyCtrlT}, {Type: tea.KeyCtrlK}, {Type: tea.KeyEnter}, {Type: tea.KeyRunes, Runes: []rune("e")}} {
+		p.StatusBar = ""
+		if _, cmd := p.Update(key); cmd != nil {
+			t.Errorf("Expected %s to do nothing, got a command", key)
+		}
+		if p.StatusBar != readOnlyStatus {
+			t.Errorf("Expected %s to report read-only mode, got %q", key, p.StatusBar)
+		}
+	}
+	if p.pendingFeatureStatus() != "approved" || p.deps != nil || p.editingPRD {
+		t.Error("Expected no edit state to be entered")
+	}
+
+	p.focusState, p.FeaturesTab = 2, 1
+	for _, key := range []string{"e", "a"} {
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		if p.editingTask {
+			t.Fatalf("Expected %s not to open the task form", key)
+		}
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if p.selectedTaskIndex != 1 {
+		t.Errorf("Expected task selection to keep working, got %d", p.selectedTaskIndex)
+	}
+
+	p.help.Open(KeyMap)
+	if view := p.help.View(100, 200, p.theme); !strings.Contains(view, "Edit selected task (read-only)") {
+		t.Error("Expected edit shortcuts to be marked read-only in the help overlay")
+	}
+
+	// /plan writes the features it generates
+	p.StatusBar = ""
+	if _, cmd := handlePlan(p, ""); cmd != nil || p.workflowProgress != nil || p.StatusBar != readOnlyStatus {
+		t.Errorf("Expected /plan refused in read-only mode, got %q", p.StatusBar)
+	}
+}
@@ -0,0 +1,39 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"tddpro/internal/mcpclient"
+)
+
+// formatRelativeTime describes how long before now t was, e.g. "45s ago",
+// "3h ago" or "12d ago". Times in the future (clock skew) are "just now".
+func formatRelativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Second:
+		return "just now"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", int(d/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d/time.Hour))
+	}
+	return fmt.Sprintf("%dd ago", int(d/(24*time.Hour)))
+}
+
+// featureTimesView renders "created 3d ago · updated 2h ago" for a feature,
+// leaving out times the server didn't provide; "" when it provided neither
+func featureTimesView(feature *mcpclient.Feature, now time.Time) string {
+	var parts []string
+	if !feature.CreatedAt.IsZero() {
+		parts = append(parts, "created "+formatRelativeTime(feature.CreatedAt.Time, now))
+	}
+	if !feature.UpdatedAt.IsZero() {
+		parts = append(parts, "updated "+formatRelativeTime(feature.UpdatedAt.Time, now))
+	}
+	return strings.Join(parts, " · ")
+}
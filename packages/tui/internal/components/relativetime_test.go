@@ -0,0 +1,38 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatRelativeTime(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[time.Duration]string{
+		-time.Minute:              "just now",
+		0:                         "just now",
+		45 * time.Second:          "45s ago",
+		90 * time.Second:          "1m ago",
+		3*time.Hour + time.Minute: "3h ago",
+		50 * time.Hour:            "2d ago",
+	}
+	for d, want := range tests {
+		if got := formatRelativeTime(now.Add(-d), now); got != want {
+			t.Errorf("formatRelativeTime(-%s) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestFeatureDataContent_Timestamps(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.WindowWidth, p.WindowHeight = 120, 40
+	if view := p.generateFeatureDataContent(p.SelectedFeature); !strings.Contains(view, "created 12d ago · updated 3h ago") {
+		t.Errorf("Expected relative timestamps, got %q", view)
+	}
+
+	// Features without timestamps get no line at all
+	feature := p.FeaturesData.Backlog[0]
+	if view := p.generateFeatureDataContent(&feature); strings.Contains(view, "created") || strings.Contains(view, "0001") {
+		t.Errorf("Expected no timestamp line, got %q", view)
+	}
+}
@@ -0,0 +1,56 @@
+package components
+
+import (
+	"sync"
+
+	"tddpro/internal/streams"
+)
+
+// workflowRuns tracks running workflows so they can be cancelled on quit
+type workflowRuns struct {
+	mu   sync.Mutex
+	runs map[*streams.WorkflowRun]struct{}
+}
+
+func newWorkflowRuns() *workflowRuns {
+	return &workflowRuns{runs: map[*streams.WorkflowRun]struct{}{}}
+}
+
+func (w *workflowRuns) add(wr *streams.WorkflowRun) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.runs[wr] = struct{}{}
+}
+
+func (w *workflowRuns) remove(wr *streams.WorkflowRun) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.runs, wr)
+}
+
+// cancelAll stops every tracked run's requests
+func (w *workflowRuns) cancelAll() {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for wr := range w.runs {
+		wr.Cancel()
+	}
+}
+
+// Shutdown cancels running workflows and releases the backend client (SSE
+// connection and MCP stdio servers). Call it once the program has exited.
+func (p *Prompt) Shutdown() {
+	p.workflows.cancelAll()
+	if p.MCP != nil {
+		p.MCP.Shutdown()
+	}
+}
@@ -0,0 +1,124 @@
+package components
+
+import (
+	"tddpro/internal/mcpclient"
+	"tddpro/internal/util"
+)
+
+// featureStatusRank returns the position in util.FeatureStatuses of the group
+// holding featureID, or -1 when it isn't listed
+func (p *Prompt) featureStatusRank(featureID string) int {
+	groups := [][]mcpclient.Feature{p.FeaturesData.Approved, p.FeaturesData.Planned, p.FeaturesData.Refinement, p.FeaturesData.Backlog}
+	for rank, features := range groups {
+		for _, f := range features {
+			if f.ID == featureID {
+				return rank
+			}
+		}
+	}
+	return -1
+}
+
+// statusRank returns the position of status in util.FeatureStatuses
+func statusRank(status string) int {
+	for rank, s := range util.FeatureStatuses {
+		if s == status {
+			return rank
+		}
+	}
+	return -1
+}
+
+// jumpFeatureGroup selects the first feature of the next (delta 1) or
+// previous (delta -1) non-empty sidebar group, wrapping around
+func (p *Prompt) jumpFeatureGroup(delta int) {
+	var groups []featureGroup
+	for _, group := range p.featureGroups() {
+		if len(group.features) > 0 && !p.collapsedGroups[group.status] {
+			groups = append(groups, group)
+		}
+	}
+	if len(groups) == 0 {
+		return
+	}
+
+	current := -1
+	if p.SelectedFeature != nil {
+		current = p.featureStatusRank(p.SelectedFeature.ID)
+	}
+	target := groups[0]
+	if delta < 0 {
+		target = groups[len(groups)-1]
+	}
+	if current >= 0 {
+		// The selection may sit in a group the sidebar hides (planned), so
+		// compare positions rather than looking the group up
+		if delta > 0 {
+			for _, group := range groups {
+				if statusRank(group.status) > current {
+					target = group
+					break
+				}
+			}
+		} else {
+			for i := len(groups) - 1; i >= 0; i-- {
+				if statusRank(groups[i].status) < current {
+					target = groups[i]
+					break
+				}
+			}
+		}
+	}
+
+	p.SelectedFeature = &target.features[0]
+	p.ensureFeatureVisible()
+}
+
+// ensureFeatureVisible scrolls the sidebar so the selected feature, and the
+// header of its group when it fits, are in view. Positions mirror the layout
+// of generateSidebarContent: a header, one line per feature and a blank line
+// per group.
+func (p *Prompt) ensureFeatureVisible() {
+	if p.SelectedFeature == nil {
+		return
+	}
+	sidebarContentHeight := p.WindowHeight - 8 // Account for header, borders, prompt, status
+	if sidebarContentHeight < 1 {
+		sidebarContentHeight = 1
+	}
+
+	line := 0
+	if p.featureStatusFilter == "" {
+		current := 0
+		for _, id := range p.FeaturesData.CurrentFeatures {
+			if _, ok := p.FeaturesData.FindFeature(id); ok {
+				current++
+			}
+		}
+		line += current + 2
+	}
+	header, selected := -1, -1
+	for _, group := range p.featureGroups() {
+		if p.collapsedGroups[group.status] {
+			line += 2 // summary line and blank line
+			continue
+		}
+		for i, f := range group.features {
+			if f.ID == p.SelectedFeature.ID {
+				header, selected = line, line+1+i
+			}
+		}
+		line += len(group.features) + 2
+	}
+	if selected < 0 {
+		return
+	}
+
+	if header < p.sidebarScroll {
+		p.sidebarScroll = header
+	}
+	if selected >= p.sidebarScroll+sidebarContentHeight {
+		p.sidebarScroll = selected - sidebarContentHeight + 1
+	}
+	p.sidebarScroll = min(p.sidebarScroll, p.getMaxSidebarScroll())
+}
@@ -0,0 +1,36 @@
+package components
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestJumpFeatureGroup(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.WindowWidth, p.WindowHeight = 80, 12 // room for 4 sidebar lines
+
+	for _, want := range []string{"notifications", "dark-mode", "user-auth"} {
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("]")})
+		if p.SelectedFeature.ID != want {
+			t.Fatalf("Expected ] to select %s, got %s", want, p.SelectedFeature.ID)
+		}
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	if p.SelectedFeature.ID != "dark-mode" {
+		t.Fatalf("Expected pgup to wrap to dark-mode, got %s", p.SelectedFeature.ID)
+	}
+	if p.sidebarScroll == 0 || p.sidebarScroll > 10 || p.sidebarScroll+4 <= 10 {
+		t.Errorf("Expected the sidebar to scroll dark-mode (line 10) into view, got offset %d", p.sidebarScroll)
+	}
+
+	// Planned is hidden from the unfiltered sidebar but still ordered between the groups
+	p.SelectedFeature = &p.FeaturesData.Planned[0]
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("[")})
+	if p.SelectedFeature.ID != "user-auth" {
+		t.Errorf("Expected [ from planned to select user-auth, got %s", p.SelectedFeature.ID)
+	}
+	if p.sidebarScroll > 3 {
+		t.Errorf("Expected the sidebar to scroll back to the Accepted header, got offset %d", p.sidebarScroll)
+	}
+}
@@ -0,0 +1,51 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SetStatusLines caps how many lines the status bar shows (status_lines in
+// config.yml). 0 lets it grow to half the window.
+func (p *Prompt) SetStatusLines(n int) {
+	p.statusMaxLines = n
+}
+
+// statusWidth is the width of the status bar: the window, or 60 columns
+// before the first resize
+func (p *Prompt) statusWidth() int {
+	if p.WindowWidth <= 0 {
+		return 60
+	}
+	return p.WindowWidth
+}
+
+// statusLines wraps text to width and caps it at the status line limit, the
+// last line counting what was left out. Multi-line messages such as /tool
+// output keep their line breaks.
+func (p *Prompt) statusLines(text string, width int) []string {
+	if width < 10 {
+		width = 10
+	}
+	lines := strings.Split(lipgloss.NewStyle().Width(width).Render(text), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+
+	limit := p.statusMaxLines
+	if half := p.WindowHeight / 2; p.WindowHeight > 0 && (limit <= 0 || limit > half) {
+		limit = half
+	}
+	if limit < 1 || len(lines) <= limit {
+		return lines
+	}
+	more := len(lines) - limit + 1
+	return append(lines[:limit-1], fmt.Sprintf("… %d more lines", more))
+}
+
+// statusHeight is how many lines the current status message takes
+func (p *Prompt) statusHeight() int {
+	return len(p.statusLines(p.StatusBar, p.statusWidth()-2))
+}
@@ -0,0 +1,41 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"tddpro/internal/mcpclient"
+)
+
+func TestStatusBar_WrapsToWindowAndCapsLines(t *testing.T) {
+	t.Chdir(t.TempDir())
+	prompt := NewPromptWithClient(mcpclient.NewDemoClient(), "http://localhost:4111", "test")
+	p := &prompt
+	p.WindowWidth, p.WindowHeight = 80, 30
+
+	p.StatusBar = strings.Repeat("connection refused while starting the MCP server ", 5)
+	lines := p.statusLines(p.StatusBar, p.statusWidth()-2)
+	if len(lines) < 2 {
+		t.Fatalf("Expected a long error to wrap, got %q", lines)
+	}
+	for _, line := range lines {
+		if lipgloss.Width(line) > 78 {
+			t.Errorf("Expected lines to fit the window, got %d columns: %q", lipgloss.Width(line), line)
+		}
+	}
+	if view := p.View(); !strings.Contains(view, lines[len(lines)-1]) {
+		t.Errorf("Expected the whole wrapped status in the view:\n%s", view)
+	}
+
+	p.SetStatusLines(3)
+	p.StatusBar = "get-feature:\n1\n2\n3\n4\n5\n6\n7\n8"
+	lines = p.statusLines(p.StatusBar, p.statusWidth()-2)
+	if len(lines) != 3 || lines[2] != "… 7 more lines" {
+		t.Errorf("Expected the status capped at 3 lines, got %q", lines)
+	}
+	if got := strings.Count(p.View(), "\n") + 1; got > p.WindowHeight {
+		t.Errorf("Expected the layout to fit the window with a multi-line status, got %d lines", got)
+	}
+}
@@ -0,0 +1,93 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"tddpro/internal/mcpclient"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// toggleCompactTasks switches the Tasks panel between full task boxes and
+// one line per task
+func (p *Prompt) toggleCompactTasks() {
+	p.compactTasks = !p.compactTasks
+	if p.compactTasks {
+		p.StatusBar = "Compact tasks (z for full boxes)"
+	} else {
+		p.StatusBar = "Full task boxes (z for compact)"
+	}
+	p.mainPanelScroll = 0
+	p.ensureTaskVisible()
+}
+
+// compactTaskLines is how many lines the selected task takes in compact mode:
+// its line and its expanded criteria
+func (p *Prompt) compactTaskLines() int {
+	featureDetail, err := p.tasksFor(p.SelectedFeature.ID)
+	if err != nil || p.selectedTaskIndex >= len(featureDetail.Tasks) {
+		return 1
+	}
+	return 1 + len(featureDetail.Tasks[p.selectedTaskIndex].EvaluationCriteria)
+}
+
+// taskBadge summarizes a task for compact mode: its completion and how many
+// of its criteria pass, e.g. "◐ 1/2 tests", or that it has no criteria
+func (p *Prompt) taskBadge(task mcpclient.Task) string {
+	glyph, color := "○", p.theme.Muted
+	switch {
+	case task.Completed():
+		glyph, color = "✓", p.theme.Success
+	case task.Status == "in-progress":
+		glyph, color = "◐", p.theme.Warning
+	}
+	badge := lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(glyph)
+	if total := len(task.EvaluationCriteria); total > 0 {
+		passing := 0
+		for i := range task.EvaluationCriteria {
+			if task.CriterionResult(i) == mcpclient.CriterionPass {
+				passing++
+			}
+		}
+		badge += lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render(fmt.Sprintf(" %d/%d tests", passing, total))
+	} else {
+		badge += " " + p.missingCriteriaBadge()
+	}
+	if estimate := p.estimateBadge(task); estimate != "" {
+		badge += " " + estimate
+	}
+	return badge
+}
+
+// renderTaskLine renders a task on one line for compact mode. The selected
+// task is highlighted and shows its acceptance criteria below.
+func (p *Prompt) renderTaskLine(task mcpclient.Task, taskNumber int, isSelected bool) string {
+	width := p.taskContentWidth()
+	badge := p.taskBadge(task)
+	title := fmt.Sprintf("%d. %s", taskNumber, task.Title)
+	// Leave room for the badge and a gap
+	if runes, room := []rune(title), width-lipgloss.Width(badge)-4; len(runes) > room && room > 1 {
+		title = string(runes[:room-1]) + "…"
+	}
+
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Value))
+	marker := "  "
+	if isSelected {
+		titleStyle = titleStyle.Foreground(lipgloss.Color(p.theme.Focus)).Bold(true)
+		marker = lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Render("▸ ")
+	}
+	line := marker + titleStyle.Render(title)
+	gap := width - lipgloss.Width(line) - lipgloss.Width(badge)
+	if gap < 1 {
+		gap = 1
+	}
+	line += strings.Repeat(" ", gap) + badge + "\n"
+
+	if isSelected {
+		for i := range task.EvaluationCriteria {
+			line += p.criterionLine(task, i) + "\n"
+		}
+	}
+	return line
+}
@@ -0,0 +1,40 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCompactTasks_OneLineEachWithSelectedCriteria(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.WindowWidth, p.WindowHeight = 120, 40
+	p.focusState = 2
+	p.selectedTaskIndex = 0
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	if !p.compactTasks {
+		t.Fatal("Expected z to switch to compact tasks")
+	}
+	view := p.renderTasksForFeature(p.SelectedFeature)
+	for _, want := range []string{"▸ 1. Hash passwords with bcrypt", "✓ 2/2 tests", "2. Issue session tokens on sign-in", "Test 1: Plaintext passwords"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in compact tasks:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Tokens expire") || strings.Contains(view, "Store only bcrypt") {
+		t.Errorf("Expected only the selected task's criteria and no descriptions:\n%s", view)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	view = p.renderTasksForFeature(p.SelectedFeature)
+	if !strings.Contains(view, "▸ 2. Issue session tokens") || !strings.Contains(view, "Tokens expire") || strings.Contains(view, "Plaintext passwords") {
+		t.Errorf("Expected the criteria to follow the selection:\n%s", view)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	if view := p.renderTasksForFeature(p.SelectedFeature); p.compactTasks || !strings.Contains(view, "Task 1: Hash passwords") {
+		t.Errorf("Expected z to restore the full task boxes")
+	}
+}
@@ -0,0 +1,202 @@
+package components
+
+import (
+	"sort"
+	"strings"
+
+	"tddpro/internal/mcpclient"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+// Task status filters, cycled with f in the Tasks panel
+const (
+	taskFilterAll        = ""
+	taskFilterIncomplete = "incomplete"
+	taskFilterComplete   = "complete"
+)
+
+// taskFilter narrows the Tasks panel by a fuzzy search over titles and
+// descriptions (/), by completion (f) and to tasks without acceptance
+// criteria (!). Tasks keep their order and numbers.
+type taskFilter struct {
+	status     string
+	noCriteria bool // only tasks missing acceptance criteria
+	input      textinput.Model
+	searching  bool // the search input has the keyboard
+}
+
+// query returns the search text, "" when there's none
+func (f *taskFilter) query() string {
+	return strings.TrimSpace(f.input.Value())
+}
+
+// active reports whether any filter hides tasks
+func (f *taskFilter) active() bool {
+	return f.status != taskFilterAll || f.noCriteria || f.query() != ""
+}
+
+// label describes the active filter for the Tasks tab title, "" for none
+func (f *taskFilter) label() string {
+	var parts []string
+	if f.status != taskFilterAll {
+		parts = append(parts, f.status)
+	}
+	if f.noCriteria {
+		parts = append(parts, "no criteria")
+	}
+	if q := f.query(); q != "" {
+		parts = append(parts, "/"+q)
+	}
+	return strings.Join(parts, " ")
+}
+
+// taskSearchSource adapts tasks for fuzzy matching
+type taskSearchSource []mcpclient.Task
+
+func (s taskSearchSource) String(i int) string { return s[i].Title + " " + s[i].Description }
+func (s taskSearchSource) Len() int            { return len(s) }
+
+// visibleTasks returns the indices of the tasks the filter lets through, in
+// task order
+func (f *taskFilter) visibleTasks(tasks []mcpclient.Task) []int {
+	var indices []int
+	if q := f.query(); q != "" {
+		for _, match := range fuzzy.FindFrom(q, taskSearchSource(tasks)) {
+			indices = append(indices, match.Index)
+		}
+		sort.Ints(indices)
+	} else {
+		for i := range tasks {
+			indices = append(indices, i)
+		}
+	}
+
+	if f.status == taskFilterAll && !f.noCriteria {
+		return indices
+	}
+	var filtered []int
+	for _, i := range indices {
+		if f.status != taskFilterAll && tasks[i].Completed() != (f.status == taskFilterComplete) {
+			continue
+		}
+		if f.noCriteria && !missingCriteria(tasks[i]) {
+			continue
+		}
+		filtered = append(filtered, i)
+	}
+	return filtered
+}
+
+// visibleTaskIndices returns the filtered tasks of the selected feature
+func (p *Prompt) visibleTaskIndices() []int {
+	if p.SelectedFeature == nil || p.MCP == nil {
+		return nil
+	}
+	featureDetail, err := p.tasksFor(p.SelectedFeature.ID)
+	if err != nil {
+		return nil
+	}
+	return p.taskFilter.visibleTasks(featureDetail.Tasks)
+}
+
+// snapTaskSelection moves the selection to the first visible task when the
+// filter hides the selected one
+func (p *Prompt) snapTaskSelection() {
+	visible := p.visibleTaskIndices()
+	for _, i := range visible {
+		if i == p.selectedTaskIndex {
+			return
+		}
+	}
+	if len(visible) > 0 {
+		p.selectedTaskIndex = visible[0]
+	}
+	p.mainPanelScroll = 0
+}
+
+// handleTaskFilterKey handles /, f and ! in the Tasks panel, every key while
+// the search input is open, and esc while a filter is active. Returns
+// whether the key was consumed.
+func (p *Prompt) handleTaskFilterKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	f := &p.taskFilter
+	if f.searching {
+		switch msg.String() {
+		case "enter":
+			f.searching = false
+			f.input.Blur()
+			p.StatusBar = ""
+		case "esc":
+			f.searching = false
+			f.input.Blur()
+			f.input.SetValue("")
+			p.snapTaskSelection()
+			p.StatusBar = ""
+		default:
+			var cmd tea.Cmd
+			f.input, cmd = f.input.Update(msg)
+			p.snapTaskSelection()
+			return cmd, true
+		}
+		return nil, true
+	}
+
+	switch msg.String() {
+	case "/":
+		if f.input.Placeholder == "" {
+			f.input = textinput.New()
+			f.input.Placeholder = "search tasks"
+			f.input.Prompt = "/ "
+			f.input.CharLimit = 100
+		}
+		f.searching = true
+		p.StatusBar = "Search tasks: enter to keep, esc to clear"
+		return f.input.Focus(), true
+	case "f":
+		switch f.status {
+		case taskFilterAll:
+			f.status = taskFilterIncomplete
+		case taskFilterIncomplete:
+			f.status = taskFilterComplete
+		default:
+			f.status = taskFilterAll
+		}
+		p.snapTaskSelection()
+		return nil, true
+	case "!":
+		f.noCriteria = !f.noCriteria
+		if f.noCriteria {
+			p.StatusBar = "Showing tasks without acceptance criteria (! to show all)"
+		} else {
+			p.StatusBar = ""
+		}
+		p.snapTaskSelection()
+		return nil, true
+	case "esc":
+		if !f.active() {
+			return nil, false
+		}
+		f.status = taskFilterAll
+		f.noCriteria = false
+		f.input.SetValue("")
+		p.snapTaskSelection()
+		p.StatusBar = "Task filter cleared"
+		return nil, true
+	}
+	return nil, false
+}
+
+// taskFilterView renders the search line above the tasks, "" when there's
+// no search
+func (p *Prompt) taskFilterView() string {
+	if !p.taskFilter.searching && p.taskFilter.query() == "" {
+		return ""
+	}
+	if p.taskFilter.searching {
+		return p.taskFilter.input.View() + "\n\n"
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render("/ "+p.taskFilter.query()+"  (esc to clear)") + "\n\n"
+}
@@ -0,0 +1,50 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTaskFilter_SearchAndStatus(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.focusState, p.FeaturesTab = 2, 1
+	p.WindowWidth, p.WindowHeight = 120, 40
+	key := func(s string) {
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+	}
+
+	key("f")
+	if got := p.visibleTaskIndices(); len(got) != 2 || p.selectedTaskIndex != 1 {
+		t.Fatalf("Expected the incomplete filter to hide task 1 and move the selection, got %v selected %d", got, p.selectedTaskIndex)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if p.selectedTaskIndex != 1 {
+		t.Errorf("Expected selection to wrap within the filtered tasks, got %d", p.selectedTaskIndex)
+	}
+
+	key("f")
+	key("f")
+	key("/")
+	for _, r := range "sign" {
+		key(string(r))
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := p.visibleTaskIndices(); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Fatalf("Expected the search to keep tasks 2 and 3 in order, got %v", got)
+	}
+	view := p.renderTasksForFeature(p.SelectedFeature)
+	if strings.Contains(view, "bcrypt") || !strings.Contains(view, "Add sign-out endpoint") {
+		t.Errorf("Expected only matching tasks to render, got %q", view)
+	}
+	if p.View(); !strings.Contains(p.View(), "Tasks (t) · /sign") {
+		t.Error("Expected the active filter in the Tasks tab title")
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.taskFilter.active() || len(p.visibleTaskIndices()) != 3 || !p.FeaturesViewActive {
+		t.Errorf("Expected esc to clear the filter and keep the features view open")
+	}
+}
@@ -0,0 +1,46 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// taskProgress returns how many of a feature's tasks are completed, from
+// cached details only so rendering never starts an MCP server. ok is false
+// until the feature's details have been fetched (see prefetchFeatureDetails).
+func (p *Prompt) taskProgress(featureID string) (done, total int, ok bool) {
+	if p.featureDetails == nil {
+		return 0, 0, false
+	}
+	detail, ok := p.featureDetails.peek(featureID)
+	if !ok {
+		return 0, 0, false
+	}
+	for _, task := range detail.Tasks {
+		if task.Completed() {
+			done++
+		}
+	}
+	return done, len(detail.Tasks), true
+}
+
+// taskProgressView renders a feature's "done/total" task summary for the
+// sidebar, colored by how far along it is. Features without tasks, or not
+// cached yet, get none.
+func (p *Prompt) taskProgressView(featureID string) string {
+	done, total, ok := p.taskProgress(featureID)
+	if !ok || total == 0 {
+		return ""
+	}
+	color := p.theme.Muted
+	switch {
+	case done == total:
+		color = p.theme.Success
+	case done*2 >= total:
+		color = p.theme.Focus
+	case done > 0:
+		color = p.theme.Warning
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(fmt.Sprintf("%d/%d done", done, total))
+}
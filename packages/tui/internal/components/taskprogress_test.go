@@ -0,0 +1,25 @@
+package components
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSidebarTaskProgress(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.featureDetails.invalidate("")
+
+	// Nothing is fetched while rendering, so there's no summary before the prefetch
+	if sidebar := p.generateSidebarContent(); strings.Contains(sidebar, " done") {
+		t.Errorf("Expected no task summary before details are cached, got:\n%s", sidebar)
+	}
+
+	runMessageCmds(p, p.prefetchFeatureDetails())
+	sidebar := p.generateSidebarContent()
+	if !strings.Contains(sidebar, "User Authentication 1/3 done") {
+		t.Errorf("Expected a 1/3 summary for User Authentication, got:\n%s", sidebar)
+	}
+	if !strings.Contains(sidebar, "Notifications\n") {
+		t.Errorf("Features without tasks shouldn't get a summary:\n%s", sidebar)
+	}
+}
@@ -0,0 +1,102 @@
+package components
+
+import (
+	"fmt"
+
+	"tddpro/internal/mcpclient"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// testResultsMsg carries the refreshed criterion test outcomes of a feature's tasks
+type testResultsMsg struct {
+	featureID string
+	results   map[string][]mcpclient.CriterionStatus // by task ID
+	err       error                                  // first failure, if any
+}
+
+// refreshTestResults fetches the criterion test outcomes of the selected
+// feature's tasks
+func (p *Prompt) refreshTestResults() tea.Cmd {
+	if p.SelectedFeature == nil || p.MCP == nil {
+		return nil
+	}
+	featureDetail, err := p.featureDetail(p.SelectedFeature.ID)
+	if err != nil {
+		p.StatusBar = "Error loading tasks: " + errorText(err)
+		return nil
+	}
+	var taskIDs []string
+	for _, task := range featureDetail.Tasks {
+		if len(task.EvaluationCriteria) > 0 {
+			taskIDs = append(taskIDs, task.ID)
+		}
+	}
+	if len(taskIDs) == 0 {
+		p.StatusBar = "No acceptance criteria to check"
+		return nil
+	}
+
+	client, featureID := p.MCP, featureDetail.ID
+	p.StatusBar = "Refreshing test results..."
+	return func() tea.Msg {
+		msg := testResultsMsg{featureID: featureID, results: map[string][]mcpclient.CriterionStatus{}}
+		for _, taskID := range taskIDs {
+			results, err := client.GetTaskTestResultsViaStdio(featureID, taskID)
+			if err != nil {
+				if msg.err == nil {
+					msg.err = err
+				}
+				continue
+			}
+			msg.results[taskID] = results
+		}
+		return msg
+	}
+}
+
+// updateTestResults stores refreshed test outcomes on the cached tasks
+func (p *Prompt) updateTestResults(msg tea.Msg) (tea.Cmd, bool) {
+	refreshed, ok := msg.(testResultsMsg)
+	if !ok {
+		return nil, false
+	}
+	counts := map[mcpclient.CriterionStatus]int{}
+	if featureDetail, ok := p.featureDetails.get(refreshed.featureID); ok {
+		for i := range featureDetail.Tasks {
+			task := &featureDetail.Tasks[i]
+			results, ok := refreshed.results[task.ID]
+			if !ok {
+				continue
+			}
+			task.CriteriaResults = results
+			for c := range task.EvaluationCriteria {
+				counts[task.CriterionResult(c)]++
+			}
+		}
+	}
+	if refreshed.err != nil {
+		p.StatusBar = "Test results unavailable: " + errorText(refreshed.err)
+		return nil, true
+	}
+	p.StatusBar = fmt.Sprintf("Test results: %d pass, %d fail, %d pending",
+		counts[mcpclient.CriterionPass], counts[mcpclient.CriterionFail], counts[mcpclient.CriterionPending])
+	return nil, true
+}
+
+// criterionLine renders one acceptance criterion with its test outcome:
+// ✓ passing, ✗ failing, ⧖ not yet run
+func (p *Prompt) criterionLine(task mcpclient.Task, i int) string {
+	glyph, color := "⧖", p.theme.Muted
+	switch task.CriterionResult(i) {
+	case mcpclient.CriterionPass:
+		glyph, color = "✓", p.theme.Success
+	case mcpclient.CriterionFail:
+		glyph, color = "✗", p.theme.Error
+	}
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color(color)).
+		PaddingLeft(3).
+		Render(fmt.Sprintf("%s Test %d: %s", glyph, i+1, task.EvaluationCriteria[i]))
+}
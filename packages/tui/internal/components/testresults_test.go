@@ -0,0 +1,56 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tddpro/internal/mcpclient"
+)
+
+type testResultsClient struct {
+	*mcpclient.DemoClient
+	results []mcpclient.CriterionStatus
+}
+
+func (c *testResultsClient) GetTaskTestResultsViaStdio(featureId, taskId string) ([]mcpclient.CriterionStatus, error) {
+	if taskId != "task-2" {
+		return c.DemoClient.GetTaskTestResultsViaStdio(featureId, taskId)
+	}
+	return c.results, nil
+}
+
+func TestTaskTestResults_RenderAndRefresh(t *testing.T) {
+	p := newDemoPrompt(t)
+	client := &testResultsClient{DemoClient: mcpclient.NewDemoClient()}
+	p.MCP = client
+	p.focusState = 2
+
+	detail, _ := p.featureDetail("user-auth")
+	if got := p.criterionLine(detail.Tasks[1], 1); !strings.Contains(got, "✗ Test 2: Invalid credentials return 401") {
+		t.Errorf("Expected a failing criterion, got %q", got)
+	}
+	if got := p.criterionLine(detail.Tasks[0], 0); !strings.Contains(got, "✓ Test 1") {
+		t.Errorf("Expected a passing criterion, got %q", got)
+	}
+
+	// The tests were fixed and rerun
+	client.results = []mcpclient.CriterionStatus{mcpclient.CriterionPass, mcpclient.CriterionPass}
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if cmd == nil {
+		t.Fatalf("Expected a refresh command, status %q", p.StatusBar)
+	}
+	p.Update(cmd())
+	if p.StatusBar != "Test results: 4 pass, 0 fail, 0 pending" {
+		t.Errorf("Unexpected summary %q", p.StatusBar)
+	}
+	detail, _ = p.featureDetail("user-auth")
+	if got := p.criterionLine(detail.Tasks[1], 1); !strings.Contains(got, "✓ Test 2") {
+		t.Errorf("Expected the refreshed result to render, got %q", got)
+	}
+
+	if got := (mcpclient.Task{EvaluationCriteria: []string{"x"}}).CriterionResult(0); got != mcpclient.CriterionPending {
+		t.Errorf("Expected criteria without results to be pending, got %q", got)
+	}
+}
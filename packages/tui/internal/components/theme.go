@@ -0,0 +1,105 @@
+package components
+
+import "strings"
+
+// Theme centralizes the colors used across the TUI. Values are lipgloss
+// color strings (ANSI 256 codes or hex).
+type Theme struct {
+	Name         string
+	Text         string // primary text
+	Value        string // secondary text such as descriptions and values
+	Muted        string // labels, hints and inactive items
+	Border       string // unfocused panel and box borders
+	Focus        string // focused borders, selection and accents
+	SelectedText string // text drawn on a Focus background
+	Header       string // TUI header
+	Success      string
+	Warning      string
+	Error        string
+	StatusBg     string // status bar background
+	DialogBg     string // completion dialog background
+}
+
+// DarkTheme is the default theme and matches the original hardcoded colors
+var DarkTheme = Theme{
+	Name:         "dark",
+	Text:         "255",
+	Value:        "248",
+	Muted:        "245",
+	Border:       "240",
+	Focus:        "39",
+	SelectedText: "255",
+	Header:       "81",
+	Success:      "46",
+	Warning:      "214",
+	Error:        "196",
+	StatusBg:     "236",
+	DialogBg:     "234",
+}
+
+// LightTheme is tuned for terminals with a light background
+var LightTheme = Theme{
+	Name:         "light",
+	Text:         "235",
+	Value:        "238",
+	Muted:        "243",
+	Border:       "250",
+	Focus:        "25",
+	SelectedText: "255",
+	Header:       "31",
+	Success:      "28",
+	Warning:      "130",
+	Error:        "160",
+	StatusBg:     "254",
+	DialogBg:     "255",
+}
+
+// ThemeByName returns the built-in theme with the given name, defaulting to dark
+func ThemeByName(name string) Theme {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "light":
+		return LightTheme
+	default:
+		return DarkTheme
+	}
+}
+
+// WithColors returns a copy of the theme with individual colors overridden.
+// Keys match the Theme field names in snake_case (e.g. "border", "selected_text").
+func (t Theme) WithColors(colors map[string]string) Theme {
+	for key, color := range colors {
+		if color == "" {
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "text":
+			t.Text = color
+		case "value":
+			t.Value = color
+		case "muted":
+			t.Muted = color
+		case "border":
+			t.Border = color
+		case "focus":
+			t.Focus = color
+		case "selected_text":
+			t.SelectedText = color
+		case "header":
+			t.Header = color
+		case "success":
+			t.Success = color
+		case "warning":
+			t.Warning = color
+		case "error":
+			t.Error = color
+		case "status_bg":
+			t.StatusBg = color
+		case "dialog_bg":
+			t.DialogBg = color
+		}
+	}
+	if len(colors) > 0 {
+		t.Name = "custom"
+	}
+	return t
+}
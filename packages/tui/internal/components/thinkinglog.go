@@ -0,0 +1,109 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// thinkingPreviewLines is how many recent thinking messages the normal view shows
+const thinkingPreviewLines = 3
+
+// recordThinking adds a thinking/tool-call message to the full log and the
+// compact preview, which keeps only the most recent few
+func (p *Prompt) recordThinking(msg string) {
+	p.ThinkingLog = append(p.ThinkingLog, msg)
+	p.ThinkingState = append(p.ThinkingState, msg)
+	if len(p.ThinkingState) > thinkingPreviewLines {
+		p.ThinkingState = p.ThinkingState[len(p.ThinkingState)-thinkingPreviewLines:]
+	}
+}
+
+// toggleThinkingLog opens the full log scrolled to the newest entries, or closes it
+func (p *Prompt) toggleThinkingLog() {
+	p.thinkingLogOpen = !p.thinkingLogOpen
+	if p.thinkingLogOpen {
+		p.thinkingLogScroll = p.maxThinkingLogScroll()
+	}
+}
+
+// updateThinkingLog handles keys while the log panel is open
+func (p *Prompt) updateThinkingLog(msg tea.KeyMsg) (*Prompt, tea.Cmd) {
+	page := p.thinkingLogHeight()
+	switch msg.String() {
+	case "ctrl+l", "esc":
+		p.thinkingLogOpen = false
+	case "up", "k":
+		p.scrollThinkingLog(-1)
+	case "down", "j":
+		p.scrollThinkingLog(1)
+	case "pgup":
+		p.scrollThinkingLog(-page)
+	case "pgdown":
+		p.scrollThinkingLog(page)
+	case "home", "g":
+		p.thinkingLogScroll = 0
+	case "end", "G":
+		p.thinkingLogScroll = p.maxThinkingLogScroll()
+	case "ctrl+c":
+		return p, p.quit()
+	}
+	return p, nil
+}
+
+func (p *Prompt) scrollThinkingLog(delta int) {
+	p.thinkingLogScroll += delta
+	if max := p.maxThinkingLogScroll(); p.thinkingLogScroll > max {
+		p.thinkingLogScroll = max
+	}
+	if p.thinkingLogScroll < 0 {
+		p.thinkingLogScroll = 0
+	}
+}
+
+// thinkingLogHeight is the number of log lines that fit in the panel
+func (p *Prompt) thinkingLogHeight() int {
+	height := p.WindowHeight - 8 // header, borders, title and hint
+	if height < 5 {
+		height = 5
+	}
+	return height
+}
+
+func (p *Prompt) maxThinkingLogScroll() int {
+	max := len(p.ThinkingLog) - p.thinkingLogHeight()
+	if max < 0 {
+		return 0
+	}
+	return max
+}
+
+// thinkingLogView renders the full thinking history as a scrollable panel
+func (p *Prompt) thinkingLogView(width int) string {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Bold(true)
+	lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Value))
+	numberStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Italic(true)
+
+	lines := []string{titleStyle.Render("Thinking Log")}
+	if len(p.ThinkingLog) == 0 {
+		lines = append(lines, hintStyle.Render("No thinking messages for this run yet"))
+	}
+	end := p.thinkingLogScroll + p.thinkingLogHeight()
+	if end > len(p.ThinkingLog) {
+		end = len(p.ThinkingLog)
+	}
+	for i := p.thinkingLogScroll; i < end; i++ {
+		lines = append(lines, numberStyle.Render(fmt.Sprintf("%4d ", i+1))+lineStyle.Render(p.ThinkingLog[i]))
+	}
+	lines = append(lines, hintStyle.Render(fmt.Sprintf("%d messages · ↑↓ pgup/pgdn scroll · ctrl+l/esc close", len(p.ThinkingLog))))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(p.theme.Focus)).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
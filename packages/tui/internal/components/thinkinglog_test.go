@@ -0,0 +1,19 @@
+package components
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecordThinking_KeepsFullLog(t *testing.T) {
+	p := NewPrompt()
+	for i := 1; i <= 5; i++ {
+		p.recordThinking(strings.Repeat("x", i))
+	}
+	if len(p.ThinkingLog) != 5 {
+		t.Errorf("Expected all 5 messages in the log, got %d", len(p.ThinkingLog))
+	}
+	if len(p.ThinkingState) != 3 || p.ThinkingState[0] != "xxx" {
+		t.Errorf("Expected preview of the last 3 messages, got %v", p.ThinkingState)
+	}
+}
@@ -0,0 +1,104 @@
+package components
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// toastDuration is how long a toast stays up before dismissing itself
+const toastDuration = 3 * time.Second
+
+type toastKind int
+
+const (
+	toastSuccess toastKind = iota
+	toastError
+)
+
+// toast is a short-lived result message, e.g. "Task created". Persistent
+// state such as "Waiting for reply..." stays in the status bar instead.
+type toast struct {
+	id   int
+	kind toastKind
+	text string
+}
+
+// toastExpiredMsg dismisses the toast with the given id, unless a newer one
+// has replaced it
+type toastExpiredMsg struct {
+	id int
+}
+
+// prdSavedMsg is sent when a PRD edit has been written via MCP
+type prdSavedMsg struct {
+	featureID string
+	err       error
+}
+
+// showToast displays text until toastDuration passes or another toast replaces it
+func (p *Prompt) showToast(kind toastKind, text string) tea.Cmd {
+	p.toastSeq++
+	p.toast = &toast{id: p.toastSeq, kind: kind, text: text}
+	id := p.toastSeq
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: id}
+	})
+}
+
+// toastResult shows an error toast for a failed action, or a success toast
+func (p *Prompt) toastResult(err error, failure, success string) tea.Cmd {
+	if err != nil {
+		return p.showToast(toastError, failure+": "+errorText(err))
+	}
+	return p.showToast(toastSuccess, success)
+}
+
+// updateToast handles toast expiry and save results that are reported as toasts
+func (p *Prompt) updateToast(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case toastExpiredMsg:
+		if p.toast != nil && p.toast.id == msg.id {
+			p.toast = nil
+		}
+		return nil, true
+	case prdSavedMsg:
+		// The saved PRD is fetched again rather than trusting what was sent
+		if msg.err == nil {
+			p.prds.invalidate(msg.featureID)
+		}
+		return p.toastResult(msg.err, "Error saving PRD", "PRD saved"), true
+	}
+	return nil, false
+}
+
+// withToast layers the current toast over the right end of the view's
+// first line, or replaces that line when both don't fit
+func (p *Prompt) withToast(view string) string {
+	if p.toast == nil {
+		return view
+	}
+	color, icon := p.theme.Success, "✓"
+	if p.toast.kind == toastError {
+		color, icon = p.theme.Error, "✗"
+	}
+	rendered := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(color)).
+		Bold(true).
+		Padding(0, 1).
+		Render(icon + " " + p.toast.text)
+
+	first, rest, _ := strings.Cut(view, "\n")
+	gap := p.WindowWidth - lipgloss.Width(first) - lipgloss.Width(rendered)
+	if gap > 0 {
+		first += strings.Repeat(" ", gap) + rendered
+	} else {
+		first = rendered
+	}
+	if rest == "" && !strings.Contains(view, "\n") {
+		return first
+	}
+	return first + "\n" + rest
+}
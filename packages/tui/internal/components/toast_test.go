@@ -0,0 +1,39 @@
+package components
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToast_ShownAndExpires(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.WindowWidth, p.WindowHeight = 120, 40
+
+	cmd := p.savePRD("# Updated PRD")
+	if cmd == nil {
+		t.Fatalf("Expected a save command, status %q", p.StatusBar)
+	}
+	p.Update(cmd())
+	if p.toast == nil || p.toast.kind != toastSuccess || p.toast.text != "PRD saved" {
+		t.Fatalf("Expected a success toast, got %+v", p.toast)
+	}
+	if p.StatusBar != "" {
+		t.Errorf("Expected the status bar to stay clear, got %q", p.StatusBar)
+	}
+	first, _, _ := strings.Cut(p.View(), "\n")
+	if !strings.Contains(first, "PRD saved") || !strings.Contains(first, "TDD-Pro TUI") {
+		t.Errorf("Expected the toast on the header line, got %q", first)
+	}
+
+	// An expiry for a replaced toast leaves the newer one up
+	stale := p.toast.id
+	p.showToast(toastError, "Error saving task: boom")
+	p.Update(toastExpiredMsg{id: stale})
+	if p.toast == nil || p.toast.kind != toastError {
+		t.Fatalf("Expected the newer toast to remain, got %+v", p.toast)
+	}
+	p.Update(toastExpiredMsg{id: p.toast.id})
+	if p.toast != nil {
+		t.Errorf("Expected the toast to be dismissed, got %+v", p.toast)
+	}
+}
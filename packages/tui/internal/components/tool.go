@@ -0,0 +1,103 @@
+package components
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxToolOutputLines caps how much of a /tool result the status area shows
+const maxToolOutputLines = 40
+
+// toolResultMsg carries the result of a /tool call
+type toolResultMsg struct {
+	name   string
+	output string
+	err    error
+}
+
+// SetDebugTools enables /tool, which calls MCP tools directly. It's on with
+// --log-level debug or DEBUG=1.
+func (p *Prompt) SetDebugTools(enabled bool) {
+	p.debugTools = enabled
+}
+
+// debugCommands only run with debug tools enabled, so they are left out of
+// the command palette otherwise
+var debugCommands = map[string]bool{"/tool": true, "/workflow": true}
+
+// parseToolArgs splits "/tool <name> <json-args>" arguments. The JSON must be
+// an object and may be omitted.
+func parseToolArgs(arg string) (string, map[string]interface{}, error) {
+	name, rest, _ := strings.Cut(strings.TrimSpace(arg), " ")
+	if name == "" {
+		return "", nil, fmt.Errorf("usage: /tool <name> [json-args], e.g. /tool get-feature {\"featureId\": \"user-auth\"}")
+	}
+	args := map[string]interface{}{}
+	if rest = strings.TrimSpace(rest); rest != "" {
+		if err := json.Unmarshal([]byte(rest), &args); err != nil {
+			return "", nil, fmt.Errorf("invalid JSON arguments (expected an object): %w", err)
+		}
+	}
+	return name, args, nil
+}
+
+func handleTool(p *Prompt, arg string) (*Prompt, tea.Cmd) {
+	p.textInput.SetValue("")
+	if !p.debugTools {
+		p.StatusBar = "/tool is a debugging command: start with --log-level debug or DEBUG=1 to enable it"
+		return p, nil
+	}
+	// Tools can edit the project
+	if p.blockedByReadOnly() {
+		return p, nil
+	}
+	if p.MCP == nil {
+		p.StatusBar = "MCP client not available"
+		return p, nil
+	}
+	name, args, err := parseToolArgs(arg)
+	if err != nil {
+		p.StatusBar = err.Error()
+		return p, nil
+	}
+	p.StatusBar = "Calling " + name + "..."
+	return p, func() tea.Msg {
+		output, err := p.MCP.CallToolViaStdio(name, args)
+		return toolResultMsg{name: name, output: output, err: err}
+	}
+}
+
+// formatToolOutput pretty-prints JSON output and caps its length
+func formatToolOutput(output string) string {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, []byte(output), "", "  "); err == nil {
+		output = indented.String()
+	}
+	if strings.TrimSpace(output) == "" {
+		return "(no output)"
+	}
+	lines := strings.Split(output, "\n")
+	if len(lines) > maxToolOutputLines {
+		more := len(lines) - maxToolOutputLines
+		lines = append(lines[:maxToolOutputLines], fmt.Sprintf("… %d more lines", more))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// updateToolResult shows the result of a /tool call
+func (p *Prompt) updateToolResult(msg tea.Msg) (tea.Cmd, bool) {
+	result, ok := msg.(toolResultMsg)
+	if !ok {
+		return nil, false
+	}
+	if result.err != nil {
+		p.StatusBar = fmt.Sprintf("⚠ %s failed: %v", result.name, result.err)
+		return nil, true
+	}
+	p.StatusBar = result.name + ":\n" + formatToolOutput(result.output)
+	return nil, true
+}
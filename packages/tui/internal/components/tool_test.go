@@ -0,0 +1,30 @@
+package components
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToolCommand(t *testing.T) {
+	p := newDemoPrompt(t)
+	if handleTool(p, "list-features"); !strings.Contains(p.StatusBar, "--log-level debug") {
+		t.Errorf("Expected /tool to need debug mode, got %q", p.StatusBar)
+	}
+
+	p.SetDebugTools(true)
+	if _, cmd := handleTool(p, `get-feature {"featureId": `); cmd != nil || !strings.Contains(p.StatusBar, "invalid JSON arguments") {
+		t.Errorf("Expected malformed JSON to be rejected, got %q", p.StatusBar)
+	}
+
+	_, cmd := handleTool(p, `get-feature {"featureId": "user-auth"}`)
+	p.Update(cmd())
+	if !strings.HasPrefix(p.StatusBar, "get-feature:\n{\n") || !strings.Contains(p.StatusBar, `  "id": "user-auth"`) {
+		t.Errorf("Expected pretty-printed JSON, got %q", p.StatusBar)
+	}
+
+	_, cmd = handleTool(p, "delete-everything")
+	p.Update(cmd())
+	if !strings.Contains(p.StatusBar, "delete-everything failed: tool delete-everything is not available") {
+		t.Errorf("Expected the tool error to be shown, got %q", p.StatusBar)
+	}
+}
@@ -0,0 +1,111 @@
+package components
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// transcriptEntry is one message exchanged with the agent. Unlike the
+// Conversation, the transcript survives /clear so it covers the whole session.
+type transcriptEntry struct {
+	Role MessageRole
+	Text string
+	At   time.Time
+}
+
+// transcriptSavedMsg reports the result of /save-transcript
+type transcriptSavedMsg struct {
+	path string
+	err  error
+}
+
+// recordTranscript appends a delivered message or reply to the session transcript
+func (p *Prompt) recordTranscript(role MessageRole, text string) {
+	p.transcript = append(p.transcript, transcriptEntry{Role: role, Text: text, At: time.Now()})
+}
+
+// defaultTranscriptPath is ~/.config/tdd-pro/transcripts/<timestamp>.md
+func defaultTranscriptPath(now time.Time) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "tdd-pro", "transcripts", now.Format("20060102-150405")+".md"), nil
+}
+
+// transcriptMarkdown renders the transcript as one section per message
+func transcriptMarkdown(entries []transcriptEntry, savedAt time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# TDD-Pro transcript\n\n- Saved: %s\n- Messages: %d\n", savedAt.Format("2006-01-02 15:04"), len(entries))
+	for _, entry := range entries {
+		who := "You"
+		if entry.Role == RoleAgent {
+			who = "Agent"
+		}
+		fmt.Fprintf(&b, "\n## %s (%s)\n\n%s\n", who, entry.At.Format("15:04:05"), strings.TrimSpace(entry.Text))
+	}
+	return b.String()
+}
+
+// handleSaveTranscript writes this session's messages and replies to a
+// Markdown file: /save-transcript [path] [--force]. The path defaults to
+// ~/.config/tdd-pro/transcripts/<timestamp>.md. As with /export, an existing
+// file is only overwritten with --force.
+func handleSaveTranscript(p *Prompt, arg string) (*Prompt, tea.Cmd) {
+	p.textInput.SetValue("")
+	if len(p.transcript) == 0 {
+		p.StatusBar = "Nothing to save: no messages exchanged with the agent yet"
+		return p, nil
+	}
+	now := time.Now()
+	path := strings.TrimSpace(arg)
+	force := false
+	if path == "--force" {
+		path, force = "", true
+	} else if rest, ok := strings.CutPrefix(path, "--force "); ok {
+		path, force = strings.TrimSpace(rest), true
+	} else if rest, ok := strings.CutSuffix(path, " --force"); ok {
+		path, force = strings.TrimSpace(rest), true
+	}
+	createDir := false
+	if path == "" {
+		var err error
+		if path, err = defaultTranscriptPath(now); err != nil {
+			p.StatusBar = "Save failed: " + err.Error()
+			return p, nil
+		}
+		createDir = true
+	}
+
+	doc := transcriptMarkdown(p.transcript, now)
+	p.StatusBar = "Saving transcript..."
+	return p, func() tea.Msg {
+		if createDir {
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return transcriptSavedMsg{path: path, err: err}
+			}
+		}
+		// Transcripts may hold anything said to the agent, so only the user reads them
+		err := writeNewFile(path, []byte(doc), 0600, force)
+		return transcriptSavedMsg{path: path, err: err}
+	}
+}
+
+// updateTranscriptSaved reports where /save-transcript wrote the session
+func (p *Prompt) updateTranscriptSaved(msg tea.Msg) (tea.Cmd, bool) {
+	saved, ok := msg.(transcriptSavedMsg)
+	if !ok {
+		return nil, false
+	}
+	if saved.err != nil {
+		p.StatusBar = "Save failed: " + writeFailure(saved.path, saved.err)
+	} else {
+		p.StatusBar = "Transcript saved to " + saved.path
+	}
+	return nil, true
+}
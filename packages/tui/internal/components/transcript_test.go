@@ -0,0 +1,68 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveTranscript_WritesBothSidesAcrossClear(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.FeaturesViewActive = false
+	t.Setenv("HOME", t.TempDir())
+
+	if _, cmd := handleSaveTranscript(p, ""); cmd != nil || !strings.HasPrefix(p.StatusBar, "Nothing to save") {
+		t.Fatalf("Expected an empty session to be refused, got %q", p.StatusBar)
+	}
+
+	// Drive a full send -> wait -> reply round trip
+	runMessageCmds(p, p.submitMessage("Plan the login flow"))
+	if len(p.transcript) != 2 || p.transcript[0].Role != RoleUser || p.transcript[1].Role != RoleAgent {
+		t.Fatalf("Expected the message and its reply in the transcript, got %+v", p.transcript)
+	}
+	handleClear(p, "")
+
+	_, cmd := handleSaveTranscript(p, "")
+	if cmd == nil {
+		t.Fatalf("Expected a save command, status %q", p.StatusBar)
+	}
+	p.Update(cmd())
+	path := strings.TrimPrefix(p.StatusBar, "Transcript saved to ")
+	if path == p.StatusBar || !strings.Contains(path, filepath.Join(".config", "tdd-pro", "transcripts")) {
+		t.Fatalf("Expected the default transcript path to be reported, got %q", p.StatusBar)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Transcript file missing: %v", err)
+	}
+	if info, err := os.Stat(path); err == nil && info.Mode().Perm() != 0600 {
+		t.Errorf("Expected the transcript readable by the user only, got %v", info.Mode().Perm())
+	}
+	doc := string(data)
+	for _, want := range []string{"# TDD-Pro transcript", "## You (", "Plan the login flow", "## Agent (", p.transcript[1].Text} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("Expected %q in transcript:\n%s", want, doc)
+		}
+	}
+
+	// As with /export, an existing file is only replaced with --force
+	existing := filepath.Join(t.TempDir(), "notes.md")
+	os.WriteFile(existing, []byte("keep me"), 0644)
+	_, cmd = handleSaveTranscript(p, existing)
+	p.Update(cmd())
+	if data, _ := os.ReadFile(existing); string(data) != "keep me" || !strings.Contains(p.StatusBar, "already exists") {
+		t.Errorf("Expected the existing file kept, got %q and %q", data, p.StatusBar)
+	}
+	_, cmd = handleSaveTranscript(p, existing+" --force")
+	p.Update(cmd())
+	if data, _ := os.ReadFile(existing); !strings.Contains(string(data), "# TDD-Pro transcript") || p.StatusBar != "Transcript saved to "+existing {
+		t.Errorf("Expected --force to overwrite the file, got %q", p.StatusBar)
+	}
+
+	_, cmd = handleSaveTranscript(p, filepath.Join(t.TempDir(), "missing", "log.md"))
+	p.Update(cmd())
+	if !strings.HasPrefix(p.StatusBar, "Save failed: directory") {
+		t.Errorf("Expected a friendly write error for an explicit path, got %q", p.StatusBar)
+	}
+}
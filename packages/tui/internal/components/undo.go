@@ -0,0 +1,182 @@
+package components
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tddpro/internal/mcpclient"
+)
+
+// maxUndo is how many edits /undo can step back through
+const maxUndo = 10
+
+// undoEntry records the values an edit replaced, which /undo writes back
+// through the MCP server
+type undoEntry struct {
+	featureID string
+	task      *mcpclient.Task // previous task values, nil for a feature edit
+	name      string          // previous feature name
+	desc      string          // previous feature description
+}
+
+func (e undoEntry) label() string {
+	if e.task != nil {
+		return "task " + e.task.Title
+	}
+	return "feature " + e.name
+}
+
+// taskSaveRetryDelay is the pause before a task save that failed
+// transiently is retried
+var taskSaveRetryDelay = 500 * time.Millisecond
+
+// taskUpdatedMsg is sent when a task edit has been saved via MCP
+type taskUpdatedMsg struct {
+	featureID string
+	previous  mcpclient.Task
+	title     string
+	// On a failed save, the task position and the edited values, which are
+	// put back in the form so they can be saved again
+	index int
+	edit  *TaskEditCompleteMsg
+	err   error
+}
+
+// featureSavedMsg is sent when a feature's name and description have been
+// saved via MCP
+type featureSavedMsg struct {
+	previous undoEntry // the values the edit replaced
+	name     string
+	desc     string
+	err      error
+}
+
+// undoAppliedMsg is sent when an undo has been written back via MCP
+type undoAppliedMsg struct {
+	entry undoEntry
+	err   error
+}
+
+// pushUndo records an edit, dropping the oldest beyond maxUndo
+func (p *Prompt) pushUndo(entry undoEntry) {
+	p.undo = append(p.undo, entry)
+	if len(p.undo) > maxUndo {
+		p.undo = p.undo[len(p.undo)-maxUndo:]
+	}
+}
+
+// handleUndo reverts the most recent task or feature edit
+func handleUndo(p *Prompt, arg string) (*Prompt, tea.Cmd) {
+	p.textInput.SetValue("")
+	if p.blockedByReadOnly() {
+		return p, nil
+	}
+	if len(p.undo) == 0 {
+		p.StatusBar = "Nothing to undo"
+		return p, nil
+	}
+	entry := p.undo[len(p.undo)-1]
+	p.undo = p.undo[:len(p.undo)-1]
+
+	if p.MCP == nil {
+		p.StatusBar = "MCP client not available"
+		return p, nil
+	}
+	p.StatusBar = "Undoing edit of " + entry.label() + "..."
+	if entry.task == nil {
+		return p, p.saveFeatureFields(entry.featureID, entry.name, entry.desc, func(err error) tea.Msg {
+			return undoAppliedMsg{entry: entry, err: err}
+		})
+	}
+	return p, func() tea.Msg {
+		updates := map[string]interface{}{
+			"name":                entry.task.Title,
+			"description":         entry.task.Description,
+			"acceptance_criteria": entry.task.EvaluationCriteria,
+			"estimate":            entry.task.Estimate,
+		}
+		return undoAppliedMsg{entry: entry, err: p.MCP.UpdateTaskViaStdio(entry.featureID, entry.task.ID, updates)}
+	}
+}
+
+// saveFeatureFields writes a feature's name and description via MCP,
+// reporting the result with done
+func (p *Prompt) saveFeatureFields(featureID, name, desc string, done func(error) tea.Msg) tea.Cmd {
+	client := p.MCP
+	return func() tea.Msg {
+		return done(client.UpdateFeatureViaStdio(featureID, map[string]interface{}{"name": name, "description": desc}))
+	}
+}
+
+// setFeatureFields shows a feature's saved name and description
+func (p *Prompt) setFeatureFields(featureID, name, desc string) {
+	for _, group := range [][]mcpclient.Feature{p.FeaturesData.Approved, p.FeaturesData.Planned, p.FeaturesData.Refinement, p.FeaturesData.Backlog} {
+		for i := range group {
+			if group[i].ID == featureID {
+				group[i].Name = name
+				group[i].Description = desc
+			}
+		}
+	}
+	if p.SelectedFeature != nil && p.SelectedFeature.ID == featureID {
+		p.SelectedFeature.Name = name
+		p.SelectedFeature.Description = desc
+	}
+}
+
+// updateUndo handles the results of saving and undoing task and feature edits
+func (p *Prompt) updateUndo(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case taskUpdatedMsg:
+		p.StatusBar = ""
+		if msg.err != nil {
+			if msg.edit != nil && p.SelectedFeature != nil && p.SelectedFeature.ID == msg.featureID {
+				return tea.Batch(p.reopenTaskEdit(msg.index, *msg.edit), p.toastResult(msg.err, "Task not saved, edits kept", "")), true
+			}
+			return p.toastResult(msg.err, "Error saving task", ""), true
+		}
+		p.featureDetails.invalidate(msg.featureID)
+		previous := msg.previous
+		p.pushUndo(undoEntry{featureID: msg.featureID, task: &previous})
+		return p.showToast(toastSuccess, "Task edited: "+msg.title+" (u or /undo to revert)"), true
+	case featureSavedMsg:
+		p.StatusBar = ""
+		if msg.err != nil {
+			return p.toastResult(msg.err, "Error saving feature", ""), true
+		}
+		p.setFeatureFields(msg.previous.featureID, msg.name, msg.desc)
+		p.pushUndo(msg.previous)
+		return p.showToast(toastSuccess, "Feature updated: "+msg.name+" (/undo to revert)"), true
+	case undoAppliedMsg:
+		p.StatusBar = ""
+		if msg.err != nil {
+			// Keep the entry so the undo can be retried
+			p.pushUndo(msg.entry)
+			return p.toastResult(msg.err, "Error undoing edit", ""), true
+		}
+		if msg.entry.task == nil {
+			p.setFeatureFields(msg.entry.featureID, msg.entry.name, msg.entry.desc)
+		}
+		p.featureDetails.invalidate(msg.entry.featureID)
+		return p.showToast(toastSuccess, "Undid edit of "+msg.entry.label()), true
+	}
+	return nil, false
+}
+
+// reopenTaskEdit opens the task form again with edits whose save failed, so
+// enter retries instead of the user retyping them
+func (p *Prompt) reopenTaskEdit(index int, edit TaskEditCompleteMsg) tea.Cmd {
+	p.selectedTaskIndex = index
+	p.taskEditForm = &TaskEditForm{
+		visible:     true,
+		theme:       p.theme,
+		title:       edit.Title,
+		description: edit.Description,
+		criteria:    edit.Criteria,
+		estimate:    estimateText(edit.Estimate),
+	}
+	p.taskEditForm.buildForm()
+	p.editingTask = true
+	return p.taskEditForm.Init()
+}
@@ -0,0 +1,107 @@
+package components
+
+import (
+	"errors"
+	"testing"
+
+	"tddpro/internal/mcpclient"
+)
+
+func TestUndo_RevertsTaskEdit(t *testing.T) {
+	p := newDemoPrompt(t)
+	p.focusState = 2
+	before, _ := p.MCP.GetFeatureViaStdio(p.SelectedFeature.ID)
+	original := before.Tasks[0]
+
+	_, cmd := p.Update(TaskEditCompleteMsg{Title: "Renamed", Description: "Changed", Criteria: []string{"new"}})
+	if cmd == nil {
+		t.Fatal("Expected a command to save the task")
+	}
+	p.Update(cmd())
+	after, _ := p.MCP.GetFeatureViaStdio(p.SelectedFeature.ID)
+	if after.Tasks[0].Title != "Renamed" || len(p.undo) != 1 {
+		t.Fatalf("Expected the edit saved and recorded, got %q with %d undo entries", after.Tasks[0].Title, len(p.undo))
+	}
+
+	_, cmd = handleUndo(p, "")
+	if cmd == nil {
+		t.Fatal("Expected a command to restore the task")
+	}
+	p.Update(cmd())
+	restored, _ := p.featureDetail(p.SelectedFeature.ID)
+	if restored.Tasks[0].Title != original.Title || restored.Tasks[0].Description != original.Description {
+		t.Errorf("Expected task restored to %q, got %q", original.Title, restored.Tasks[0].Title)
+	}
+	if len(p.undo) != 0 {
+		t.Errorf("Expected the undo stack to be empty, got %d", len(p.undo))
+	}
+
+	handleUndo(p, "")
+	if p.StatusBar != "Nothing to undo" {
+		t.Errorf("Expected empty stack message, got %q", p.StatusBar)
+	}
+}
+
+// featureSaveClient fails feature updates with err before passing them on to
+// the demo client
+type featureSaveClient struct {
+	*mcpclient.DemoClient
+	err error
+}
+
+func (c *featureSaveClient) UpdateFeatureViaStdio(featureId string, updates map[string]interface{}) error {
+	if c.err != nil {
+		return c.err
+	}
+	return c.DemoClient.UpdateFeatureViaStdio(featureId, updates)
+}
+
+func TestUndo_FeatureEditsAreSavedAndRevertedOnTheServer(t *testing.T) {
+	p := newDemoPrompt(t)
+	client := &featureSaveClient{DemoClient: p.MCP.(*mcpclient.DemoClient), err: errors.New("index.yml is read-only")}
+	p.MCP = client
+	p.focusState, p.FeaturesTab = 1, 0
+	p.WindowWidth, p.WindowHeight = 120, 40
+	p.View() // fills the name and description inputs
+	id, original := p.SelectedFeature.ID, p.SelectedFeature.Name
+	serverName := func() string {
+		t.Helper()
+		data, err := client.ListFeaturesViaStdio()
+		if err != nil {
+			t.Fatal(err)
+		}
+		feature, _ := data.FindFeature(id)
+		return feature.Name
+	}
+
+	p.featureNameEdit.SetValue("Sign-in")
+	_, cmd := p.saveFeatureChanges()
+	p.Update(cmd())
+	if p.SelectedFeature.Name != original || len(p.undo) != 0 || p.toast == nil || p.toast.kind != toastError {
+		t.Fatalf("Expected a failed save to change nothing and say so, got %q, %d undo entries, %+v", p.SelectedFeature.Name, len(p.undo), p.toast)
+	}
+
+	client.err = nil
+	p.featureNameEdit.SetValue("Sign-in")
+	_, cmd = p.saveFeatureChanges()
+	p.Update(cmd())
+	if serverName() != "Sign-in" || p.SelectedFeature.Name != "Sign-in" || len(p.undo) != 1 {
+		t.Fatalf("Expected the rename saved and undoable, got %q on the server, %q shown", serverName(), p.SelectedFeature.Name)
+	}
+
+	_, cmd = handleUndo(p, "")
+	p.Update(cmd())
+	if serverName() != original || p.SelectedFeature.Name != original || p.toast.kind != toastSuccess {
+		t.Errorf("Expected /undo to restore %q on the server, got %q (%q shown)", original, serverName(), p.SelectedFeature.Name)
+	}
+}
+
+func TestUndo_StackIsBounded(t *testing.T) {
+	p := newDemoPrompt(t)
+	for i := 0; i < maxUndo+5; i++ {
+		p.pushUndo(undoEntry{featureID: "user-auth", name: "n"})
+	}
+	if len(p.undo) != maxUndo {
+		t.Errorf("Expected at most %d entries, got %d", maxUndo, len(p.undo))
+	}
+}
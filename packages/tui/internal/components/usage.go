@@ -0,0 +1,74 @@
+package components
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"tddpro/internal/util"
+)
+
+// commandUsageFile holds how often each command has been run, under the
+// config dir (~/.tdd-pro)
+const commandUsageFile = "command-usage.json"
+
+// CommandUsage counts how often each command has been run so completions
+// can list a user's most-used commands first
+type CommandUsage struct {
+	path   string
+	counts map[string]int
+}
+
+// DefaultCommandUsagePath returns where command usage is persisted, or "" if
+// the home directory can't be determined
+func DefaultCommandUsagePath() string {
+	dir := util.GetConfigDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, commandUsageFile)
+}
+
+// LoadCommandUsage reads usage counts from path. A missing or unreadable
+// file starts with no history; an empty path keeps counts in memory only.
+func LoadCommandUsage(path string) *CommandUsage {
+	u := &CommandUsage{path: path, counts: map[string]int{}}
+	if path == "" {
+		return u
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return u
+	}
+	if err := json.Unmarshal(data, &u.counts); err != nil || u.counts == nil {
+		u.counts = map[string]int{}
+	}
+	return u
+}
+
+// Count returns how often cmd has been run
+func (u *CommandUsage) Count(cmd string) int {
+	if u == nil {
+		return 0
+	}
+	return u.counts[cmd]
+}
+
+// Record counts one run of cmd and persists the counts
+func (u *CommandUsage) Record(cmd string) error {
+	if u == nil {
+		return nil
+	}
+	u.counts[cmd]++
+	if u.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(u.counts, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(u.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(u.path, data, 0644)
+}
@@ -0,0 +1,80 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"tddpro/internal/release"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// versionCheckMsg carries the result of comparing this build with the latest release
+type versionCheckMsg struct {
+	result  release.Result
+	err     error
+	startup bool // the check ran on its own, so only an update is worth showing
+}
+
+// SetUpdateCheck makes Init look for a newer release (check_updates in config.yml)
+func (p *Prompt) SetUpdateCheck(enabled bool) {
+	p.checkUpdates = enabled
+}
+
+// checkVersion looks up the latest release in the background
+func (p *Prompt) checkVersion(startup bool) tea.Cmd {
+	url := p.releaseURL
+	if url == "" {
+		url = release.LatestURL
+	}
+	version := p.version
+	return func() tea.Msg {
+		result, err := release.Check(context.Background(), url, version)
+		return versionCheckMsg{result: result, err: err, startup: startup}
+	}
+}
+
+// startupVersionCheck runs the release check when it's enabled, skipping development builds
+func (p *Prompt) startupVersionCheck() tea.Cmd {
+	if !p.checkUpdates || release.IsDevBuild(p.version) {
+		return nil
+	}
+	return p.checkVersion(true)
+}
+
+func handleVersionCheck(p *Prompt, arg string) (*Prompt, tea.Cmd) {
+	p.textInput.SetValue("")
+	if release.IsDevBuild(p.version) {
+		p.StatusBar = "Development build: no release version to compare"
+		return p, nil
+	}
+	p.StatusBar = "Checking for a newer release..."
+	return p, p.checkVersion(false)
+}
+
+// updateVersionCheck reports a release check. Startup checks stay quiet
+// unless an update is available.
+func (p *Prompt) updateVersionCheck(msg tea.Msg) (tea.Cmd, bool) {
+	checked, ok := msg.(versionCheckMsg)
+	if !ok {
+		return nil, false
+	}
+	if checked.startup {
+		if checked.err != nil {
+			slog.Debug("startup version check failed", "err", checked.err)
+		} else if checked.result.UpdateAvailable {
+			p.StatusBar = checked.result.String() + " - see https://github.com/tdd-pro/tdd-pro/releases"
+		}
+		return nil, true
+	}
+	switch {
+	case errors.Is(checked.err, release.ErrDevBuild):
+		p.StatusBar = "Development build: no release version to compare"
+	case checked.err != nil:
+		p.StatusBar = "Version check failed: " + checked.err.Error()
+	default:
+		p.StatusBar = checked.result.String()
+	}
+	return nil, true
+}
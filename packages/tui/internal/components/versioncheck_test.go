@@ -0,0 +1,42 @@
+package components
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVersionCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tag_name": "v1.4.0"}`))
+	}))
+	defer server.Close()
+
+	p := newDemoPrompt(t)
+	p.releaseURL = server.URL
+	p.version = "v1.3.2"
+	_, cmd := handleVersionCheck(p, "")
+	p.Update(cmd())
+	if p.StatusBar != "Update available: v1.4.0 (running v1.3.2)" {
+		t.Errorf("Expected an update notice, got %q", p.StatusBar)
+	}
+
+	// The startup check only speaks up about updates
+	p.version = "v1.4.0"
+	p.StatusBar = ""
+	if p.startupVersionCheck() != nil {
+		t.Error("Expected no startup check unless enabled")
+	}
+	p.SetUpdateCheck(true)
+	p.Update(p.startupVersionCheck()())
+	if p.StatusBar != "" {
+		t.Errorf("Expected an up to date startup check to stay quiet, got %q", p.StatusBar)
+	}
+
+	for _, version := range []string{"dev", "v0.0.0-dev", "1a2b3c4"} {
+		p.version = version
+		if _, cmd := handleVersionCheck(p, ""); cmd != nil || p.startupVersionCheck() != nil {
+			t.Errorf("Expected development build %q to skip the request", version)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"tddpro/internal/mcpclient"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// workflowResultMsg carries the response of a /workflow call
+type workflowResultMsg struct {
+	workflowID string
+	resp       *mcpclient.WorkflowResponse
+	err        error
+}
+
+// workflowPanel shows a raw workflow response, scrolled like the thinking log
+type workflowPanel struct {
+	title  string   // workflow ID and HTTP status
+	lines  []string // response body, one entry per line
+	failed bool     // non-2xx status or a body that isn't JSON
+	scroll int
+}
+
+// handleWorkflow calls a workflow directly and shows its raw response:
+// /workflow <workflowId> [json-input]. Like /tool it's a debugging aid,
+// separate from the interactive /plan run.
+func handleWorkflow(p *Prompt, arg string) (*Prompt, tea.Cmd) {
+	p.textInput.SetValue("")
+	if !p.debugTools {
+		p.StatusBar = "/workflow is a debugging command: start with --log-level debug or DEBUG=1 to enable it"
+		return p, nil
+	}
+	// Workflows can edit the project
+	if p.blockedByReadOnly() {
+		return p, nil
+	}
+	if p.MCP == nil {
+		p.StatusBar = "MCP client not available"
+		return p, nil
+	}
+	if strings.TrimSpace(arg) == "" {
+		p.StatusBar = `Usage: /workflow <workflowId> [json-input], e.g. /workflow tddPlanning {"cwd": "."}`
+		return p, nil
+	}
+	workflowID, input, err := parseToolArgs(arg)
+	if err != nil {
+		p.StatusBar = err.Error()
+		return p, nil
+	}
+	client, baseURL := p.MCP, p.workflowBaseURL()
+	p.StatusBar = "Calling workflow " + workflowID + "..."
+	return p, func() tea.Msg {
+		resp, err := client.CallWorkflow(baseURL, workflowID, input)
+		return workflowResultMsg{workflowID: workflowID, resp: resp, err: err}
+	}
+}
+
+// workflowBaseURL is where workflows are called, /plan's and /workflow's
+// alike: WorkflowURL, or APIURL when no separate workflow API is configured
+func (p *Prompt) workflowBaseURL() string {
+	if p.WorkflowURL != "" {
+		return p.WorkflowURL
+	}
+	return p.APIURL
+}
+
+// updateWorkflowResult opens the response panel for a finished /workflow call
+func (p *Prompt) updateWorkflowResult(msg tea.Msg) (tea.Cmd, bool) {
+	result, ok := msg.(workflowResultMsg)
+	if !ok {
+		return nil, false
+	}
+	if result.err != nil {
+		p.StatusBar = fmt.Sprintf("⚠ workflow %s failed: %v", result.workflowID, result.err)
+		return nil, true
+	}
+	body := result.resp.Body
+	if strings.TrimSpace(body) == "" {
+		body = "(empty body)"
+	}
+	failed := result.resp.Code < 200 || result.resp.Code >= 300 || !result.resp.JSON
+	p.workflowPanel = &workflowPanel{
+		title:  fmt.Sprintf("Workflow %s · HTTP %s", result.workflowID, result.resp.Status),
+		lines:  strings.Split(strings.TrimRight(body, "\n"), "\n"),
+		failed: failed,
+	}
+	p.StatusBar = "Workflow " + result.workflowID + " returned " + result.resp.Status
+	if !result.resp.JSON {
+		p.StatusBar += " (not JSON, shown verbatim)"
+	}
+	return nil, true
+}
+
+// updateWorkflowPanel handles keys while the response panel is open
+func (p *Prompt) updateWorkflowPanel(msg tea.KeyMsg) (*Prompt, tea.Cmd) {
+	page := p.thinkingLogHeight()
+	switch msg.String() {
+	case "esc", "q":
+		p.workflowPanel = nil
+	case "up", "k":
+		p.scrollWorkflowPanel(-1)
+	case "down", "j":
+		p.scrollWorkflowPanel(1)
+	case "pgup":
+		p.scrollWorkflowPanel(-page)
+	case "pgdown":
+		p.scrollWorkflowPanel(page)
+	case "home", "g":
+		p.workflowPanel.scroll = 0
+	case "end", "G":
+		p.workflowPanel.scroll = p.maxWorkflowPanelScroll()
+	case "ctrl+c":
+		return p, p.quit()
+	}
+	return p, nil
+}
+
+func (p *Prompt) scrollWorkflowPanel(delta int) {
+	p.workflowPanel.scroll += delta
+	if max := p.maxWorkflowPanelScroll(); p.workflowPanel.scroll > max {
+		p.workflowPanel.scroll = max
+	}
+	if p.workflowPanel.scroll < 0 {
+		p.workflowPanel.scroll = 0
+	}
+}
+
+func (p *Prompt) maxWorkflowPanelScroll() int {
+	max := len(p.workflowPanel.lines) - p.thinkingLogHeight()
+	if max < 0 {
+		return 0
+	}
+	return max
+}
+
+// workflowPanelView renders the response body as a scrollable panel
+func (p *Prompt) workflowPanelView(width int) string {
+	panel := p.workflowPanel
+	titleColor := p.theme.Focus
+	if panel.failed {
+		titleColor = p.theme.Error
+	}
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(titleColor)).Bold(true)
+	lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Value))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Italic(true)
+
+	lines := []string{titleStyle.Render(panel.title)}
+	end := min(panel.scroll+p.thinkingLogHeight(), len(panel.lines))
+	for _, line := range panel.lines[panel.scroll:end] {
+		lines = append(lines, lineStyle.Render(line))
+	}
+	lines = append(lines, hintStyle.Render(fmt.Sprintf("lines %d-%d of %d · ↑↓ pgup/pgdn scroll · esc close", panel.scroll+1, end, len(panel.lines))))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(titleColor)).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,79 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tddpro/internal/mcpclient"
+)
+
+// workflowClient answers /workflow calls with a fixed response
+type workflowClient struct {
+	*mcpclient.DemoClient
+	resp    *mcpclient.WorkflowResponse
+	input   map[string]interface{}
+	baseURL string
+}
+
+func (c *workflowClient) CallWorkflow(baseURL, workflowId string, input map[string]interface{}) (*mcpclient.WorkflowResponse, error) {
+	c.input, c.baseURL = input, baseURL
+	return c.resp, nil
+}
+
+func TestWorkflowCommand_ShowsRawResponseInScrollablePanel(t *testing.T) {
+	body := "{\n" + strings.Repeat("  \"step\": \"done\",\n", 60) + "  \"runId\": \"r1\"\n}"
+	client := &workflowClient{DemoClient: mcpclient.NewDemoClient(), resp: &mcpclient.WorkflowResponse{Status: "200 OK", Code: 200, Body: body, JSON: true}}
+	prompt := NewPromptWithClient(client, "http://localhost:4111", "test")
+	p := &prompt
+	p.WindowWidth, p.WindowHeight = 100, 30
+
+	if _, cmd := handleWorkflow(p, "tddPlanning"); cmd != nil || !strings.HasPrefix(p.StatusBar, "/workflow is a debugging command") {
+		t.Fatalf("Expected /workflow to need debug tools, got %q", p.StatusBar)
+	}
+	p.SetDebugTools(true)
+
+	_, cmd := handleWorkflow(p, `tddPlanning {"cwd": "."}`)
+	if cmd == nil {
+		t.Fatalf("Expected a workflow call, status %q", p.StatusBar)
+	}
+	p.Update(cmd())
+	if client.input["cwd"] != "." {
+		t.Errorf("Expected the JSON input to be passed, got %v", client.input)
+	}
+	if client.baseURL != "http://localhost:4111" {
+		t.Errorf("Expected the API URL when no workflow URL is set, got %q", client.baseURL)
+	}
+	if p.workflowPanel == nil {
+		t.Fatal("Expected the response panel to open")
+	}
+	view := p.View()
+	if !strings.Contains(view, "Workflow tddPlanning · HTTP 200 OK") || strings.Contains(view, `"runId"`) {
+		t.Errorf("Expected the status and the top of the body, got:\n%s", view)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	if view = p.View(); !strings.Contains(view, `"runId": "r1"`) {
+		t.Errorf("Expected end to scroll to the bottom, got:\n%s", view)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.workflowPanel != nil {
+		t.Error("Expected esc to close the panel")
+	}
+
+	// Non-JSON error bodies are shown as they came, from the workflow API
+	// when it is served apart from the MCP API
+	p.WorkflowURL = "http://localhost:4112"
+	client.resp = &mcpclient.WorkflowResponse{Status: "502 Bad Gateway", Code: 502, Body: "<html>upstream down</html>"}
+	_, cmd = handleWorkflow(p, "tddPlanning")
+	p.Update(cmd())
+	if view = p.View(); !strings.Contains(view, "HTTP 502 Bad Gateway") || !strings.Contains(view, "<html>upstream down</html>") {
+		t.Errorf("Expected the error body verbatim, got:\n%s", view)
+	}
+	if !strings.Contains(p.StatusBar, "not JSON") {
+		t.Errorf("Expected the status to flag a non-JSON body, got %q", p.StatusBar)
+	}
+	if client.baseURL != "http://localhost:4112" {
+		t.Errorf("Expected the workflow URL, got %q", client.baseURL)
+	}
+}
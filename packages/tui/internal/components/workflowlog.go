@@ -0,0 +1,263 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"tddpro/internal/streams"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// workflowLogEntry is one workflow event as the event log shows it
+type workflowLogEntry struct {
+	at   time.Time
+	step string // payload step, or the event type when there's none
+	text string
+}
+
+// workflowStartedMsg reports a /plan run that was created and started, so its
+// events can be read
+type workflowStartedMsg struct {
+	run      *streams.WorkflowRun
+	progress *workflowProgress
+}
+
+// workflowFailedMsg reports a /plan run that couldn't be created or started
+type workflowFailedMsg struct {
+	err error
+}
+
+// workflowEventMsg delivers one event read from a run's Events channel
+type workflowEventMsg struct {
+	run      *streams.WorkflowRun
+	progress *workflowProgress
+	event    streams.WorkflowEvent
+	at       time.Time
+}
+
+// workflowEndedMsg reports that a run's Events channel closed, with the
+// run's Err when the stream didn't end cleanly
+type workflowEndedMsg struct {
+	run      *streams.WorkflowRun
+	progress *workflowProgress
+	err      error
+}
+
+// startWorkflow creates and starts a tddPlanning run off the event loop. The
+// run is tracked from creation so quitting cancels it even mid-start.
+func (p *Prompt) startWorkflow(cwd string, opts ...streams.Option) tea.Cmd {
+	runs, progress := p.workflows, p.workflowProgress
+	return func() tea.Msg {
+		wr, err := streams.NewWorkflowRun(cwd, opts...)
+		if err != nil {
+			return workflowFailedMsg{err: err}
+		}
+		runs.add(wr)
+		wr.Watch()
+		if err := wr.StartWorkflow(cwd); err != nil {
+			wr.Cancel()
+			runs.remove(wr)
+			return workflowFailedMsg{err: err}
+		}
+		return workflowStartedMsg{run: wr, progress: progress}
+	}
+}
+
+// waitForWorkflowEvent reads the next event from a run, or reports that the
+// run ended once its Events channel closes
+func waitForWorkflowEvent(wr *streams.WorkflowRun, progress *workflowProgress) tea.Cmd {
+	return func() tea.Msg {
+		evt, ok := <-wr.Events
+		if !ok {
+			return workflowEndedMsg{run: wr, progress: progress, err: wr.Err}
+		}
+		return workflowEventMsg{run: wr, progress: progress, event: evt, at: time.Now()}
+	}
+}
+
+// updateWorkflowRun handles the messages of a running /plan workflow: each
+// event is logged, updates progress and the thinking preview, and the next
+// one is waited for until the run ends
+func (p *Prompt) updateWorkflowRun(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case workflowFailedMsg:
+		p.StatusBar = "Error: " + msg.err.Error()
+		return nil, true
+	case workflowStartedMsg:
+		return waitForWorkflowEvent(msg.run, msg.progress), true
+	case workflowEventMsg:
+		var payload map[string]interface{}
+		json.Unmarshal(msg.event.Payload, &payload)
+		step, _ := payload["step"].(string)
+		msg.progress.update(step, payload)
+		p.recordWorkflowEvent(newWorkflowLogEntry(msg.at, msg.event, payload))
+		switch step {
+		case "thinking":
+			text, _ := payload["msg"].(string)
+			p.recordThinking(text)
+			p.StatusBar = "Workflow is thinking..."
+		case "clarification":
+			p.StatusBar, _ = payload["prompt"].(string)
+		case "finished":
+			result, _ := payload["result"].(string)
+			p.StatusBar = "Workflow finished: " + result
+			p.ThinkingState = nil
+			p.textInput.SetValue("")
+		}
+		return waitForWorkflowEvent(msg.run, msg.progress), true
+	case workflowEndedMsg:
+		p.workflows.remove(msg.run)
+		if msg.err != nil {
+			p.recordWorkflowEvent(workflowLogEntry{at: time.Now(), step: "error", text: "connection lost: " + msg.err.Error()})
+			p.StatusBar = "Workflow connection lost: " + msg.err.Error()
+			p.ThinkingState = nil
+			if p.workflowProgress == msg.progress {
+				p.workflowProgress = nil
+			}
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+// newWorkflowLogEntry summarizes an event for the log: its message, prompt,
+// result or error when it has one, else its raw payload
+func newWorkflowLogEntry(at time.Time, evt streams.WorkflowEvent, payload map[string]interface{}) workflowLogEntry {
+	entry := workflowLogEntry{at: at, step: evt.Type}
+	if step, ok := payload["step"].(string); ok && step != "" {
+		entry.step = step
+	}
+	if entry.step == "" {
+		entry.step = "event"
+	}
+	for _, key := range []string{"msg", "prompt", "result", "error"} {
+		if text, ok := payload[key].(string); ok && text != "" {
+			entry.text = text
+			return entry
+		}
+	}
+	entry.text = strings.TrimSpace(string(evt.Payload))
+	return entry
+}
+
+// recordWorkflowEvent appends to the event log. An open log that's scrolled
+// to the bottom follows new events; one scrolled back stays put.
+func (p *Prompt) recordWorkflowEvent(entry workflowLogEntry) {
+	following := p.workflowLogScroll >= p.maxWorkflowLogScroll()
+	p.workflowLog = append(p.workflowLog, entry)
+	if following {
+		p.workflowLogScroll = p.maxWorkflowLogScroll()
+	}
+}
+
+// toggleWorkflowLog opens the event log following the newest events, or closes it
+func (p *Prompt) toggleWorkflowLog() {
+	p.workflowLogOpen = !p.workflowLogOpen
+	if p.workflowLogOpen {
+		p.workflowLogScroll = p.maxWorkflowLogScroll()
+	}
+}
+
+// updateWorkflowLog handles keys while the event log is open. The workflow
+// keeps running, so events arrive while the log is scrolled back.
+func (p *Prompt) updateWorkflowLog(msg tea.KeyMsg) (*Prompt, tea.Cmd) {
+	page := p.thinkingLogHeight()
+	switch msg.String() {
+	case "ctrl+t", "esc":
+		p.workflowLogOpen = false
+	case "up", "k":
+		p.scrollWorkflowLog(-1)
+	case "down", "j":
+		p.scrollWorkflowLog(1)
+	case "pgup":
+		p.scrollWorkflowLog(-page)
+	case "pgdown":
+		p.scrollWorkflowLog(page)
+	case "home", "g":
+		p.workflowLogScroll = 0
+	case "end", "G":
+		p.workflowLogScroll = p.maxWorkflowLogScroll()
+	case "ctrl+c":
+		return p, p.quit()
+	}
+	return p, nil
+}
+
+func (p *Prompt) scrollWorkflowLog(delta int) {
+	p.workflowLogScroll += delta
+	if max := p.maxWorkflowLogScroll(); p.workflowLogScroll > max {
+		p.workflowLogScroll = max
+	}
+	if p.workflowLogScroll < 0 {
+		p.workflowLogScroll = 0
+	}
+}
+
+// maxWorkflowLogScroll uses the thinking log's height, as both panels have
+// the same chrome
+func (p *Prompt) maxWorkflowLogScroll() int {
+	max := len(p.workflowLog) - p.thinkingLogHeight()
+	if max < 0 {
+		return 0
+	}
+	return max
+}
+
+// workflowStepColor color-codes an event's step type
+func (p *Prompt) workflowStepColor(step string) string {
+	switch step {
+	case "thinking":
+		return p.theme.Focus
+	case "clarification":
+		return p.theme.Warning
+	case "finished":
+		return p.theme.Success
+	case "error", "failed":
+		return p.theme.Error
+	}
+	return p.theme.Muted
+}
+
+// workflowLogView renders every event of the /plan runs as a scrollable
+// panel, one line per event
+func (p *Prompt) workflowLogView(width int) string {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Focus)).Bold(true)
+	timeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted))
+	lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Value))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Italic(true)
+
+	lines := []string{titleStyle.Render("Workflow Events")}
+	if len(p.workflowLog) == 0 {
+		lines = append(lines, hintStyle.Render("No workflow events yet, run /plan to start one"))
+	}
+	end := p.workflowLogScroll + p.thinkingLogHeight()
+	if end > len(p.workflowLog) {
+		end = len(p.workflowLog)
+	}
+	for _, entry := range p.workflowLog[p.workflowLogScroll:end] {
+		stepStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(p.workflowStepColor(entry.step))).Bold(true)
+		prefix := timeStyle.Render(entry.at.Format("15:04:05")+" ") + stepStyle.Render(fmt.Sprintf("%-13s ", entry.step))
+		// One line per event keeps the scroll position exact
+		text := strings.Join(strings.Fields(entry.text), " ")
+		if runes, room := []rune(text), width-2-lipgloss.Width(prefix); len(runes) > room && room > 1 {
+			text = string(runes[:room-1]) + "…"
+		}
+		lines = append(lines, prefix+lineStyle.Render(text))
+	}
+	position := "following"
+	if p.workflowLogScroll < p.maxWorkflowLogScroll() {
+		position = "G to follow"
+	}
+	lines = append(lines, hintStyle.Render(fmt.Sprintf("%d events · %s · ↑↓ pgup/pgdn scroll · ctrl+t/esc close", len(p.workflowLog), position)))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(p.theme.Focus)).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,77 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tddpro/internal/streams"
+)
+
+func TestWorkflowLog_RecordsEveryEventAndScrollsBack(t *testing.T) {
+	p := NewPrompt()
+	p.WindowWidth, p.WindowHeight = 100, 20 // twelve log lines
+	progress := &workflowProgress{}
+	p.workflowProgress = progress
+	run := &streams.WorkflowRun{Events: make(chan streams.WorkflowEvent, 1)}
+	start := time.Date(2026, 1, 2, 12, 0, 0, 0, time.Local)
+	send := func(i int, eventType, payload string) tea.Cmd {
+		t.Helper()
+		_, cmd := p.Update(workflowEventMsg{run: run, progress: progress, at: start.Add(time.Duration(i) * time.Second),
+			event: streams.WorkflowEvent{Type: eventType, Payload: []byte(payload)}})
+		return cmd
+	}
+
+	if cmd := send(1, "step", `{"step":"thinking","msg":"Reading the repo"}`); cmd == nil {
+		t.Fatal("Expected the next event to be waited for")
+	}
+	send(2, "step", `{"step":"clarification","prompt":"Which database?"}`)
+	send(3, "step-result", `{"stepIndex":1,"totalSteps":4}`)
+	if len(p.workflowLog) != 3 || p.ThinkingState[0] != "Reading the repo" || p.StatusBar != "Which database?" {
+		t.Fatalf("Expected every event logged and handled, got %+v / %v / %q", p.workflowLog, p.ThinkingState, p.StatusBar)
+	}
+	if completed, total := progress.state(); completed != 1 || total != 4 {
+		t.Errorf("Expected progress 1/4, got %d/%d", completed, total)
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	view := p.View()
+	for _, want := range []string{"Workflow Events", "12:00:01", "thinking", "Reading the repo", "clarification", "step-result", `{"stepIndex":1,"totalSteps":4}`, "3 events"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the event log to show %q, got:\n%s", want, view)
+		}
+	}
+
+	// Scrolled back, new events don't move the log; G follows them again
+	for i := 4; i <= 15; i++ {
+		send(i, "step", fmt.Sprintf(`{"step":"thinking","msg":"step %d"}`, i))
+	}
+	if p.workflowLogScroll != 3 {
+		t.Fatalf("Expected the open log to follow new events, got scroll %d", p.workflowLogScroll)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyUp})
+	send(16, "step", `{"step":"finished","result":"3 features"}`)
+	if p.workflowLogScroll != 2 || p.StatusBar != "Workflow finished: 3 features" {
+		t.Errorf("Expected the scrolled-back log to stay put while the run finished, got scroll %d, %q", p.workflowLogScroll, p.StatusBar)
+	}
+	if view := p.View(); strings.Contains(view, "3 features") || !strings.Contains(view, "G to follow") {
+		t.Errorf("Expected the newest event off screen, got:\n%s", view)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	if view := p.View(); !strings.Contains(view, "3 features") || !strings.Contains(view, "following") {
+		t.Errorf("Expected G to jump to the newest event, got:\n%s", view)
+	}
+
+	p.Update(workflowEndedMsg{run: run, progress: progress, err: errors.New("EOF")})
+	if last := p.workflowLog[len(p.workflowLog)-1]; last.step != "error" || p.StatusBar != "Workflow connection lost: EOF" || p.workflowProgress != nil {
+		t.Errorf("Expected a lost connection logged and reported, got %+v, %q", last, p.StatusBar)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.workflowLogOpen {
+		t.Error("Expected esc to close the event log")
+	}
+}
@@ -0,0 +1,65 @@
+package components
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// workflowProgress tracks how many steps of a workflow run have completed.
+// Events report it with numeric stepIndex (steps reached so far) and
+// totalSteps payload fields; without a total the bar stays hidden.
+type workflowProgress struct {
+	mu        sync.Mutex // updated by the watcher goroutine, read by View
+	completed int
+	total     int
+}
+
+// update records the progress carried by a workflow event. A finished run
+// fills the bar.
+func (w *workflowProgress) update(step string, payload map[string]interface{}) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if total, ok := payload["totalSteps"].(float64); ok && total > 0 {
+		w.total = int(total)
+	}
+	if index, ok := payload["stepIndex"].(float64); ok && index >= 0 {
+		w.completed = int(index)
+	}
+	if step == "finished" {
+		w.completed = w.total
+	}
+	if w.completed > w.total {
+		w.completed = w.total
+	}
+}
+
+// state returns the completed and total step counts
+func (w *workflowProgress) state() (int, int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.completed, w.total
+}
+
+// workflowProgressView renders the progress bar of the running workflow,
+// or "" when there is none or its total is unknown
+func (p *Prompt) workflowProgressView(width int) string {
+	if p.workflowProgress == nil {
+		return ""
+	}
+	completed, total := p.workflowProgress.state()
+	if total <= 0 {
+		return ""
+	}
+	label := fmt.Sprintf(" %d/%d steps", completed, total)
+	bar := progress.New(
+		progress.WithSolidFill(p.theme.Focus),
+		progress.WithoutPercentage(),
+		progress.WithWidth(width-lipgloss.Width(label)),
+	)
+	bar.EmptyColor = p.theme.Border
+	return bar.ViewAs(float64(completed)/float64(total)) +
+		lipgloss.NewStyle().Foreground(lipgloss.Color(p.theme.Muted)).Render(label)
+}
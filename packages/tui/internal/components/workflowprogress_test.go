@@ -0,0 +1,35 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestWorkflowProgressView(t *testing.T) {
+	p := newDemoPrompt(t)
+	if got := p.workflowProgressView(60); got != "" {
+		t.Errorf("Expected no bar without a run, got %q", got)
+	}
+
+	p.workflowProgress = &workflowProgress{}
+	p.workflowProgress.update("thinking", map[string]interface{}{"msg": "Thinking"})
+	if got := p.workflowProgressView(60); got != "" {
+		t.Errorf("Expected the bar hidden while the total is unknown, got %q", got)
+	}
+
+	p.workflowProgress.update("thinking", map[string]interface{}{"stepIndex": 2.0, "totalSteps": 4.0})
+	view := p.workflowProgressView(60)
+	if !strings.Contains(view, "2/4 steps") {
+		t.Errorf("Expected 2/4 steps, got %q", view)
+	}
+	if w := lipgloss.Width(view); w != 60 {
+		t.Errorf("Expected the bar to fill the width, got %d", w)
+	}
+
+	p.workflowProgress.update("finished", map[string]interface{}{"result": "done"})
+	if view := p.workflowProgressView(60); !strings.Contains(view, "4/4 steps") {
+		t.Errorf("Expected a finished run to fill the bar, got %q", view)
+	}
+}
@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultPath is where the TUI writes its log: ~/.config/tdd-pro/tdd-pro.log
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "tdd-pro", "tdd-pro.log"), nil
+}
+
+// ParseLevel converts a --log-level value (debug, info, warn, error) to a slog.Level
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return slog.LevelInfo, fmt.Errorf("unknown log level %q (use debug, info, warn or error)", s)
+}
+
+// Discard drops all log output so nothing reaches the terminal
+func Discard() {
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// Setup sends the default slog logger (and the standard log package) to the
+// file at path. The TUI owns stdout/stderr while the alt-screen is active, so
+// if the file can't be opened logging is discarded rather than printed.
+// The returned function closes the file.
+func Setup(path string, level slog.Level) (func() error, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		Discard()
+		return func() error { return nil }, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		Discard()
+		return func() error { return nil }, err
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(f, &slog.HandlerOptions{Level: level})))
+	return f.Close, nil
+}
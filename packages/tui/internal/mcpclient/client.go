@@ -0,0 +1,59 @@
+package mcpclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// Client is the backend the TUI talks to: the agent conversation plus the
+// project data served by the MCP stdio server. MCPClient is the real
+// implementation; DemoClient serves canned data for --demo.
+type Client interface {
+	SendMessage(agentId, userMsg string) error
+	ListenForReply() (string, error)
+	ListenForReplyContext(ctx context.Context) (string, error)
+	ReplyUsage() *Usage                // token usage of the last reply, nil if unknown
+	RequestHeaders() map[string]string // extra headers for backend requests
+	HTTPClient() *http.Client          // client for backend requests, carrying the TLS settings
+	Connected() bool                   // whether an SSE session is open
+	ConnectionState() ConnectionState
+
+	ListFeaturesViaStdio() (*FeaturesData, error)
+	GetFeatureViaStdio(featureId string) (*FeatureDetail, error)
+	GetFeaturesViaStdio(featureIds []string) (map[string]*FeatureDetail, error)
+	UpdateTaskViaStdio(featureId, taskId string, updates map[string]interface{}) error
+	CreateTaskViaStdio(featureId string, task Task) (Task, error)
+	GetTaskTestResultsViaStdio(featureId, taskId string) ([]CriterionStatus, error)
+	GetFeatureDocumentViaStdio(featureId string) (string, error)
+	UpdateFeatureDocumentViaStdio(featureId, content string) error
+	UpdateFeatureStatusViaStdio(featureId, status string) error
+	UpdateFeatureViaStdio(featureId string, updates map[string]interface{}) error
+	CreateFeatureViaStdio(feature Feature) error
+	ServerInfoViaStdio() (*ServerInfo, error)
+	CallToolViaStdio(name string, args map[string]interface{}) (string, error)                        // any tool, for /tool
+	CallWorkflow(baseURL, workflowId string, input map[string]interface{}) (*WorkflowResponse, error) // raw workflow call, for /workflow
+
+	Close()       // ends the SSE session; the client can reconnect
+	CancelReply() // abandons the reply to a sent message that isn't being listened for
+	Shutdown()    // releases all resources before exit
+}
+
+var (
+	_ Client = (*MCPClient)(nil)
+	_ Client = (*DemoClient)(nil)
+)
+
+// ReplyUsage returns the token usage reported with the last reply
+func (c *MCPClient) ReplyUsage() *Usage {
+	return c.LastUsage
+}
+
+// Connected reports whether an SSE session is currently open
+func (c *MCPClient) Connected() bool {
+	return c.ConnectionState() == StateConnected
+}
+
+// RequestHeaders returns the extra headers sent with every backend request
+func (c *MCPClient) RequestHeaders() map[string]string {
+	return c.Headers
+}
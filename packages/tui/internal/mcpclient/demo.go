@@ -0,0 +1,387 @@
+package mcpclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DemoClient is an in-memory Client with sample features, tasks and PRDs and
+// an agent that echoes messages back. It needs no backend or MCP server, so
+// the TUI can be tried out instantly with --demo. Edits last for the session.
+type DemoClient struct {
+	mu       sync.Mutex
+	features FeaturesData
+	tasks    map[string][]Task
+	prds     map[string]string
+	pending  []string // messages sent but not yet replied to
+}
+
+// NewDemoClient returns a DemoClient loaded with sample data
+func NewDemoClient() *DemoClient {
+	ago := func(d time.Duration) Timestamp { return Timestamp{time.Now().Add(-d)} }
+	return &DemoClient{
+		features: FeaturesData{
+			Approved: []Feature{
+				{ID: "user-auth", Name: "User Authentication", Description: "Email and password sign-in with session management", Status: "approved", CreatedAt: ago(12 * 24 * time.Hour), UpdatedAt: ago(3 * time.Hour)},
+			},
+			Planned: []Feature{
+				{ID: "search", Name: "Full-Text Search", Description: "Search across projects, features and tasks", Status: "planned", Dependencies: []string{"user-auth"}, CreatedAt: ago(5 * 24 * time.Hour)},
+			},
+			Refinement: []Feature{
+				{ID: "notifications", Name: "Notifications", Description: "Email and in-app notifications for task updates", Status: "refinement", Dependencies: []string{"user-auth", "search"}},
+			},
+			Backlog: []Feature{
+				{ID: "dark-mode", Name: "Dark Mode", Description: "A dark theme for the web dashboard", Status: "backlog"},
+			},
+			CurrentFeatures: []string{"user-auth"},
+		},
+		tasks: map[string][]Task{
+			"user-auth": {
+				{
+					ID:          "task-1",
+					Title:       "Hash passwords with bcrypt",
+					Description: "Store only bcrypt hashes of user passwords.",
+					Status:      TaskCompleted,
+					EvaluationCriteria: []string{
+						"Plaintext passwords are never persisted",
+						"Hashing cost is configurable",
+					},
+					CriteriaResults: []CriterionStatus{CriterionPass, CriterionPass},
+				},
+				{
+					ID:          "task-2",
+					Title:       "Issue session tokens on sign-in",
+					Description: "Create a signed session token after a successful sign-in.",
+					Status:      "in-progress",
+					EvaluationCriteria: []string{
+						"Tokens expire after 24 hours",
+						"Invalid credentials return 401",
+					},
+					CriteriaResults: []CriterionStatus{CriterionPass, CriterionFail},
+				},
+				{
+					ID:          "task-3",
+					Title:       "Add sign-out endpoint",
+					Description: "Revoke the current session token.",
+					Status:      "pending",
+				},
+			},
+			"search": {
+				{
+					ID:                 "task-1",
+					Title:              "Index feature names and descriptions",
+					Description:        "Build a full-text index that updates when features change.",
+					Status:             "pending",
+					EvaluationCriteria: []string{"New features are searchable within a second"},
+				},
+			},
+		},
+		prds: map[string]string{
+			"user-auth":     "# User Authentication\n\n## Goal\nLet users sign in with email and password.\n\n## Requirements\n- Passwords are stored hashed\n- Sessions expire after 24 hours\n- Users can sign out\n",
+			"search":        "# Full-Text Search\n\n## Goal\nFind anything in a project from one search box.\n\n## Requirements\n- Results ranked by relevance\n- Index updates as data changes\n",
+			"notifications": "# Notifications\n\n## Open Questions\n- Which events should notify?\n- Should users be able to mute features?\n",
+		},
+	}
+}
+
+// SendMessage queues the message for the echoing demo agent
+func (d *DemoClient) SendMessage(agentId, userMsg string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pending = append(d.pending, userMsg)
+	return nil
+}
+
+// ListenForReply echoes the oldest unanswered message
+func (d *DemoClient) ListenForReply() (string, error) {
+	return d.ListenForReplyContext(context.Background())
+}
+
+// ListenForReplyContext is ListenForReply, dropping the unanswered messages
+// instead when ctx is already cancelled
+func (d *DemoClient) ListenForReplyContext(ctx context.Context) (string, error) {
+	if ctx.Err() != nil {
+		d.CancelReply()
+		return "", ctx.Err()
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.pending) == 0 {
+		return "", fmt.Errorf("no message awaiting a reply")
+	}
+	msg := d.pending[0]
+	d.pending = d.pending[1:]
+	return fmt.Sprintf("(demo agent) You said: %s", msg), nil
+}
+
+// ReplyUsage returns nil; the demo agent doesn't report token usage
+func (d *DemoClient) ReplyUsage() *Usage {
+	return nil
+}
+
+// RequestHeaders returns nil; the demo client makes no backend requests
+func (d *DemoClient) RequestHeaders() map[string]string {
+	return nil
+}
+
+// HTTPClient returns http.DefaultClient; the demo client makes no backend requests
+func (d *DemoClient) HTTPClient() *http.Client {
+	return http.DefaultClient
+}
+
+// Shutdown does nothing; the demo client holds no resources
+func (d *DemoClient) Shutdown() {}
+
+// Connected returns false; the demo client never opens a connection
+func (d *DemoClient) Connected() bool {
+	return false
+}
+
+// ConnectionState is always disconnected for the demo client
+func (d *DemoClient) ConnectionState() ConnectionState {
+	return StateDisconnected
+}
+
+// ListFeaturesViaStdio returns the sample features
+func (d *DemoClient) ListFeaturesViaStdio() (*FeaturesData, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	data := d.features
+	return &data, nil
+}
+
+// GetFeatureViaStdio returns a copy of the feature's sample tasks
+func (d *DemoClient) GetFeatureViaStdio(featureId string) (*FeatureDetail, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	feature, ok := d.findFeature(featureId)
+	if !ok {
+		return nil, fmt.Errorf("feature %s not found", featureId)
+	}
+	tasks := make([]Task, len(d.tasks[featureId]))
+	copy(tasks, d.tasks[featureId])
+	return &FeatureDetail{ID: featureId, Name: feature.Name, Tasks: tasks}, nil
+}
+
+// GetFeaturesViaStdio returns copies of several features' sample tasks
+func (d *DemoClient) GetFeaturesViaStdio(featureIds []string) (map[string]*FeatureDetail, error) {
+	details := make(map[string]*FeatureDetail, len(featureIds))
+	for _, id := range featureIds {
+		detail, err := d.GetFeatureViaStdio(id)
+		if err != nil {
+			return nil, err
+		}
+		details[id] = detail
+	}
+	return details, nil
+}
+
+// UpdateTaskViaStdio applies the same update keys the update-task tool accepts
+func (d *DemoClient) UpdateTaskViaStdio(featureId, taskId string, updates map[string]interface{}) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	tasks := d.tasks[featureId]
+	for i := range tasks {
+		if tasks[i].ID != taskId {
+			continue
+		}
+		if name, ok := updates["name"].(string); ok {
+			tasks[i].Title = name
+		}
+		if description, ok := updates["description"].(string); ok {
+			tasks[i].Description = description
+		}
+		switch estimate := updates["estimate"].(type) {
+		case float64:
+			tasks[i].Estimate = estimate
+		case int:
+			tasks[i].Estimate = float64(estimate)
+		}
+		switch criteria := updates["acceptance_criteria"].(type) {
+		case []string:
+			tasks[i].EvaluationCriteria = criteria
+		case []interface{}:
+			// A new slice, as earlier results still share the old one
+			list := make([]string, 0, len(criteria))
+			for _, c := range criteria {
+				list = append(list, fmt.Sprint(c))
+			}
+			tasks[i].EvaluationCriteria = list
+		}
+		return nil
+	}
+	return fmt.Errorf("task %s not found in feature %s", taskId, featureId)
+}
+
+// GetTaskTestResultsViaStdio returns the sample test outcomes of a task
+func (d *DemoClient) GetTaskTestResultsViaStdio(featureId, taskId string) ([]CriterionStatus, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, task := range d.tasks[featureId] {
+		if task.ID == taskId {
+			results := make([]CriterionStatus, len(task.EvaluationCriteria))
+			for i := range results {
+				results[i] = task.CriterionResult(i)
+			}
+			return results, nil
+		}
+	}
+	return nil, fmt.Errorf("task %s not found in feature %s", taskId, featureId)
+}
+
+// CreateTaskViaStdio appends a task to the feature
+func (d *DemoClient) CreateTaskViaStdio(featureId string, task Task) (Task, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.findFeature(featureId); !ok {
+		return Task{}, fmt.Errorf("feature %s not found", featureId)
+	}
+	if task.ID == "" {
+		task.ID = fmt.Sprintf("task-%d", time.Now().UnixNano())
+	}
+	task.Status = "pending"
+	d.tasks[featureId] = append(d.tasks[featureId], task)
+	return task, nil
+}
+
+// GetFeatureDocumentViaStdio returns the feature's sample PRD
+func (d *DemoClient) GetFeatureDocumentViaStdio(featureId string) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if prd, ok := d.prds[featureId]; ok {
+		return prd, nil
+	}
+	feature, ok := d.findFeature(featureId)
+	if !ok {
+		return "", fmt.Errorf("feature %s not found", featureId)
+	}
+	return fmt.Sprintf("# %s\n\n%s\n", feature.Name, strings.TrimSpace(feature.Description)), nil
+}
+
+// UpdateFeatureDocumentViaStdio replaces the feature's PRD for this session
+func (d *DemoClient) UpdateFeatureDocumentViaStdio(featureId, content string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.findFeature(featureId); !ok {
+		return fmt.Errorf("feature %s not found", featureId)
+	}
+	d.prds[featureId] = content
+	return nil
+}
+
+// UpdateFeatureStatusViaStdio moves the feature to another status group for this session
+func (d *DemoClient) UpdateFeatureStatusViaStdio(featureId, status string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.findFeature(featureId); !ok {
+		return fmt.Errorf("feature %s not found", featureId)
+	}
+	if _, ok := d.features.SetFeatureStatus(featureId, status); !ok {
+		return fmt.Errorf("unknown status %q", status)
+	}
+	return nil
+}
+
+// UpdateFeatureViaStdio applies name, description and dependencies updates for this session
+func (d *DemoClient) UpdateFeatureViaStdio(featureId string, updates map[string]interface{}) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	feature, ok := d.features.FindFeature(featureId)
+	if !ok {
+		return fmt.Errorf("feature %s not found", featureId)
+	}
+	if name, ok := updates["name"].(string); ok && name != "" {
+		feature.Name = name
+	}
+	if description, ok := updates["description"].(string); ok && description != "" {
+		feature.Description = description
+	}
+	if dependencies, ok := updates["dependencies"].([]string); ok {
+		feature.Dependencies = append([]string(nil), dependencies...)
+	}
+	return nil
+}
+
+// CreateFeatureViaStdio adds a feature to the refinement or backlog group for this session
+func (d *DemoClient) CreateFeatureViaStdio(feature Feature) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.findFeature(feature.ID); ok {
+		return fmt.Errorf("feature %s already exists", feature.ID)
+	}
+	now := Timestamp{time.Now()}
+	feature.CreatedAt, feature.UpdatedAt = now, now
+	if feature.Status == "backlog" {
+		d.features.Backlog = append(d.features.Backlog, feature)
+	} else {
+		feature.Status = "refinement"
+		d.features.Refinement = append(d.features.Refinement, feature)
+	}
+	return nil
+}
+
+// ServerInfoViaStdio describes the demo client as a server with every required tool
+func (d *DemoClient) ServerInfoViaStdio() (*ServerInfo, error) {
+	return &ServerInfo{
+		Path:            "(demo)",
+		Name:            "TDD-Pro demo data",
+		Version:         "dev",
+		ProtocolVersion: "n/a",
+		Tools:           RequiredTools,
+	}, nil
+}
+
+// CallToolViaStdio answers the read-only tools from the sample data as JSON
+func (d *DemoClient) CallToolViaStdio(name string, args map[string]interface{}) (string, error) {
+	var result interface{}
+	var err error
+	switch name {
+	case "list-features":
+		result, err = d.ListFeaturesViaStdio()
+	case "get-feature":
+		featureId, _ := args["featureId"].(string)
+		result, err = d.GetFeatureViaStdio(featureId)
+	case "get-feature-document":
+		featureId, _ := args["featureId"].(string)
+		result, err = d.GetFeatureDocumentViaStdio(featureId)
+	default:
+		return "", fmt.Errorf("tool %s is not available in demo mode", name)
+	}
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(result)
+	return string(data), err
+}
+
+// CallWorkflow fails: demo mode has no workflow backend
+func (d *DemoClient) CallWorkflow(baseURL, workflowId string, input map[string]interface{}) (*WorkflowResponse, error) {
+	return nil, fmt.Errorf("workflow %s is not available in demo mode", workflowId)
+}
+
+// Close is a no-op; the demo client holds no connections
+func (d *DemoClient) Close() {}
+
+// CancelReply drops unanswered messages so their echoes aren't returned for the next one
+func (d *DemoClient) CancelReply() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pending = nil
+}
+
+// findFeature looks a feature up across every status group. Callers hold d.mu.
+func (d *DemoClient) findFeature(featureId string) (Feature, bool) {
+	for _, group := range [][]Feature{d.features.Approved, d.features.Planned, d.features.Refinement, d.features.Backlog} {
+		for _, f := range group {
+			if f.ID == featureId {
+				return f, true
+			}
+		}
+	}
+	return Feature{}, false
+}
@@ -0,0 +1,92 @@
+package mcpclient
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDemoClient_EchoesMessages(t *testing.T) {
+	d := NewDemoClient()
+	if err := d.SendMessage("tddAgent", "hello"); err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+	reply, err := d.ListenForReply()
+	if err != nil {
+		t.Fatalf("ListenForReply failed: %v", err)
+	}
+	if !strings.Contains(reply, "hello") {
+		t.Errorf("Expected reply to echo the message, got %q", reply)
+	}
+	if _, err := d.ListenForReply(); err == nil {
+		t.Error("Expected an error when no message is awaiting a reply")
+	}
+}
+
+func TestDemoClient_TaskEditsPersist(t *testing.T) {
+	d := NewDemoClient()
+	created, err := d.CreateTaskViaStdio("user-auth", Task{Title: "Rate-limit sign-in"})
+	if err != nil {
+		t.Fatalf("CreateTaskViaStdio failed: %v", err)
+	}
+	if err := d.UpdateTaskViaStdio("user-auth", created.ID, map[string]interface{}{
+		"description":         "Lock out after five failures",
+		"acceptance_criteria": []string{"Sixth attempt returns 429"},
+	}); err != nil {
+		t.Fatalf("UpdateTaskViaStdio failed: %v", err)
+	}
+
+	detail, err := d.GetFeatureViaStdio("user-auth")
+	if err != nil {
+		t.Fatalf("GetFeatureViaStdio failed: %v", err)
+	}
+	last := detail.Tasks[len(detail.Tasks)-1]
+	if last.ID != created.ID || last.Description != "Lock out after five failures" || len(last.EvaluationCriteria) != 1 {
+		t.Errorf("Expected created task with updates, got %+v", last)
+	}
+	if _, err := d.GetFeatureViaStdio("missing"); err == nil {
+		t.Error("Expected an error for an unknown feature")
+	}
+}
+
+// Criteria decoded from JSON arrive as []interface{}; replacing them must not
+// rewrite a task fetched before the edit
+func TestDemoClient_CriteriaEditsLeaveEarlierResultsAlone(t *testing.T) {
+	d := NewDemoClient()
+	before, err := d.GetFeatureViaStdio("user-auth")
+	if err != nil {
+		t.Fatalf("GetFeatureViaStdio failed: %v", err)
+	}
+	task := before.Tasks[0]
+	if len(task.EvaluationCriteria) == 0 {
+		t.Fatal("Expected the sample task to have criteria")
+	}
+	first := task.EvaluationCriteria[0]
+	if err := d.UpdateTaskViaStdio("user-auth", task.ID, map[string]interface{}{
+		"acceptance_criteria": []interface{}{"Rewritten"},
+	}); err != nil {
+		t.Fatalf("UpdateTaskViaStdio failed: %v", err)
+	}
+	if task.EvaluationCriteria[0] != first {
+		t.Errorf("Expected the earlier result to keep %q, got %q", first, task.EvaluationCriteria[0])
+	}
+	after, _ := d.GetFeatureViaStdio("user-auth")
+	if criteria := after.Tasks[0].EvaluationCriteria; len(criteria) != 1 || criteria[0] != "Rewritten" {
+		t.Errorf("Expected the criteria replaced, got %v", criteria)
+	}
+}
+
+func TestTimestamp_DecodesLeniently(t *testing.T) {
+	var feature Feature
+	data := `{"id": "a", "created_at": "2025-01-02T03:04:05.000Z", "updated_at": "yesterday"}`
+	if err := json.Unmarshal([]byte(data), &feature); err != nil {
+		t.Fatalf("Expected an odd timestamp not to fail decoding, got %v", err)
+	}
+	if feature.CreatedAt.Year() != 2025 || !feature.UpdatedAt.IsZero() {
+		t.Errorf("Expected created_at parsed and updated_at zero, got %v / %v", feature.CreatedAt, feature.UpdatedAt)
+	}
+	out, _ := json.Marshal(feature)
+	if !strings.Contains(string(out), `"updated_at":null`) {
+		t.Errorf("Expected a zero time to encode as null, got %s", out)
+	}
+}
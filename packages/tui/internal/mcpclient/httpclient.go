@@ -0,0 +1,88 @@
+package mcpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	dialTimeout           = 10 * time.Second
+	tlsHandshakeTimeout   = 10 * time.Second
+	responseHeaderTimeout = 30 * time.Second
+	idleConnTimeout       = 90 * time.Second
+	// requestTimeout bounds whole requests; streams use StreamingClient instead
+	requestTimeout = 60 * time.Second
+)
+
+// defaultHTTPClient is shared by clients that weren't given one so they reuse connections
+var defaultHTTPClient, _ = NewHTTPClient(TLSOptions{})
+
+// TLSOptions configures how backend HTTPS certificates are verified, for
+// servers behind an internal CA or using self-signed certificates
+type TLSOptions struct {
+	CAFile             string // PEM bundle trusted in addition to the system roots
+	InsecureSkipVerify bool   // skip certificate verification entirely; testing only
+}
+
+// NewHTTPClient returns the client used for every backend request (SSE,
+// messages and workflows) with opts applied to its transport. Connections
+// are kept alive and reused; dialing, the TLS handshake, response headers
+// and whole requests are each bounded by a timeout.
+func NewHTTPClient(opts TLSOptions) (*http.Client, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: opts.InsecureSkipVerify}
+	if opts.InsecureSkipVerify {
+		slog.Warn("TLS certificate verification is disabled (insecure_skip_verify); connections can be intercepted")
+	}
+	if opts.CAFile != "" {
+		pem, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", opts.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   dialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   tlsHandshakeTimeout,
+		ResponseHeaderTimeout: responseHeaderTimeout,
+		IdleConnTimeout:       idleConnTimeout,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+	}
+	return &http.Client{Transport: transport, Timeout: requestTimeout}, nil
+}
+
+// StreamingClient returns a copy of client without the overall request
+// timeout, for long-lived bodies such as SSE. It shares client's transport,
+// so connect and response-header timeouts and pooled connections still apply.
+func StreamingClient(client *http.Client) *http.Client {
+	stream := *client
+	stream.Timeout = 0
+	return &stream
+}
+
+// HTTPClient returns the client backend requests are sent with
+func (c *MCPClient) HTTPClient() *http.Client {
+	if c.HTTP != nil {
+		return c.HTTP
+	}
+	return defaultHTTPClient
+}
@@ -5,12 +5,18 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	mcp "github.com/metoro-io/mcp-golang"
 	"github.com/metoro-io/mcp-golang/transport/stdio"
@@ -18,38 +24,410 @@ import (
 
 type MCPClient struct {
 	APIURL    string
+	Headers   map[string]string // extra headers sent with every backend request (e.g. Authorization)
+	HTTP      *http.Client      // client for backend requests; nil uses a shared default
 	SessionID string
 	lastReply string
+	LastUsage *Usage // token usage of the last reply, nil if the backend didn't report it
 	respBody  *http.Response
+	scanner   *bufio.Scanner // shared reader over respBody so buffered events aren't lost
+	cancelSSE context.CancelFunc
+
+	// Transport selects how replies are received. The zero value tries SSE
+	// and switches to polling if no sessionId arrives within SSETimeout.
+	Transport    Transport
+	SSETimeout   time.Duration // defaults to defaultSSETimeout
+	PollInterval time.Duration // first delay between polls, doubling up to maxPollInterval
+	polling      bool          // set once the automatic fallback has switched to polling
+
+	// Last message sent, kept so it can be resent if the connection drops
+	// before its reply arrives. Cleared once a reply is received.
+	lastAgentID string
+	lastMessage string
+
+	// MCP stdio servers with a call in progress, killed by Shutdown. Each call
+	// reaps its own server when it returns.
+	procMu sync.Mutex
+	procs  []*exec.Cmd
+
+	// Connection state, readable from other goroutines via ConnectionState
+	stateMu sync.Mutex
+	state   ConnectionState
+	// OnStateChange, if set, is called whenever the connection state changes.
+	// It may be called from whichever goroutine is using the client.
+	OnStateChange func(ConnectionState)
+}
+
+// Transport is how the client receives replies from the backend
+type Transport int
+
+const (
+	TransportAuto    Transport = iota // SSE, falling back to polling when SSE is blocked
+	TransportSSE                      // SSE only
+	TransportPolling                  // poll /poll for replies
+)
+
+const (
+	defaultSSETimeout   = 10 * time.Second
+	defaultPollInterval = 250 * time.Millisecond
+	maxPollInterval     = 5 * time.Second
+	// stdioCallTimeout bounds a stdio tool call, server startup included
+	stdioCallTimeout = 30 * time.Second
+)
+
+// errSSEUnavailable means the backend answered but no sessionId came over
+// SSE, which is what a proxy that buffers or blocks text/event-stream looks like
+var errSSEUnavailable = errors.New("no sessionId received from SSE")
+
+// transientError marks a failure that may succeed when retried: the MCP
+// server process couldn't be spawned or initialized, or the call timed out
+type transientError struct {
+	err error
+}
+
+func (e transientError) Error() string { return e.err.Error() }
+func (e transientError) Unwrap() error { return e.err }
+
+// IsTransient reports whether err is worth retrying. Errors reported by the
+// tool itself, such as a missing task, are permanent.
+func IsTransient(err error) bool {
+	var transient transientError
+	return errors.As(err, &transient) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// ConnectionState describes the SSE connection to the backend
+type ConnectionState int
+
+const (
+	StateDisconnected ConnectionState = iota
+	StateConnecting
+	StateConnected
+	StateReconnecting
+)
+
+func (s ConnectionState) String() string {
+	switch s {
+	case StateConnecting:
+		return "connecting"
+	case StateConnected:
+		return "connected"
+	case StateReconnecting:
+		return "reconnecting"
+	}
+	return "disconnected"
+}
+
+// ConnectionState returns the current state of the SSE connection
+func (c *MCPClient) ConnectionState() ConnectionState {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	return c.state
+}
+
+// setState records a state change and notifies OnStateChange
+func (c *MCPClient) setState(state ConnectionState) {
+	c.stateMu.Lock()
+	changed := c.state != state
+	c.state = state
+	c.stateMu.Unlock()
+	if changed && c.OnStateChange != nil {
+		c.OnStateChange(state)
+	}
+}
+
+// Usage reports token counts for an agent reply
+type Usage struct {
+	InputTokens  int
+	OutputTokens int
+}
+
+// replyUsage accepts both Anthropic-style and AI SDK-style usage fields
+type replyUsage struct {
+	InputTokens      int `json:"input_tokens"`
+	OutputTokens     int `json:"output_tokens"`
+	PromptTokens     int `json:"promptTokens"`
+	CompletionTokens int `json:"completionTokens"`
+}
+
+// normalize converts the reported fields to a Usage, or nil when nothing was reported
+func (u *replyUsage) normalize() *Usage {
+	if u == nil {
+		return nil
+	}
+	usage := &Usage{InputTokens: u.InputTokens, OutputTokens: u.OutputTokens}
+	if usage.InputTokens == 0 {
+		usage.InputTokens = u.PromptTokens
+	}
+	if usage.OutputTokens == 0 {
+		usage.OutputTokens = u.CompletionTokens
+	}
+	if usage.InputTokens == 0 && usage.OutputTokens == 0 {
+		return nil
+	}
+	return usage
 }
 
 func NewMCPClient(apiURL string) *MCPClient {
 	return &MCPClient{APIURL: apiURL}
 }
 
-// OpenSSE opens the /sse endpoint and extracts the sessionId, keeps the connection open
-func (c *MCPClient) OpenSSE() error {
-	resp, err := http.Get(c.APIURL + "/sse")
+// get performs a GET against the backend with the configured headers
+func (c *MCPClient) get(url string) (*http.Response, error) {
+	return c.getContext(context.Background(), url)
+}
+
+// getContext is get, aborted when ctx is cancelled
+func (c *MCPClient) getContext(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+// post performs a POST against the backend with the configured headers
+func (c *MCPClient) post(url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, url, body)
 	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return c.do(req)
+}
+
+// do applies the configured headers and sends the request
+func (c *MCPClient) do(req *http.Request) (*http.Response, error) {
+	return c.doWith(c.HTTPClient(), req)
+}
+
+// openStream GETs a long-lived body such as /sse, which must not be cut
+// off by the overall request timeout
+func (c *MCPClient) openStream(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.doWith(StreamingClient(c.HTTPClient()), req)
+}
+
+func (c *MCPClient) doWith(client *http.Client, req *http.Request) (*http.Response, error) {
+	for key, value := range c.Headers {
+		req.Header.Set(key, value)
+	}
+	return client.Do(req)
+}
+
+// connect opens a session using the configured transport. In automatic mode
+// a blocked SSE stream switches the client to polling for the rest of its life.
+func (c *MCPClient) connect() error {
+	if c.Transport == TransportPolling || c.polling {
+		return c.openPolling()
+	}
+	err := c.OpenSSE()
+	if c.Transport == TransportSSE || !errors.Is(err, errSSEUnavailable) {
 		return err
 	}
-	c.respBody = resp
-	scanner := bufio.NewScanner(resp.Body)
+	slog.Warn("SSE unavailable, falling back to polling", "err", err)
+	c.polling = true
+	return c.openPolling()
+}
+
+// OpenSSE opens the /sse endpoint and extracts the sessionId, keeps the connection open.
+// It gives up with errSSEUnavailable if no sessionId arrives within SSETimeout.
+func (c *MCPClient) OpenSSE() error {
+	if c.ConnectionState() != StateReconnecting {
+		c.setState(StateConnecting)
+	}
+	timeout := c.SSETimeout
+	if timeout <= 0 {
+		timeout = defaultSSETimeout
+	}
+
+	type opened struct {
+		resp      *http.Response
+		scanner   *bufio.Scanner
+		sessionID string
+		err       error
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan opened, 1)
+	go func() {
+		resp, err := c.openStream(ctx, c.APIURL+"/sse")
+		if err != nil {
+			done <- opened{err: err}
+			return
+		}
+		scanner := bufio.NewScanner(resp.Body)
+		done <- opened{resp: resp, scanner: scanner, sessionID: scanSessionID(scanner)}
+	}()
+
+	var result opened
+	select {
+	case result = <-done:
+	case <-time.After(timeout):
+		cancel()
+		go func() {
+			// Release the response if it arrives after we've given up
+			if late := <-done; late.resp != nil {
+				late.resp.Body.Close()
+			}
+		}()
+		c.setState(StateDisconnected)
+		return fmt.Errorf("%w within %s", errSSEUnavailable, timeout)
+	}
+	if result.err != nil {
+		cancel()
+		c.setState(StateDisconnected)
+		return result.err
+	}
+	c.respBody = result.resp
+	c.scanner = result.scanner
+	c.cancelSSE = cancel
+	c.SessionID = result.sessionID
+	if c.SessionID == "" {
+		c.Close()
+		return errSSEUnavailable
+	}
+	c.setState(StateConnected)
+	return nil
+}
+
+// scanSessionID reads SSE lines until the endpoint event carrying the sessionId
+func scanSessionID(scanner *bufio.Scanner) string {
 	for scanner.Scan() {
 		line := scanner.Text()
 		if strings.HasPrefix(line, "data:") && strings.Contains(line, "sessionId=") {
 			parts := strings.Split(line, "sessionId=")
 			if len(parts) > 1 {
-				c.SessionID = strings.TrimSpace(parts[1])
-				break
+				return strings.TrimSpace(parts[1])
 			}
 		}
 	}
+	return ""
+}
+
+// ProbeSSE opens /sse at apiURL, waits for the sessionId the backend
+// announces and disconnects again, leaving any client's session alone.
+// It's a diagnostic; cancel ctx to bound the wait.
+func ProbeSSE(ctx context.Context, client *http.Client, headers map[string]string, apiURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+"/sse", nil)
+	if err != nil {
+		return "", err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := StreamingClient(client).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("/sse returned %s", resp.Status)
+	}
+	if sessionID := scanSessionID(bufio.NewScanner(resp.Body)); sessionID != "" {
+		return sessionID, nil
+	}
+	return "", errSSEUnavailable
+}
+
+// openPolling starts a polling session: GET /poll returns {"sessionId": "..."}
+func (c *MCPClient) openPolling() error {
+	if c.ConnectionState() != StateReconnecting {
+		c.setState(StateConnecting)
+	}
+	resp, err := c.get(c.APIURL + "/poll")
+	if err != nil {
+		c.setState(StateDisconnected)
+		return err
+	}
+	defer resp.Body.Close()
+	var session struct {
+		SessionID string `json:"sessionId"`
+	}
+	if resp.StatusCode != http.StatusOK {
+		c.setState(StateDisconnected)
+		return fmt.Errorf("failed to open polling session: %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil || session.SessionID == "" {
+		c.setState(StateDisconnected)
+		return fmt.Errorf("no sessionId received from /poll")
+	}
+	c.SessionID = session.SessionID
+	c.setState(StateConnected)
 	return nil
 }
 
+// Reconnect drops the current connection and opens a fresh session
+func (c *MCPClient) Reconnect() error {
+	c.Close()
+	c.setState(StateReconnecting)
+	return c.connect()
+}
+
+// Close closes the SSE connection and forgets the session
+func (c *MCPClient) Close() {
+	if c.respBody != nil {
+		c.respBody.Body.Close()
+	}
+	if c.cancelSSE != nil {
+		c.cancelSSE()
+	}
+	c.respBody = nil
+	c.scanner = nil
+	c.cancelSSE = nil
+	c.SessionID = ""
+	c.setState(StateDisconnected)
+}
+
+// CancelReply abandons the reply to a message that was sent but isn't being
+// listened for. The session is closed so the reply can't arrive later as
+// the answer to the next message. Cancel ListenForReplyContext's context to
+// abort a listen in progress instead.
+func (c *MCPClient) CancelReply() {
+	c.lastMessage = ""
+	c.Close()
+}
+
+// Shutdown releases everything the client holds before the program exits:
+// the SSE connection and the MCP stdio servers of calls still in flight
+func (c *MCPClient) Shutdown() {
+	c.Close()
+	c.procMu.Lock()
+	procs := c.procs
+	c.procs = nil
+	c.procMu.Unlock()
+	for _, cmd := range procs {
+		if err := cmd.Process.Kill(); err == nil {
+			cmd.Wait()
+		}
+	}
+}
+
 // SendMessage sends a JSON-RPC message to /message?sessionId=...
+// It opens the session on first use, and if the server has dropped the
+// session it reconnects and resends once.
 func (c *MCPClient) SendMessage(agentId, userMsg string) error {
+	c.lastAgentID = agentId
+	c.lastMessage = userMsg
+	if c.SessionID == "" {
+		if err := c.connect(); err != nil {
+			return fmt.Errorf("failed to open session: %w", err)
+		}
+	}
+	err := c.postMessage(agentId, userMsg)
+	if err == nil {
+		return nil
+	}
+	slog.Warn("message send failed, reconnecting", "err", err)
+	if rerr := c.Reconnect(); rerr != nil {
+		return fmt.Errorf("%v (reconnect failed: %w)", err, rerr)
+	}
+	return c.postMessage(agentId, userMsg)
+}
+
+// postMessage performs a single POST of the message to the current session
+func (c *MCPClient) postMessage(agentId, userMsg string) error {
 	payload := map[string]interface{}{
 		"jsonrpc": "2.0",
 		"id":      "1",
@@ -63,64 +441,185 @@ func (c *MCPClient) SendMessage(agentId, userMsg string) error {
 	}
 	data, _ := json.Marshal(payload)
 	url := fmt.Sprintf("%s/message?sessionId=%s", c.APIURL, c.SessionID)
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(data))
+	resp, err := c.post(url, "application/json", bytes.NewBuffer(data))
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
+	// A 404/410 means the server no longer knows this session
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return fmt.Errorf("session %s expired (HTTP %d)", c.SessionID, resp.StatusCode)
+	}
 	return nil // ignore the 'Accepted' response
 }
 
-// ListenForReply blocks and returns the next agent reply from the SSE stream
+// ListenForReply blocks and returns the next agent reply from the SSE stream.
+// If the stream drops before a reply arrives, it reconnects and resends the
+// last message once so the in-flight request isn't lost.
 func (c *MCPClient) ListenForReply() (string, error) {
-	if c.respBody == nil {
+	return c.ListenForReplyContext(context.Background())
+}
+
+// ListenForReplyContext is ListenForReply, aborting the read when ctx is
+// cancelled. The session is closed then, so the abandoned reply can't be
+// read as the next message's, and the message isn't resent.
+func (c *MCPClient) ListenForReplyContext(ctx context.Context) (string, error) {
+	reply, err := c.readReply(ctx)
+	if ctx.Err() != nil {
+		c.lastMessage = ""
+		return "", ctx.Err()
+	}
+	if err == nil || c.lastMessage == "" {
+		return reply, err
+	}
+	slog.Warn("SSE stream dropped before reply, reconnecting", "err", err)
+	if rerr := c.Reconnect(); rerr != nil {
+		return "", fmt.Errorf("%v (reconnect failed: %w)", err, rerr)
+	}
+	if perr := c.postMessage(c.lastAgentID, c.lastMessage); perr != nil {
+		return "", fmt.Errorf("failed to resend message after reconnect: %w", perr)
+	}
+	return c.readReply(ctx)
+}
+
+// readReply waits for the next reply over SSE, or by polling once the client has fallen back to it
+func (c *MCPClient) readReply(ctx context.Context) (string, error) {
+	if c.Transport == TransportPolling || c.polling {
+		return c.pollReply(ctx)
+	}
+	if c.respBody == nil || c.scanner == nil {
 		return "", fmt.Errorf("SSE connection not open")
 	}
-	scanner := bufio.NewScanner(c.respBody.Body)
-	for scanner.Scan() {
-		line := scanner.Text()
+	// Closing the body is what unblocks a scan waiting on the stream
+	body := c.respBody.Body
+	stop := context.AfterFunc(ctx, func() { body.Close() })
+	defer stop()
+	for c.scanner.Scan() {
+		line := c.scanner.Text()
 		if strings.HasPrefix(line, "data:") {
-			var event struct {
-				Result struct {
-					Messages []struct {
-						Content string `json:"content"`
-					} `json:"messages"`
-				} `json:"result"`
-			}
 			jsonStr := strings.TrimPrefix(line, "data:")
 			jsonStr = strings.TrimSpace(jsonStr)
-			if err := json.Unmarshal([]byte(jsonStr), &event); err == nil && len(event.Result.Messages) > 0 {
-				c.lastReply = event.Result.Messages[0].Content
+			if c.parseReply([]byte(jsonStr)) {
+				return c.lastReply, nil
+			}
+		}
+	}
+	// The stream has ended, so the session is gone; forget it so the next
+	// send opens a fresh one instead of posting to a stale sessionId
+	c.Close()
+	return "", fmt.Errorf("SSE stream closed before a reply arrived")
+}
+
+// pollReply polls /poll?sessionId=... with exponential backoff until a reply
+// arrives. The backend answers 204 while no reply is ready yet.
+func (c *MCPClient) pollReply(ctx context.Context) (string, error) {
+	if c.SessionID == "" {
+		return "", fmt.Errorf("polling session not open")
+	}
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	for {
+		resp, err := c.getContext(ctx, fmt.Sprintf("%s/poll?sessionId=%s", c.APIURL, c.SessionID))
+		if err != nil {
+			c.Close()
+			return "", err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		switch {
+		case err != nil:
+			c.Close()
+			return "", err
+		case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+			sessionID := c.SessionID
+			c.Close()
+			return "", fmt.Errorf("session %s expired (HTTP %d)", sessionID, resp.StatusCode)
+		case resp.StatusCode == http.StatusOK:
+			if c.parseReply(body) {
 				return c.lastReply, nil
 			}
+		case resp.StatusCode != http.StatusNoContent:
+			c.Close()
+			return "", fmt.Errorf("poll failed: %s", resp.Status)
 		}
+		select {
+		case <-ctx.Done():
+			c.Close()
+			return "", ctx.Err()
+		case <-time.After(interval):
+		}
+		if interval *= 2; interval > maxPollInterval {
+			interval = maxPollInterval
+		}
+	}
+}
+
+// parseReply records the reply if data is a reply event, reporting whether it was
+func (c *MCPClient) parseReply(data []byte) bool {
+	var event struct {
+		Result struct {
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+			Usage *replyUsage `json:"usage"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil || len(event.Result.Messages) == 0 {
+		return false
 	}
-	return "", fmt.Errorf("no reply received from SSE")
+	c.lastReply = event.Result.Messages[0].Content
+	c.LastUsage = event.Result.Usage.normalize()
+	c.lastMessage = ""
+	return true
 }
 
-// CallWorkflow calls a workflow by ID with the given input and returns the response as a string
-func (c *MCPClient) CallWorkflow(workflowId string, input map[string]interface{}) (string, error) {
-	url := c.APIURL + "/api/workflows/" + workflowId
+// WorkflowResponse is the raw result of CallWorkflow
+type WorkflowResponse struct {
+	Status string // HTTP status line, e.g. "200 OK"
+	Code   int
+	Body   string // indented when the body is JSON, verbatim otherwise
+	JSON   bool   // whether Body parsed as JSON
+}
+
+// CallWorkflow calls a workflow by ID on the workflow API at baseURL, or at
+// APIURL when baseURL is empty, and returns the response whatever its status,
+// so error bodies can be inspected too. The error is only set when no
+// response arrived.
+func (c *MCPClient) CallWorkflow(baseURL, workflowId string, input map[string]interface{}) (*WorkflowResponse, error) {
+	if baseURL == "" {
+		baseURL = c.APIURL
+	}
+	url := baseURL + "/api/workflows/" + workflowId
 	data, _ := json.Marshal(input)
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(data))
+	resp, err := c.post(url, "application/json", bytes.NewBuffer(data))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", err
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading workflow response: %w", err)
+	}
+	result := &WorkflowResponse{Status: resp.Status, Code: resp.StatusCode, Body: string(body)}
+	var pretty bytes.Buffer
+	if json.Valid(body) && json.Indent(&pretty, body, "", "  ") == nil {
+		result.Body = pretty.String()
+		result.JSON = true
 	}
-	// Return the whole response as a pretty JSON string for now
-	pretty, _ := json.MarshalIndent(result, "", "  ")
-	return string(pretty), nil
+	return result, nil
 }
 
 type Feature struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	Status       string   `json:"status"`
+	Dependencies []string `json:"dependencies,omitempty"` // IDs of features this one depends on
+	// Zero when the server doesn't record them
+	CreatedAt Timestamp `json:"created_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
 }
 
 type FeatureListResponse struct {
@@ -133,29 +632,76 @@ type FeaturesData struct {
 	Refinement      []Feature `json:"refinement"`
 	Backlog         []Feature `json:"backlog"`
 	CurrentFeatures []string  `json:"current_features,omitempty"`
-	// Legacy support for old format  
-	CurrentFeature  string    `json:"current_feature,omitempty"`
+	// Legacy support for old format
+	CurrentFeature string `json:"current_feature,omitempty"`
+}
+
+// FindFeature returns the feature with the given ID in whichever group holds it
+func (d *FeaturesData) FindFeature(featureId string) (*Feature, bool) {
+	for _, group := range []*[]Feature{&d.Approved, &d.Planned, &d.Refinement, &d.Backlog} {
+		for i := range *group {
+			if (*group)[i].ID == featureId {
+				return &(*group)[i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// SetFeatureStatus moves a feature into the group for status, returning it
+// in its new place. It reports false for an unknown feature or status.
+func (d *FeaturesData) SetFeatureStatus(featureId, status string) (*Feature, bool) {
+	groups := map[string]*[]Feature{
+		"approved":   &d.Approved,
+		"planned":    &d.Planned,
+		"refinement": &d.Refinement,
+		"backlog":    &d.Backlog,
+	}
+	target, ok := groups[status]
+	if !ok {
+		return nil, false
+	}
+	for _, group := range groups {
+		for i, feature := range *group {
+			if feature.ID != featureId {
+				continue
+			}
+			if group == target {
+				(*group)[i].Status = status
+				return &(*group)[i], true
+			}
+			feature.Status = status
+			// Copy rather than shift in place so pointers into the old
+			// group (such as the selected feature) stay valid
+			*group = append((*group)[:i:i], (*group)[i+1:]...)
+			*target = append(*target, feature)
+			return &(*target)[len(*target)-1], true
+		}
+	}
+	return nil, false
 }
 
 // GetMCPServerPath discovers the path to the MCP stdio server.
+// Priority: TDDPRO_MCP_PATH → ~/.tdd-pro/bin/tdd-pro-mcp → TDDPRO_PATH dev .ts
+// → a tdd-pro-mcp binary next to the executable → upward search for the .ts source.
 func GetMCPServerPath() (string, error) {
 	// 1. Check TDDPRO_MCP_PATH env var (direct path to binary - for development)
 	mcpPath := os.Getenv("TDDPRO_MCP_PATH")
 	if mcpPath != "" {
-		if _, err := os.Stat(mcpPath); err == nil {
+		if isRunnableServer(mcpPath) {
 			return mcpPath, nil
 		}
 	}
-	
+
 	// 2. Check for installed binary in ~/.tdd-pro/bin (production install)
 	homeDir, err := os.UserHomeDir()
 	if err == nil {
 		binaryPath := filepath.Join(homeDir, ".tdd-pro", "bin", "tdd-pro-mcp")
-		if _, err := os.Stat(binaryPath); err == nil {
+		if isExecutableFile(binaryPath) {
 			return binaryPath, nil
 		}
 	}
-	
+
 	// 3. Check TDDPRO_PATH env var (for development)
 	tddproPath := os.Getenv("TDDPRO_PATH")
 	if tddproPath != "" {
@@ -164,13 +710,16 @@ func GetMCPServerPath() (string, error) {
 			return candidate, nil
 		}
 	}
-	
-	// 4. Fallback: search upward from executable for tdd-pro root
+
+	// 4. Fallback: compiled binary alongside the executable, then search upward for tdd-pro root
 	exePath, err := os.Executable()
 	if err != nil {
 		return "", fmt.Errorf("could not determine executable path: %w", err)
 	}
 	dir := filepath.Dir(exePath)
+	if candidate := filepath.Join(dir, "tdd-pro-mcp"); isExecutableFile(candidate) {
+		return candidate, nil
+	}
 	for i := 0; i < 6; i++ { // search up to 6 levels
 		candidate := filepath.Join(dir, "packages", "tdd-pro", "mcp-stdio-server.ts")
 		if _, err := os.Stat(candidate); err == nil {
@@ -178,62 +727,205 @@ func GetMCPServerPath() (string, error) {
 		}
 		dir = filepath.Dir(dir)
 	}
-	
+
 	return "", fmt.Errorf("Could not find MCP server. Install via 'curl -fsSL https://raw.githubusercontent.com/tdd-pro/tdd-pro/main/install | bash' or set TDDPRO_MCP_PATH for development.")
 }
 
-// ListFeaturesViaStdio uses the mcp-golang client to call the list-features tool via stdio transport
-func (c *MCPClient) ListFeaturesViaStdio() (*FeaturesData, error) {
+// isExecutableFile reports whether path is a regular file with an execute bit set
+func isExecutableFile(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	return info.Mode().Perm()&0111 != 0
+}
+
+// isRunnableServer reports whether path is a compiled server binary or a .ts source we can run
+func isRunnableServer(path string) bool {
+	if strings.HasSuffix(path, ".ts") {
+		_, err := os.Stat(path)
+		return err == nil
+	}
+	return isExecutableFile(path)
+}
+
+// ServerCommand builds the command that launches the MCP server at path.
+// Compiled binaries run directly; .ts sources run directly only when executable
+// (relying on their shebang), otherwise through bun.
+func ServerCommand(path string) (*exec.Cmd, error) {
+	if !strings.HasSuffix(path, ".ts") {
+		if !isExecutableFile(path) {
+			return nil, fmt.Errorf("MCP server at %s is not an executable file", path)
+		}
+		return exec.Command(path), nil
+	}
+	if isExecutableFile(path) {
+		return exec.Command(path), nil
+	}
+	bun, err := exec.LookPath("bun")
+	if err != nil {
+		return nil, fmt.Errorf("MCP server %s is a TypeScript source and requires bun on PATH: %w", path, err)
+	}
+	return exec.Command(bun, "run", path), nil
+}
+
+// connectStdio launches the MCP server and returns an initialized client over
+// its stdio, and release to stop the server once the call is done
+func (c *MCPClient) connectStdio(ctx context.Context) (*mcp.Client, func(), error) {
+	client, _, release, err := c.startStdio(ctx)
+	return client, release, err
+}
+
+// startStdio launches the MCP server and returns the client with the server's
+// Initialize response. The caller must call release when it's done: it closes
+// the server's stdin, then kills and reaps the process.
+func (c *MCPClient) startStdio(ctx context.Context) (client *mcp.Client, init *mcp.InitializeResponse, release func(), err error) {
 	mcpServerPath, err := GetMCPServerPath()
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
+	}
+	cmd, err := ServerCommand(mcpServerPath)
+	if err != nil {
+		return nil, nil, nil, err
 	}
-	cmd := exec.Command(mcpServerPath)
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	if err := cmd.Start(); err != nil {
-		return nil, err
+		return nil, nil, nil, transientError{fmt.Errorf("starting MCP server: %w", err)}
 	}
+	c.procMu.Lock()
+	c.procs = append(c.procs, cmd)
+	c.procMu.Unlock()
 
 	transport := stdio.NewStdioServerTransportWithIO(stdout, stdin)
-	client := mcp.NewClient(transport)
+	release = func() {
+		transport.Close()
+		stdin.Close()
+		c.reap(cmd)
+	}
+	client = mcp.NewClient(transport)
+	init, err = client.Initialize(ctx)
+	if err != nil {
+		release()
+		return nil, nil, nil, transientError{fmt.Errorf("initializing MCP server: %w", err)}
+	}
+	return client, init, release, nil
+}
+
+// reap kills a stdio server and waits for it to exit, unless Shutdown
+// already has
+func (c *MCPClient) reap(cmd *exec.Cmd) {
+	c.procMu.Lock()
+	i := slices.Index(c.procs, cmd)
+	if i >= 0 {
+		c.procs = slices.Delete(c.procs, i, i+1)
+	}
+	c.procMu.Unlock()
+	if i < 0 {
+		return
+	}
+	cmd.Process.Kill()
+	cmd.Wait()
+}
+
+// ListFeaturesViaStdio uses the mcp-golang client to call the list-features tool via stdio transport
+func (c *MCPClient) ListFeaturesViaStdio() (*FeaturesData, error) {
 	ctx := context.Background()
-	if _, err := client.Initialize(ctx); err != nil {
+	client, release, err := c.connectStdio(ctx)
+	if err != nil {
 		return nil, err
 	}
+	defer release()
 	args := map[string]interface{}{"cwd": "."}
 	resp, err := client.CallTool(ctx, "list-features", args)
 	if err != nil {
+		return nil, toolCallError("list-features", err)
+	}
+	if err := toolResultError("list-features", resp); err != nil {
 		return nil, err
 	}
 	var featuresData FeaturesData
 	if len(resp.Content) > 0 && resp.Content[0].TextContent != nil {
 		if err := json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &featuresData); err != nil {
-			return nil, err
+			return nil, toolParseError("list-features", err)
 		}
 	}
-	
-	// Handle legacy current_feature format
-	if featuresData.CurrentFeature != "" && len(featuresData.CurrentFeatures) == 0 {
-		featuresData.CurrentFeatures = []string{featuresData.CurrentFeature}
-		featuresData.CurrentFeature = ""
-	}
-	
+
+	featuresData.migrateCurrentFeature()
 	return &featuresData, nil
 }
 
+// legacyCurrentFeatureOnce limits the current_feature deprecation warning to once per run
+var legacyCurrentFeatureOnce sync.Once
+
+// migrateCurrentFeature folds the legacy current_feature field into
+// CurrentFeatures, keeping the order and dropping duplicates when an index
+// has both
+func (d *FeaturesData) migrateCurrentFeature() {
+	if d.CurrentFeature == "" {
+		return
+	}
+	legacyCurrentFeatureOnce.Do(func() {
+		slog.Warn("features index uses deprecated current_feature; use current_features instead")
+	})
+	merged := make([]string, 0, len(d.CurrentFeatures)+1)
+	seen := map[string]bool{}
+	for _, id := range append(d.CurrentFeatures, d.CurrentFeature) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			merged = append(merged, id)
+		}
+	}
+	d.CurrentFeatures = merged
+	d.CurrentFeature = ""
+}
+
 // Task represents a task for a feature
 type Task struct {
 	ID                 string   `json:"id"`
 	Title              string   `json:"title"`
 	Description        string   `json:"description"`
 	EvaluationCriteria []string `json:"evaluation_criteria"`
+	Status             string   `json:"status"` // pending, in-progress or completed
+	// Story points or hours, whichever the project uses; 0 when not estimated
+	Estimate float64 `json:"estimate,omitempty"`
+	// Test outcome of each criterion, in order, when the server records them
+	CriteriaResults []CriterionStatus `json:"criteria_results,omitempty"`
+}
+
+// TaskCompleted is the status of a finished task
+const TaskCompleted = "completed"
+
+// CriterionStatus is the test outcome of one acceptance criterion
+type CriterionStatus string
+
+const (
+	CriterionPending CriterionStatus = "pending"
+	CriterionPass    CriterionStatus = "pass"
+	CriterionFail    CriterionStatus = "fail"
+)
+
+// CriterionResult returns the test outcome of criterion i, pending when
+// none is recorded
+func (t Task) CriterionResult(i int) CriterionStatus {
+	if i < len(t.CriteriaResults) {
+		switch status := t.CriteriaResults[i]; status {
+		case CriterionPass, CriterionFail:
+			return status
+		}
+	}
+	return CriterionPending
+}
+
+// Completed reports whether the task is done
+func (t Task) Completed() bool {
+	return t.Status == TaskCompleted
 }
 
 // FeatureDetail represents detailed feature information including tasks
@@ -245,48 +937,59 @@ type FeatureDetail struct {
 
 // GetFeatureViaStdio uses the mcp-golang client to call the get-feature tool via stdio transport
 func (c *MCPClient) GetFeatureViaStdio(featureId string) (*FeatureDetail, error) {
-	mcpServerPath, err := GetMCPServerPath()
-	if err != nil {
-		return nil, err
-	}
-	cmd := exec.Command(mcpServerPath)
-	stdin, err := cmd.StdinPipe()
+	ctx := context.Background()
+	client, release, err := c.connectStdio(ctx)
 	if err != nil {
 		return nil, err
 	}
-	stdout, err := cmd.StdoutPipe()
+	defer release()
+	return getFeature(ctx, client, featureId)
+}
+
+// GetFeaturesViaStdio fetches several features over a single server process,
+// avoiding a spawn and initialize per feature. Results are keyed by feature ID.
+func (c *MCPClient) GetFeaturesViaStdio(featureIds []string) (map[string]*FeatureDetail, error) {
+	ctx := context.Background()
+	client, release, err := c.connectStdio(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if err := cmd.Start(); err != nil {
-		return nil, err
+	defer release()
+	details := make(map[string]*FeatureDetail, len(featureIds))
+	for _, id := range featureIds {
+		detail, err := getFeature(ctx, client, id)
+		if err != nil {
+			return nil, fmt.Errorf("get-feature %s: %w", id, err)
+		}
+		details[id] = detail
 	}
+	return details, nil
+}
 
-	transport := stdio.NewStdioServerTransportWithIO(stdout, stdin)
-	client := mcp.NewClient(transport)
-	ctx := context.Background()
-	if _, err := client.Initialize(ctx); err != nil {
-		return nil, err
-	}
+// getFeature calls the get-feature tool on an initialized client
+func getFeature(ctx context.Context, client *mcp.Client, featureId string) (*FeatureDetail, error) {
 	args := map[string]interface{}{
 		"cwd":       ".",
 		"featureId": featureId,
 	}
 	resp, err := client.CallTool(ctx, "get-feature", args)
 	if err != nil {
+		return nil, toolCallError("get-feature", err)
+	}
+	if err := toolResultError("get-feature", resp); err != nil {
 		return nil, err
 	}
-	
-	// Parse the response 
+
+	// Parse the response
 	var featureResponse struct {
 		Tasks []Task `json:"tasks"`
 	}
 	if len(resp.Content) > 0 && resp.Content[0].TextContent != nil {
 		if err := json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &featureResponse); err != nil {
-			return nil, err
+			return nil, toolParseError("get-feature", err)
 		}
 	}
-	
+
 	return &FeatureDetail{
 		ID:    featureId,
 		Tasks: featureResponse.Tasks,
@@ -295,127 +998,294 @@ func (c *MCPClient) GetFeatureViaStdio(featureId string) (*FeatureDetail, error)
 
 // UpdateTaskViaStdio uses the mcp-golang client to call the update-task tool via stdio transport
 func (c *MCPClient) UpdateTaskViaStdio(featureId, taskId string, updates map[string]interface{}) error {
-	mcpServerPath, err := GetMCPServerPath()
+	ctx, cancel := context.WithTimeout(context.Background(), stdioCallTimeout)
+	defer cancel()
+	client, release, err := c.connectStdio(ctx)
 	if err != nil {
 		return err
 	}
-	cmd := exec.Command(mcpServerPath)
-	stdin, err := cmd.StdinPipe()
+	defer release()
+
+	args := map[string]interface{}{
+		"cwd":       ".",
+		"featureId": featureId,
+		"taskId":    taskId,
+		"updates":   updates,
+	}
+
+	resp, err := client.CallTool(ctx, "update-task", args)
 	if err != nil {
-		return err
+		if ctx.Err() != nil {
+			return transientError{fmt.Errorf("update-task timed out after %s: %w", stdioCallTimeout, ctx.Err())}
+		}
+		return toolCallError("update-task", err)
 	}
-	stdout, err := cmd.StdoutPipe()
+
+	return toolResultError("update-task", resp)
+}
+
+// GetTaskTestResultsViaStdio fetches the test outcome of each acceptance
+// criterion of a task via the get-task-test-results tool
+func (c *MCPClient) GetTaskTestResultsViaStdio(featureId, taskId string) ([]CriterionStatus, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), stdioCallTimeout)
+	defer cancel()
+	client, release, err := c.connectStdio(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if err := cmd.Start(); err != nil {
-		return err
+	defer release()
+
+	args := map[string]interface{}{
+		"cwd":       ".",
+		"featureId": featureId,
+		"taskId":    taskId,
 	}
 
-	transport := stdio.NewStdioServerTransportWithIO(stdout, stdin)
-	client := mcp.NewClient(transport)
+	resp, err := client.CallTool(ctx, "get-task-test-results", args)
+	if err != nil {
+		return nil, toolCallError("get-task-test-results", err)
+	}
+	if err := toolResultError("get-task-test-results", resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Content) == 0 || resp.Content[0].TextContent == nil {
+		return nil, toolParseError("get-task-test-results", errors.New("no content returned"))
+	}
+	var result struct {
+		Results []CriterionStatus `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &result); err != nil {
+		return nil, toolParseError("get-task-test-results", fmt.Errorf("parsing test results: %w", err))
+	}
+	return result.Results, nil
+}
+
+// CreateTaskViaStdio adds a new task to a feature via the create-task tool.
+// A task ID is generated when task.ID is empty. Returns the task as created.
+func (c *MCPClient) CreateTaskViaStdio(featureId string, task Task) (Task, error) {
+	if task.ID == "" {
+		task.ID = fmt.Sprintf("task-%d", time.Now().UnixNano())
+	}
+	task.Status = "pending"
 	ctx := context.Background()
-	if _, err := client.Initialize(ctx); err != nil {
+	client, release, err := c.connectStdio(ctx)
+	if err != nil {
+		return Task{}, err
+	}
+	defer release()
+
+	criteria := task.EvaluationCriteria
+	if criteria == nil {
+		criteria = []string{}
+	}
+	taskArgs := map[string]interface{}{
+		"id":                  task.ID,
+		"name":                task.Title,
+		"status":              task.Status,
+		"description":         task.Description,
+		"acceptance_criteria": criteria,
+	}
+	if task.Estimate > 0 {
+		taskArgs["estimate"] = task.Estimate
+	}
+	args := map[string]interface{}{
+		"cwd":       ".",
+		"featureId": featureId,
+		"task":      taskArgs,
+	}
+
+	resp, err := client.CallTool(ctx, "create-task", args)
+	if err != nil {
+		return Task{}, toolCallError("create-task", err)
+	}
+	if err := toolResultError("create-task", resp); err != nil {
+		return Task{}, err
+	}
+	return task, nil
+}
+
+// UpdateFeatureViaStdio changes a feature's name, description or dependencies
+// (a []string of feature IDs replacing the current list) via the update-feature tool
+func (c *MCPClient) UpdateFeatureViaStdio(featureId string, updates map[string]interface{}) error {
+	ctx := context.Background()
+	client, release, err := c.connectStdio(ctx)
+	if err != nil {
 		return err
 	}
-	
+	defer release()
+
 	args := map[string]interface{}{
 		"cwd":       ".",
 		"featureId": featureId,
-		"taskId":    taskId,
 		"updates":   updates,
 	}
-	
-	_, err = client.CallTool(ctx, "update-task", args)
+
+	resp, err := client.CallTool(ctx, "update-feature", args)
 	if err != nil {
-		return err
+		return toolCallError("update-feature", err)
+	}
+
+	// The tool reports failures in its result rather than as an error
+	var result struct {
+		Success bool   `json:"success"`
+		Error   string `json:"error"`
+	}
+	if len(resp.Content) > 0 && resp.Content[0].TextContent != nil {
+		if err := json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &result); err == nil && !result.Success {
+			return &MCPError{Tool: "update-feature", Category: MCPServerError, Message: result.Error}
+		}
 	}
-	
 	return nil
 }
 
-// GetFeatureDocumentViaStdio gets the PRD document for a feature
-func (c *MCPClient) GetFeatureDocumentViaStdio(featureId string) (string, error) {
-	mcpServerPath, err := GetMCPServerPath()
+// CallToolViaStdio calls the named MCP tool with args and returns its text
+// content, for diagnosing server-side tool behavior. cwd defaults to "." like
+// the other calls; everything else is passed through unchanged.
+func (c *MCPClient) CallToolViaStdio(name string, args map[string]interface{}) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), stdioCallTimeout)
+	defer cancel()
+	client, release, err := c.connectStdio(ctx)
 	if err != nil {
 		return "", err
 	}
-	cmd := exec.Command(mcpServerPath)
-	stdin, err := cmd.StdinPipe()
+	defer release()
+
+	if args == nil {
+		args = map[string]interface{}{}
+	}
+	if _, ok := args["cwd"]; !ok {
+		args["cwd"] = "."
+	}
+	resp, err := client.CallTool(ctx, name, args)
 	if err != nil {
-		return "", err
+		return "", toolCallError(name, err)
 	}
-	stdout, err := cmd.StdoutPipe()
+
+	var texts []string
+	for _, content := range resp.Content {
+		if content.TextContent != nil {
+			texts = append(texts, content.TextContent.Text)
+		}
+	}
+	return strings.Join(texts, "\n"), nil
+}
+
+// UpdateFeatureStatusViaStdio moves a feature to another status group
+// (approved, planned, refinement or backlog) via the update-feature-status tool
+func (c *MCPClient) UpdateFeatureStatusViaStdio(featureId, status string) error {
+	ctx := context.Background()
+	client, release, err := c.connectStdio(ctx)
 	if err != nil {
-		return "", err
+		return err
 	}
-	if err := cmd.Start(); err != nil {
-		return "", err
+	defer release()
+
+	args := map[string]interface{}{
+		"cwd":       ".",
+		"featureId": featureId,
+		"status":    status,
 	}
 
-	transport := stdio.NewStdioServerTransportWithIO(stdout, stdin)
-	client := mcp.NewClient(transport)
+	resp, err := client.CallTool(ctx, "update-feature-status", args)
+	if err != nil {
+		return toolCallError("update-feature-status", err)
+	}
+	return toolResultError("update-feature-status", resp)
+}
+
+// CreateFeatureViaStdio creates a feature via the create-feature tool. The
+// server accepts refinement or backlog as the initial status and defaults to
+// refinement.
+func (c *MCPClient) CreateFeatureViaStdio(feature Feature) error {
 	ctx := context.Background()
-	if _, err := client.Initialize(ctx); err != nil {
+	client, release, err := c.connectStdio(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	args := map[string]interface{}{
+		"cwd":         ".",
+		"id":          feature.ID,
+		"name":        feature.Name,
+		"description": feature.Description,
+	}
+	if feature.Status != "" {
+		args["status"] = feature.Status
+	}
+
+	resp, err := client.CallTool(ctx, "create-feature", args)
+	if err != nil {
+		return toolCallError("create-feature", err)
+	}
+
+	// The tool reports failures in its result rather than as an error
+	var result struct {
+		Success bool   `json:"success"`
+		Error   string `json:"error"`
+	}
+	if len(resp.Content) > 0 && resp.Content[0].TextContent != nil {
+		if err := json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &result); err == nil && !result.Success {
+			return &MCPError{Tool: "create-feature", Category: MCPServerError, Message: result.Error}
+		}
+	}
+	return nil
+}
+
+// GetFeatureDocumentViaStdio gets the PRD document for a feature
+func (c *MCPClient) GetFeatureDocumentViaStdio(featureId string) (string, error) {
+	ctx := context.Background()
+	client, release, err := c.connectStdio(ctx)
+	if err != nil {
 		return "", err
 	}
-	
+	defer release()
+
 	args := map[string]interface{}{
 		"cwd":       ".",
 		"featureId": featureId,
 	}
-	
+
 	resp, err := client.CallTool(ctx, "get-feature-document", args)
 	if err != nil {
+		return "", toolCallError("get-feature-document", err)
+	}
+	if err := toolResultError("get-feature-document", resp); err != nil {
 		return "", err
 	}
-	
+
 	// Parse the response
 	if len(resp.Content) > 0 && resp.Content[0].TextContent != nil {
 		var docResponse struct {
 			Content string `json:"content"`
 		}
 		if err := json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &docResponse); err != nil {
-			return "", err
+			return "", toolParseError("get-feature-document", err)
 		}
 		return docResponse.Content, nil
 	}
-	
-	return "", fmt.Errorf("no document content received")
+
+	return "", toolParseError("get-feature-document", errors.New("no document content received"))
 }
 
 // UpdateFeatureDocumentViaStdio updates the PRD document for a feature
 func (c *MCPClient) UpdateFeatureDocumentViaStdio(featureId, content string) error {
-	mcpServerPath, err := GetMCPServerPath()
-	if err != nil {
-		return err
-	}
-	cmd := exec.Command(mcpServerPath)
-	stdin, err := cmd.StdinPipe()
-	if err != nil {
-		return err
-	}
-	stdout, err := cmd.StdoutPipe()
+	ctx := context.Background()
+	client, release, err := c.connectStdio(ctx)
 	if err != nil {
 		return err
 	}
-	if err := cmd.Start(); err != nil {
-		return err
-	}
+	defer release()
 
-	transport := stdio.NewStdioServerTransportWithIO(stdout, stdin)
-	client := mcp.NewClient(transport)
-	ctx := context.Background()
-	if _, err := client.Initialize(ctx); err != nil {
-		return err
-	}
-	
 	args := map[string]interface{}{
 		"cwd":       ".",
 		"featureId": featureId,
 		"content":   content,
 	}
-	
-	_, err = client.CallTool(ctx, "update-feature-document", args)
-	return err
+
+	resp, err := client.CallTool(ctx, "update-feature-document", args)
+	if err != nil {
+		return toolCallError("update-feature-document", err)
+	}
+	return toolResultError("update-feature-document", resp)
 }
@@ -1,9 +1,25 @@
 package mcpclient
 
 import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
 	"testing"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/metoro-io/mcp-golang/transport/stdio"
 )
 
 func TestGetMCPServerPath_TDDPRO_MCP_PATH(t *testing.T) {
@@ -101,3 +117,567 @@ func TestGetMCPServerPath_NotFound(t *testing.T) {
 		t.Fatal("Expected error when server not found, got nil")
 	}
 }
+
+func TestMCPClient_ResendsAfterDroppedConnection(t *testing.T) {
+	var mu sync.Mutex
+	connections := 0
+	received := map[string]int{}
+	resent := make(chan struct{}, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sse", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		connections++
+		session := fmt.Sprintf("s%d", connections)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "event: endpoint\ndata: /message?sessionId=%s\n\n", session)
+		w.(http.Flusher).Flush()
+		if session == "s1" {
+			return // drop the first connection before any reply
+		}
+		select {
+		case <-resent:
+			fmt.Fprint(w, `data: {"result":{"messages":[{"content":"pong"}]}}`+"\n\n")
+			w.(http.Flusher).Flush()
+		case <-r.Context().Done():
+		}
+	})
+	mux.HandleFunc("/message", func(w http.ResponseWriter, r *http.Request) {
+		session := r.URL.Query().Get("sessionId")
+		mu.Lock()
+		received[session]++
+		mu.Unlock()
+		if session == "s2" {
+			resent <- struct{}{}
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c := NewMCPClient(ts.URL)
+	defer c.Close()
+	var states []ConnectionState
+	c.OnStateChange = func(state ConnectionState) {
+		mu.Lock()
+		states = append(states, state)
+		mu.Unlock()
+	}
+	if err := c.SendMessage("tddAgent", "ping"); err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+	reply, err := c.ListenForReply()
+	if err != nil {
+		t.Fatalf("ListenForReply failed: %v", err)
+	}
+	if reply != "pong" {
+		t.Errorf("Expected reply 'pong', got %q", reply)
+	}
+	if c.SessionID != "s2" {
+		t.Errorf("Expected reconnected session s2, got %q", c.SessionID)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if received["s1"] != 1 || received["s2"] != 1 {
+		t.Errorf("Expected message sent once per session, got %v", received)
+	}
+	sawReconnecting := false
+	for _, state := range states {
+		if state == StateReconnecting {
+			sawReconnecting = true
+		}
+	}
+	if !sawReconnecting || c.ConnectionState() != StateConnected {
+		t.Errorf("Expected to pass through reconnecting and end connected, got %v", states)
+	}
+}
+
+func TestMCPClient_SendsConfiguredHeaders(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[string]string{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sse", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen["/sse"] = r.Header.Get("Authorization")
+		mu.Unlock()
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: endpoint\ndata: /message?sessionId=s1\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+	mux.HandleFunc("/message", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen["/message"] = r.Header.Get("Authorization")
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c := NewMCPClient(ts.URL)
+	c.Headers = map[string]string{"Authorization": "Bearer secret"}
+	defer c.Close()
+	if err := c.SendMessage("tddAgent", "ping"); err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, path := range []string{"/sse", "/message"} {
+		if seen[path] != "Bearer secret" {
+			t.Errorf("Expected Authorization header on %s, got %q", path, seen[path])
+		}
+	}
+}
+
+func TestGetMCPServerPath_SkipsNonExecutableBinary(t *testing.T) {
+	dir := t.TempDir()
+	notExecutable := filepath.Join(dir, "tdd-pro-mcp")
+	if err := os.WriteFile(notExecutable, []byte("not a binary"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	os.Unsetenv("TDDPRO_PATH")
+	os.Setenv("TDDPRO_MCP_PATH", notExecutable)
+	defer os.Unsetenv("TDDPRO_MCP_PATH")
+
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", "/non/existent/path")
+	defer os.Setenv("HOME", originalHome)
+
+	if path, err := GetMCPServerPath(); err == nil && path == notExecutable {
+		t.Errorf("Expected non-executable %s to be skipped", notExecutable)
+	}
+}
+
+func TestServerCommand(t *testing.T) {
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "tdd-pro-mcp")
+	if err := os.WriteFile(binary, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("Failed to write binary: %v", err)
+	}
+	cmd, err := ServerCommand(binary)
+	if err != nil {
+		t.Fatalf("Expected binary to be runnable, got error: %v", err)
+	}
+	if cmd.Path != binary {
+		t.Errorf("Expected binary to run directly, got %s", cmd.Path)
+	}
+
+	source := filepath.Join(dir, "mcp-stdio-server.ts")
+	if err := os.WriteFile(source, []byte("console.log('mock')\n"), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+	cmd, err = ServerCommand(source)
+	if err != nil {
+		// Without bun on PATH the error must say what's needed
+		if !strings.Contains(err.Error(), "bun") {
+			t.Errorf("Expected error to mention bun, got: %v", err)
+		}
+		return
+	}
+	if len(cmd.Args) != 3 || cmd.Args[1] != "run" || cmd.Args[2] != source {
+		t.Errorf("Expected .ts source to run via bun, got %v", cmd.Args)
+	}
+}
+
+func TestReplyUsage_Normalize(t *testing.T) {
+	var missing *replyUsage
+	if missing.normalize() != nil {
+		t.Error("Expected nil usage when none reported")
+	}
+	anthropic := &replyUsage{InputTokens: 10, OutputTokens: 20}
+	if u := anthropic.normalize(); u == nil || u.InputTokens != 10 || u.OutputTokens != 20 {
+		t.Errorf("Unexpected Anthropic-style usage: %+v", u)
+	}
+	aiSDK := &replyUsage{PromptTokens: 5, CompletionTokens: 7}
+	if u := aiSDK.normalize(); u == nil || u.InputTokens != 5 || u.OutputTokens != 7 {
+		t.Errorf("Unexpected AI SDK-style usage: %+v", u)
+	}
+}
+
+func TestMCPClient_FallsBackToPollingWhenSSEBlocked(t *testing.T) {
+	var mu sync.Mutex
+	polls := 0
+	sent := false
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sse", func(w http.ResponseWriter, r *http.Request) {
+		// A buffering proxy: the stream never delivers the endpoint event
+		<-r.Context().Done()
+	})
+	mux.HandleFunc("/poll", func(w http.ResponseWriter, r *http.Request) {
+		session := r.URL.Query().Get("sessionId")
+		if session == "" {
+			fmt.Fprint(w, `{"sessionId":"p1"}`)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		polls++
+		if !sent || polls < 3 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		fmt.Fprint(w, `{"result":{"messages":[{"content":"pong"}]}}`)
+	})
+	mux.HandleFunc("/message", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("sessionId") == "p1" {
+			mu.Lock()
+			sent = true
+			mu.Unlock()
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c := NewMCPClient(ts.URL)
+	c.SSETimeout = 50 * time.Millisecond
+	c.PollInterval = time.Millisecond
+	defer c.Close()
+	if err := c.SendMessage("tddAgent", "ping"); err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+	if c.SessionID != "p1" {
+		t.Errorf("Expected polling session p1, got %q", c.SessionID)
+	}
+	reply, err := c.ListenForReply()
+	if err != nil {
+		t.Fatalf("ListenForReply failed: %v", err)
+	}
+	if reply != "pong" {
+		t.Errorf("Expected reply 'pong', got %q", reply)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if polls < 3 {
+		t.Errorf("Expected to keep polling until the reply was ready, polled %d times", polls)
+	}
+}
+
+func TestMCPClient_ForcedPollingSkipsSSE(t *testing.T) {
+	var mu sync.Mutex
+	sseHits := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sse", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		sseHits++
+		mu.Unlock()
+	})
+	mux.HandleFunc("/poll", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("sessionId") == "" {
+			fmt.Fprint(w, `{"sessionId":"p1"}`)
+			return
+		}
+		fmt.Fprint(w, `{"result":{"messages":[{"content":"pong"}]}}`)
+	})
+	mux.HandleFunc("/message", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c := NewMCPClient(ts.URL)
+	c.Transport = TransportPolling
+	defer c.Close()
+	if err := c.SendMessage("tddAgent", "ping"); err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+	if reply, err := c.ListenForReply(); err != nil || reply != "pong" {
+		t.Fatalf("Expected reply 'pong', got %q (err %v)", reply, err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if sseHits != 0 {
+		t.Errorf("Expected no SSE requests when polling is forced, got %d", sseHits)
+	}
+}
+
+func TestNewHTTPClient_TrustsConfiguredCA(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	defer ts.Close()
+
+	get := func(opts TLSOptions) error {
+		client, err := NewHTTPClient(opts)
+		if err != nil {
+			t.Fatalf("NewHTTPClient failed: %v", err)
+		}
+		resp, err := client.Get(ts.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	if err := get(TLSOptions{}); err == nil {
+		t.Error("Expected the self-signed certificate to be rejected by default")
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	if err := os.WriteFile(caFile, certPEM, 0644); err != nil {
+		t.Fatalf("Failed to write CA bundle: %v", err)
+	}
+	if err := get(TLSOptions{CAFile: caFile}); err != nil {
+		t.Errorf("Expected the CA bundle to be trusted, got %v", err)
+	}
+	if err := get(TLSOptions{InsecureSkipVerify: true}); err != nil {
+		t.Errorf("Expected insecure_skip_verify to skip verification, got %v", err)
+	}
+
+	if _, err := NewHTTPClient(TLSOptions{CAFile: filepath.Join(t.TempDir(), "missing.pem")}); err == nil {
+		t.Error("Expected an error for a missing CA bundle")
+	}
+}
+
+func TestMCPClient_SSEOutlivesRequestTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: endpoint\ndata: /message?sessionId=abc\n\n")
+		w.(http.Flusher).Flush()
+		// Send another event after a plain request would have timed out
+		time.Sleep(150 * time.Millisecond)
+		fmt.Fprint(w, "data: late\n\n")
+		w.(http.Flusher).Flush()
+	}))
+	defer ts.Close()
+
+	client, err := NewHTTPClient(TLSOptions{})
+	if err != nil {
+		t.Fatalf("NewHTTPClient failed: %v", err)
+	}
+	client.Timeout = 50 * time.Millisecond
+	c := NewMCPClient(ts.URL)
+	c.HTTP = client
+	if err := c.OpenSSE(); err != nil {
+		t.Fatalf("OpenSSE failed: %v", err)
+	}
+	defer c.Close()
+
+	for c.scanner.Scan() {
+		if c.scanner.Text() == "data: late" {
+			return
+		}
+	}
+	t.Errorf("Expected the SSE stream to outlive the request timeout, got %v", c.scanner.Err())
+}
+
+func TestIsTransient(t *testing.T) {
+	if !IsTransient(transientError{errors.New("exec: no such file")}) {
+		t.Error("Expected a spawn failure to be transient")
+	}
+	if !IsTransient(fmt.Errorf("call: %w", context.DeadlineExceeded)) {
+		t.Error("Expected a timeout to be transient")
+	}
+	if IsTransient(errors.New("task not found")) || IsTransient(nil) {
+		t.Error("Expected tool errors and nil to be permanent")
+	}
+}
+
+func TestFeaturesData_MigratesLegacyCurrentFeature(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want []string
+	}{
+		{"legacy only", `{"current_feature":"user-auth"}`, []string{"user-auth"}},
+		{"new only", `{"current_features":["search","user-auth"]}`, []string{"search", "user-auth"}},
+		{"both, merged", `{"current_features":["search"],"current_feature":"user-auth"}`, []string{"search", "user-auth"}},
+		{"both, deduplicated", `{"current_features":["user-auth","search"],"current_feature":"user-auth"}`, []string{"user-auth", "search"}},
+		{"neither", `{}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data FeaturesData
+			if err := json.Unmarshal([]byte(tt.json), &data); err != nil {
+				t.Fatal(err)
+			}
+			data.migrateCurrentFeature()
+			if !reflect.DeepEqual(data.CurrentFeatures, tt.want) {
+				t.Errorf("CurrentFeatures = %v, want %v", data.CurrentFeatures, tt.want)
+			}
+			if data.CurrentFeature != "" {
+				t.Errorf("Expected the legacy field cleared, got %q", data.CurrentFeature)
+			}
+		})
+	}
+}
+
+func TestMCPClient_CallWorkflowKeepsStatusAndRawBodies(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/workflows/ok", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"runId":"r1","result":{"steps":2}}`)
+	})
+	mux.HandleFunc("/api/workflows/broken", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error: workflow not registered", http.StatusInternalServerError)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	c := NewMCPClient(ts.URL)
+
+	resp, err := c.CallWorkflow("", "ok", map[string]interface{}{"cwd": "."})
+	if err != nil {
+		t.Fatalf("CallWorkflow failed: %v", err)
+	}
+	if resp.Code != http.StatusOK || !resp.JSON || !strings.Contains(resp.Body, "\n  \"runId\": \"r1\"") {
+		t.Errorf("Expected indented JSON with a 200, got %+v", resp)
+	}
+
+	resp, err = c.CallWorkflow("", "broken", nil)
+	if err != nil {
+		t.Fatalf("Expected a non-JSON error body to be returned, got %v", err)
+	}
+	if resp.Status != "500 Internal Server Error" || resp.JSON || !strings.Contains(resp.Body, "workflow not registered") {
+		t.Errorf("Expected the error body verbatim with its status, got %+v", resp)
+	}
+
+	// A separate workflow API is called instead of the MCP API
+	workflows := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"path":"`+r.URL.Path+`"}`)
+	}))
+	defer workflows.Close()
+	resp, err = c.CallWorkflow(workflows.URL, "ok", nil)
+	if err != nil || !strings.Contains(resp.Body, "/api/workflows/ok") {
+		t.Errorf("Expected the call on the workflow URL, got %+v, %v", resp, err)
+	}
+}
+
+func TestMCPClient_CancelledListenAbortsAndSessionRecovers(t *testing.T) {
+	var mu sync.Mutex
+	connections := 0
+	received := map[string]int{}
+	answer := make(chan struct{}, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sse", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		connections++
+		session := fmt.Sprintf("s%d", connections)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "event: endpoint\ndata: /message?sessionId=%s\n\n", session)
+		w.(http.Flusher).Flush()
+		if session == "s1" {
+			<-r.Context().Done() // the agent never answers the first message
+			return
+		}
+		select {
+		case <-answer:
+			fmt.Fprint(w, `data: {"result":{"messages":[{"content":"pong"}]}}`+"\n\n")
+			w.(http.Flusher).Flush()
+		case <-r.Context().Done():
+		}
+	})
+	mux.HandleFunc("/message", func(w http.ResponseWriter, r *http.Request) {
+		session := r.URL.Query().Get("sessionId")
+		mu.Lock()
+		received[session]++
+		mu.Unlock()
+		if session != "s1" {
+			answer <- struct{}{}
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c := NewMCPClient(ts.URL)
+	defer c.Close()
+	if err := c.SendMessage("tddAgent", "ping"); err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	if _, err := c.ListenForReplyContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the listen to be cancelled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected cancelling to abort the read promptly, took %s", elapsed)
+	}
+	if c.SessionID != "" {
+		t.Errorf("Expected the session to be closed after cancelling, got %q", c.SessionID)
+	}
+
+	// The next message opens a fresh session and gets its own reply
+	if err := c.SendMessage("tddAgent", "ping again"); err != nil {
+		t.Fatalf("SendMessage after cancelling failed: %v", err)
+	}
+	if reply, err := c.ListenForReply(); err != nil || reply != "pong" {
+		t.Fatalf("Expected reply 'pong', got %q (err %v)", reply, err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if received["s1"] != 1 || received["s2"] != 1 {
+		t.Errorf("Expected the cancelled message not to be resent, got %v", received)
+	}
+}
+
+// TestHelperMCPServer is the MCP server launched by the stdio tests, recording
+// its pid in $TDDPRO_TEST_MCP_SERVER. Run normally it does nothing.
+func TestHelperMCPServer(t *testing.T) {
+	pids := os.Getenv("TDDPRO_TEST_MCP_SERVER")
+	if pids == "" {
+		return
+	}
+	os.WriteFile(filepath.Join(pids, strconv.Itoa(os.Getpid())), nil, 0644)
+	type args struct {
+		Cwd       string `json:"cwd"`
+		FeatureId string `json:"featureId"`
+	}
+	server := mcp.NewServer(stdio.NewStdioServerTransport())
+	server.RegisterTool("list-features", "Lists features", func(args) (*mcp.ToolResponse, error) {
+		return mcp.NewToolResponse(mcp.NewTextContent(`{"approved":[{"id":"login","name":"Login"}]}`)), nil
+	})
+	server.RegisterTool("get-feature", "Gets a feature", func(args) (*mcp.ToolResponse, error) {
+		return mcp.NewToolResponse(mcp.NewTextContent(`{"tasks":[]}`)), nil
+	})
+	if err := server.Serve(); err != nil {
+		os.Exit(1)
+	}
+	select {}
+}
+
+func TestMCPClient_StdioCallsReapTheirServers(t *testing.T) {
+	pids := t.TempDir()
+	server := filepath.Join(t.TempDir(), "tdd-pro-mcp")
+	script := fmt.Sprintf("#!/bin/sh\nTDDPRO_TEST_MCP_SERVER=%s exec %s -test.run='^TestHelperMCPServer$'\n", pids, os.Args[0])
+	if err := os.WriteFile(server, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TDDPRO_MCP_PATH", server)
+
+	// Each auto-refresh tick lists the features, then fetches their details
+	c := &MCPClient{}
+	const ticks = 3
+	for i := 0; i < ticks; i++ {
+		if _, err := c.ListFeaturesViaStdio(); err != nil {
+			t.Fatalf("list-features: %v", err)
+		}
+		if _, err := c.GetFeaturesViaStdio([]string{"login"}); err != nil {
+			t.Fatalf("get-feature: %v", err)
+		}
+	}
+
+	if len(c.procs) != 0 {
+		t.Errorf("Expected no tracked servers once the calls returned, got %d", len(c.procs))
+	}
+	started, err := os.ReadDir(pids)
+	if err != nil || len(started) != 2*ticks {
+		t.Fatalf("Expected %d servers started, got %d (%v)", 2*ticks, len(started), err)
+	}
+	for _, entry := range started {
+		pid, _ := strconv.Atoi(entry.Name())
+		if proc, err := os.FindProcess(pid); err == nil && proc.Signal(syscall.Signal(0)) == nil {
+			t.Errorf("Expected server %d to have exited", pid)
+		}
+	}
+}
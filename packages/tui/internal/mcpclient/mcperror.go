@@ -0,0 +1,133 @@
+package mcpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+// MCPErrorCategory says why an MCP tool call failed
+type MCPErrorCategory string
+
+const (
+	MCPToolNotFound MCPErrorCategory = "tool not found"    // the server has no such tool, usually an outdated server
+	MCPInvalidArgs  MCPErrorCategory = "invalid arguments" // the server rejected the arguments
+	MCPServerError  MCPErrorCategory = "server error"      // the tool or the server failed
+	MCPParseError   MCPErrorCategory = "parse error"       // the request or the tool's result couldn't be parsed
+)
+
+// JSON-RPC error codes MCP servers answer with
+const (
+	rpcParseError     = -32700
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+// MCPError is a failed MCP tool call, returned by the *ViaStdio methods.
+// Use errors.As to tell the categories apart.
+type MCPError struct {
+	Tool     string
+	Category MCPErrorCategory
+	Message  string // what the server said, or why its result couldn't be read
+	Code     int    // JSON-RPC error code, 0 when the failure wasn't an RPC error
+	Err      error  // underlying error, if any
+}
+
+func (e *MCPError) Error() string {
+	return fmt.Sprintf("%s failed (%s): %s", e.Tool, e.Category, e.Message)
+}
+
+func (e *MCPError) Unwrap() error { return e.Err }
+
+// rpcErrorPattern matches JSON-RPC errors as mcp-golang reports them ("RPC
+// error -32602: ...") and as servers echo them in tool results ("MCP error -32602: ...")
+var rpcErrorPattern = regexp.MustCompile(`(?:RPC|MCP) error (-?\d+): (.*)`)
+
+// toolCallError classifies an error from client.CallTool. Cancellations and
+// timeouts are returned as they are so callers can still recognize them.
+func toolCallError(tool string, err error) error {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	if mcpErr := rpcError(tool, err.Error()); mcpErr != nil {
+		mcpErr.Err = err
+		return mcpErr
+	}
+	category := MCPServerError
+	if strings.Contains(err.Error(), "failed to unmarshal tool response") {
+		category = MCPParseError
+	}
+	return &MCPError{Tool: tool, Category: category, Message: err.Error(), Err: err}
+}
+
+// rpcError parses a JSON-RPC error out of text, nil if there is none
+func rpcError(tool, text string) *MCPError {
+	match := rpcErrorPattern.FindStringSubmatch(text)
+	if match == nil {
+		return nil
+	}
+	code, _ := strconv.Atoi(match[1])
+	message := strings.TrimSpace(match[2])
+	category := MCPServerError
+	switch code {
+	case rpcMethodNotFound:
+		category = MCPToolNotFound
+	case rpcInvalidParams:
+		category = MCPInvalidArgs
+		// Servers built on the TypeScript SDK report unknown tools as invalid params
+		if lower := strings.ToLower(message); strings.Contains(lower, "tool") && strings.Contains(lower, "not found") {
+			category = MCPToolNotFound
+		}
+	case rpcParseError:
+		category = MCPParseError
+	}
+	return &MCPError{Tool: tool, Category: category, Message: message, Code: code}
+}
+
+// toolResultError returns the failure a tool reported in its result rather
+// than as an RPC error: {"success": false, "error": "..."}, or an MCP error
+// message as the text. nil when the result isn't a failure.
+func toolResultError(tool string, resp *mcp.ToolResponse) error {
+	text := toolText(resp)
+	if text == "" {
+		return nil
+	}
+	var result struct {
+		Success *bool  `json:"success"`
+		Error   string `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(text), &result); err == nil {
+		if result.Success != nil && !*result.Success {
+			if mcpErr := rpcError(tool, result.Error); mcpErr != nil {
+				return mcpErr
+			}
+			return &MCPError{Tool: tool, Category: MCPServerError, Message: result.Error}
+		}
+		return nil
+	}
+	if strings.HasPrefix(text, "MCP error ") {
+		if mcpErr := rpcError(tool, text); mcpErr != nil {
+			return mcpErr
+		}
+	}
+	return nil
+}
+
+// toolText returns the text of a tool result's first content, "" if it has none
+func toolText(resp *mcp.ToolResponse) string {
+	if resp == nil || len(resp.Content) == 0 || resp.Content[0].TextContent == nil {
+		return ""
+	}
+	return resp.Content[0].TextContent.Text
+}
+
+// toolParseError reports a tool result that couldn't be decoded
+func toolParseError(tool string, err error) error {
+	return &MCPError{Tool: tool, Category: MCPParseError, Message: err.Error(), Err: err}
+}
@@ -0,0 +1,82 @@
+package mcpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	mcp "github.com/metoro-io/mcp-golang"
+)
+
+func TestToolCallError_Categories(t *testing.T) {
+	cases := []struct {
+		err      string
+		category MCPErrorCategory
+		code     int
+		message  string
+	}{
+		{"failed to call tool: RPC error -32601: Method not found", MCPToolNotFound, -32601, "Method not found"},
+		{"failed to call tool: RPC error -32602: Tool archive-feature not found", MCPToolNotFound, -32602, "Tool archive-feature not found"},
+		{"failed to call tool: RPC error -32602: featureId is required", MCPInvalidArgs, -32602, "featureId is required"},
+		{"failed to call tool: RPC error -32700: Parse error", MCPParseError, -32700, "Parse error"},
+		{"failed to call tool: RPC error -32603: ENOENT: no such file", MCPServerError, -32603, "ENOENT: no such file"},
+		{"failed to unmarshal tool response: unexpected end of JSON input", MCPParseError, 0, "failed to unmarshal tool response: unexpected end of JSON input"},
+		{"transport closed", MCPServerError, 0, "transport closed"},
+	}
+	for _, c := range cases {
+		cause := errors.New(c.err)
+		err := toolCallError("update-task", cause)
+		var mcpErr *MCPError
+		if !errors.As(err, &mcpErr) {
+			t.Fatalf("Expected an MCPError for %q, got %v", c.err, err)
+		}
+		if mcpErr.Tool != "update-task" || mcpErr.Category != c.category || mcpErr.Code != c.code || mcpErr.Message != c.message {
+			t.Errorf("toolCallError(%q) = %+v, want %s %d %q", c.err, mcpErr, c.category, c.code, c.message)
+		}
+		if !errors.Is(err, cause) {
+			t.Errorf("Expected %q to wrap the CallTool error", c.err)
+		}
+	}
+
+	// Timeouts stay recognizable as such
+	timeout := fmt.Errorf("failed to call tool: %w", context.DeadlineExceeded)
+	if err := toolCallError("get-feature", timeout); err != timeout || !IsTransient(err) {
+		t.Errorf("Expected a timeout to be returned as is, got %v", err)
+	}
+	if toolCallError("get-feature", nil) != nil {
+		t.Error("Expected nil for a successful call")
+	}
+}
+
+func TestToolResultError(t *testing.T) {
+	result := func(text string) *mcp.ToolResponse {
+		return mcp.NewToolResponse(mcp.NewTextContent(text))
+	}
+	if err := toolResultError("update-task", result(`{"success": true}`)); err != nil {
+		t.Errorf("Expected success to be no error, got %v", err)
+	}
+	if err := toolResultError("get-feature", result(`{"tasks": []}`)); err != nil {
+		t.Errorf("Expected a result without success to be no error, got %v", err)
+	}
+	if err := toolResultError("get-feature", mcp.NewToolResponse()); err != nil {
+		t.Errorf("Expected an empty result to be no error, got %v", err)
+	}
+
+	err := toolResultError("update-task", result(`{"success": false, "error": "Task task-9 not found"}`))
+	var mcpErr *MCPError
+	if !errors.As(err, &mcpErr) || mcpErr.Category != MCPServerError || mcpErr.Message != "Task task-9 not found" {
+		t.Errorf("Expected the reported failure as a server error, got %v", err)
+	}
+	if err.Error() != "update-task failed (server error): Task task-9 not found" {
+		t.Errorf("Unexpected message %q", err.Error())
+	}
+
+	err = toolResultError("create-task", result("MCP error -32602: Invalid arguments for tool create-task: task.name is required"))
+	if !errors.As(err, &mcpErr) || mcpErr.Category != MCPInvalidArgs || mcpErr.Code != -32602 {
+		t.Errorf("Expected an MCP error text parsed as invalid arguments, got %v", err)
+	}
+	if err := toolResultError("get-feature-document", result("# Plain PRD text")); err != nil {
+		t.Errorf("Expected plain text to be no error, got %v", err)
+	}
+}
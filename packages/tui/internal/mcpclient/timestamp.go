@@ -0,0 +1,40 @@
+package mcpclient
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Timestamp is a time reported by the MCP server. It decodes leniently: a
+// missing, null or unparseable value is the zero time rather than an error,
+// so one odd entry in index.yml can't fail a whole feature listing.
+type Timestamp struct {
+	time.Time
+}
+
+// timestampLayouts are the formats accepted besides RFC 3339
+var timestampLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", time.DateOnly}
+
+// UnmarshalJSON accepts RFC 3339 times and plain dates
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	t.Time = time.Time{}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil
+	}
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return nil
+}
+
+// MarshalJSON writes the zero time as null instead of 0001-01-01
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Time)
+}
@@ -0,0 +1,91 @@
+package mcpclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// RequiredTools are the MCP tools the TUI calls. A server missing any of them
+// is too old (or too new) for this build.
+var RequiredTools = []string{
+	"list-features",
+	"get-feature",
+	"create-task",
+	"update-task",
+	"get-task-test-results",
+	"get-feature-document",
+	"update-feature-document",
+	"update-feature-status",
+	"update-feature",
+	"create-feature",
+}
+
+// ServerInfo is what the MCP server reports about itself on Initialize
+type ServerInfo struct {
+	Path            string // server binary or source that was launched
+	Name            string
+	Version         string
+	ProtocolVersion string
+	Tools           []string
+}
+
+// ServerInfoViaStdio launches the MCP server and reports its name, version,
+// negotiated protocol version and tools
+func (c *MCPClient) ServerInfoViaStdio() (*ServerInfo, error) {
+	path, err := GetMCPServerPath()
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	client, init, release, err := c.startStdio(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	info := &ServerInfo{
+		Path:            path,
+		Name:            init.ServerInfo.Name,
+		Version:         init.ServerInfo.Version,
+		ProtocolVersion: init.ProtocolVersion,
+	}
+	tools, err := client.ListTools(ctx, nil)
+	if err != nil {
+		return info, fmt.Errorf("failed to list tools: %w", err)
+	}
+	for _, tool := range tools.Tools {
+		info.Tools = append(info.Tools, tool.Name)
+	}
+	return info, nil
+}
+
+// CheckCompatibility lists the ways the server doesn't match this TUI build:
+// required tools it lacks, and a version that differs from clientVersion.
+// Development builds ("dev" or empty) skip the version comparison.
+func CheckCompatibility(info *ServerInfo, clientVersion string) []string {
+	var problems []string
+	available := make(map[string]bool, len(info.Tools))
+	for _, tool := range info.Tools {
+		available[tool] = true
+	}
+	var missing []string
+	for _, tool := range RequiredTools {
+		if !available[tool] {
+			missing = append(missing, tool)
+		}
+	}
+	if len(missing) > 0 {
+		problems = append(problems, "missing tools: "+strings.Join(missing, ", "))
+	}
+
+	server := strings.TrimPrefix(info.Version, "v")
+	client := strings.TrimPrefix(clientVersion, "v")
+	if !isDevVersion(server) && !isDevVersion(client) && server != client {
+		problems = append(problems, fmt.Sprintf("server version %s does not match TUI version %s", info.Version, clientVersion))
+	}
+	return problems
+}
+
+func isDevVersion(version string) bool {
+	return version == "" || version == "dev"
+}
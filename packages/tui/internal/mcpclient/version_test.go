@@ -0,0 +1,41 @@
+package mcpclient
+
+import (
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	complete := &ServerInfo{Version: "1.2.0", Tools: append([]string{"get-tasks"}, RequiredTools...)}
+	if problems := CheckCompatibility(complete, "v1.2.0"); len(problems) != 0 {
+		t.Errorf("Expected matching versions and tools to be compatible, got %v", problems)
+	}
+	if problems := CheckCompatibility(&ServerInfo{Version: "dev", Tools: RequiredTools}, "1.2.0"); len(problems) != 0 {
+		t.Errorf("Expected dev builds to skip the version check, got %v", problems)
+	}
+
+	problems := CheckCompatibility(&ServerInfo{Version: "1.1.0", Tools: []string{"list-features"}}, "1.2.0")
+	if len(problems) != 2 {
+		t.Fatalf("Expected missing tools and version mismatch, got %v", problems)
+	}
+	if !strings.Contains(problems[0], "get-feature") || !strings.Contains(problems[1], "1.1.0") {
+		t.Errorf("Unexpected problems: %v", problems)
+	}
+}
+
+// Every tool the client calls must be checked for, or an older server passes
+// /status and then fails at runtime
+func TestRequiredTools_CoverEveryToolCalled(t *testing.T) {
+	source, err := os.ReadFile("mcpclient.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, call := range regexp.MustCompile(`CallTool\(ctx, "([a-z-]+)"`).FindAllSubmatch(source, -1) {
+		if tool := string(call[1]); !slices.Contains(RequiredTools, tool) {
+			t.Errorf("Expected %s in RequiredTools", tool)
+		}
+	}
+}
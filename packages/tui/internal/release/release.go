@@ -0,0 +1,177 @@
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LatestURL is the GitHub API endpoint for the newest published release, the
+// same one the install script downloads from
+const LatestURL = "https://api.github.com/repos/tdd-pro/tdd-pro/releases/latest"
+
+// CheckTimeout bounds the whole release lookup
+const CheckTimeout = 5 * time.Second
+
+// ErrDevBuild is returned by Check for development builds, which have no
+// version to compare
+var ErrDevBuild = errors.New("development build")
+
+// checkClient honors HTTPS_PROXY/HTTP_PROXY through the default transport
+var checkClient = &http.Client{Timeout: CheckTimeout}
+
+// Result is the outcome of comparing the running build with the latest release
+type Result struct {
+	Current         string
+	Latest          string
+	UpdateAvailable bool
+}
+
+// String reports the result the way the TUI shows it
+func (r Result) String() string {
+	if r.UpdateAvailable {
+		return fmt.Sprintf("Update available: %s (running %s)", r.Latest, r.Current)
+	}
+	return fmt.Sprintf("Up to date (%s)", r.Current)
+}
+
+// IsDevBuild reports whether version was stamped on a development build
+// rather than a release: "dev", anything that isn't major.minor.patch (such as
+// a bare commit hash from git describe --always), v0.0.0 or a -dev pre-release
+// like scripts/build.sh's v0.0.0-dev.
+func IsDevBuild(version string) bool {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version, _, _ = strings.Cut(version, "+")
+	if strings.HasSuffix(version, "-dev") {
+		return true
+	}
+	core, _, _ := strings.Cut(version, "-")
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return true
+	}
+	for _, part := range parts {
+		if _, err := strconv.Atoi(part); err != nil {
+			return true
+		}
+	}
+	return core == "0.0.0"
+}
+
+// Check compares current with the latest release at url (normally LatestURL)
+func Check(ctx context.Context, url, current string) (Result, error) {
+	if IsDevBuild(current) {
+		return Result{}, ErrDevBuild
+	}
+	latest, err := Latest(ctx, url)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Current: current, Latest: latest, UpdateAvailable: Compare(latest, current) > 0}, nil
+}
+
+// Latest returns the tag of the newest release at url
+func Latest(ctx context.Context, url string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, CheckTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := checkClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("checking latest release: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("checking latest release: GitHub returned %s", resp.Status)
+	}
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", fmt.Errorf("checking latest release: %w", err)
+	}
+	if release.TagName == "" {
+		return "", errors.New("checking latest release: no tag in response")
+	}
+	return release.TagName, nil
+}
+
+// Compare orders two semantic versions, with or without a leading v: -1 if
+// a is older than b, 0 if equal and 1 if newer. A pre-release sorts before
+// its release; build metadata is ignored.
+func Compare(a, b string) int {
+	aCore, aPre := splitVersion(a)
+	bCore, bPre := splitVersion(b)
+	for i := 0; i < 3; i++ {
+		if aCore[i] != bCore[i] {
+			if aCore[i] < bCore[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+	return comparePreRelease(aPre, bPre)
+}
+
+// splitVersion parses major.minor.patch, treating missing or malformed
+// numbers as 0, and returns the pre-release part
+func splitVersion(version string) ([3]int, string) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version, _, _ = strings.Cut(version, "+")
+	version, pre, _ := strings.Cut(version, "-")
+	var core [3]int
+	for i, part := range strings.SplitN(version, ".", 3) {
+		core[i], _ = strconv.Atoi(part)
+	}
+	return core, pre
+}
+
+// comparePreRelease orders dot-separated pre-release identifiers: numeric
+// ones numerically and below alphanumeric ones, the rest lexically
+func comparePreRelease(a, b string) int {
+	aIDs, bIDs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aIDs) && i < len(bIDs); i++ {
+		aNum, aErr := strconv.Atoi(aIDs[i])
+		bNum, bErr := strconv.Atoi(bIDs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNum != bNum {
+				if aNum < bNum {
+					return -1
+				}
+				return 1
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(aIDs[i], bIDs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	switch {
+	case len(aIDs) < len(bIDs):
+		return -1
+	case len(aIDs) > len(bIDs):
+		return 1
+	}
+	return 0
+}
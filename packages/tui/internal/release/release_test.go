@@ -0,0 +1,85 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.2.3", "1.2.3", 0},
+		{"v1.10.0", "v1.9.9", 1},
+		{"v1.2.3", "v2.0.0", -1},
+		{"v1.2.3-rc.1", "v1.2.3", -1},
+		{"v1.2.3-rc.10", "v1.2.3-rc.2", 1},
+		{"v1.2.3-alpha", "v1.2.3-alpha.1", -1},
+		{"v1.2.3+build.5", "v1.2.3", 0},
+		{"v1.2", "v1.2.0", 0},
+	}
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tag_name": "v1.3.0"}`))
+	}))
+	defer server.Close()
+
+	result, err := Check(context.Background(), server.URL, "v1.2.0")
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if !result.UpdateAvailable || result.String() != "Update available: v1.3.0 (running v1.2.0)" {
+		t.Errorf("Expected an update, got %+v", result)
+	}
+
+	result, err = Check(context.Background(), server.URL, "1.3.0")
+	if err != nil || result.UpdateAvailable || result.String() != "Up to date (1.3.0)" {
+		t.Errorf("Expected up to date, got %+v, %v", result, err)
+	}
+
+	if _, err := Check(context.Background(), "http://127.0.0.1:1", "dev"); !errors.Is(err, ErrDevBuild) {
+		t.Errorf("Expected dev builds to be skipped without a request, got %v", err)
+	}
+}
+
+func TestIsDevBuild(t *testing.T) {
+	for version, want := range map[string]bool{
+		"":                 true,
+		"dev":              true,
+		"v0.0.0-dev":       true,
+		"v1.3.0-dev":       true,
+		"v0.0.0-1a2b3c4":   true,
+		"1a2b3c4":          true,
+		"v1.2":             true,
+		"v1.2.0":           false,
+		"1.2.0-rc.1":       false,
+		"v1.2.0-3-g1a2b3c": false,
+		"v1.2.0+build.5":   false,
+	} {
+		if got := IsDevBuild(version); got != want {
+			t.Errorf("IsDevBuild(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestLatest_ReportsHTTPErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	if _, err := Latest(context.Background(), server.URL); err == nil {
+		t.Error("Expected an error for a non-200 response")
+	}
+}
@@ -3,11 +3,13 @@ package streams
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"strings"
+	"time"
 )
 
 type WorkflowRun struct {
@@ -17,68 +19,260 @@ type WorkflowRun struct {
 	Events        chan WorkflowEvent
 	Done          chan struct{}
 	ThinkingState []string // last 3 thinking messages
+	// Err is why Watch stopped early (request failed, bad status or the
+	// connection dropped mid-stream). It is set before Events is closed and
+	// stays nil when the stream ended normally.
+	Err     error
+	headers http.Header
+	client  *http.Client
+	ctx     context.Context // cancelled by Cancel; nil for runs built by hand
+	cancel  context.CancelFunc
 	// ... other state as needed
 }
 
+// Option configures how a WorkflowRun talks to the backend
+type Option func(*options)
+
+// DefaultBaseURL is the Mastra dev server, which serves the workflow API
+const DefaultBaseURL = "http://localhost:4111"
+
+type options struct {
+	baseURL string
+	headers http.Header
+	client  *http.Client
+}
+
+// WithBaseURL points the run at a workflow API other than DefaultBaseURL
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		if baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/"); baseURL != "" {
+			o.baseURL = baseURL
+		}
+	}
+}
+
+// WithHeaders adds headers (e.g. Authorization) to every request made for the run
+func WithHeaders(headers map[string]string) Option {
+	return func(o *options) {
+		for key, value := range headers {
+			o.headers.Set(key, value)
+		}
+	}
+}
+
+// WithHTTPClient sends the run's requests with client, e.g. one configured
+// with a custom CA, instead of http.DefaultClient
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		if client != nil {
+			o.client = client
+		}
+	}
+}
+
+func buildOptions(opts []Option) options {
+	o := options{baseURL: DefaultBaseURL, headers: http.Header{}, client: http.DefaultClient}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// newRequest builds a request carrying the given headers
+func newRequest(ctx context.Context, method, url string, body io.Reader, headers http.Header) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
 type WorkflowEvent struct {
 	Type    string
 	Payload json.RawMessage // or a more specific struct
 }
 
-func NewWorkflowRun(cwd string) (*WorkflowRun, error) {
+func NewWorkflowRun(cwd string, opts ...Option) (*WorkflowRun, error) {
+	o := buildOptions(opts)
 	// 1. POST to create-run, get runId
-	createRunURL := "http://localhost:4111/api/workflows/tddPlanning/create-run"
-	resp, err := http.Post(createRunURL, "application/json", bytes.NewBuffer([]byte("{}")))
+	workflowURL := o.baseURL + "/api/workflows/tddPlanning"
+	runId, err := createRun(o, workflowURL+"/create-run")
 	if err != nil {
-		return nil, fmt.Errorf("failed to create run: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
-	respBody, _ := ioutil.ReadAll(resp.Body)
-	var createRunResult map[string]interface{}
-	if err := json.Unmarshal(respBody, &createRunResult); err != nil {
-		return nil, fmt.Errorf("failed to parse create-run response: %w", err)
-	}
-	runId, ok := createRunResult["runId"].(string)
-	if !ok {
-		return nil, fmt.Errorf("runId not found in create-run response: %s", string(respBody))
-	}
-	watchURL := fmt.Sprintf("http://localhost:4111/api/workflows/tddPlanning/watch?runId=%s", runId)
-	startURL := fmt.Sprintf("http://localhost:4111/api/workflows/tddPlanning/start?runId=%s", runId)
+	watchURL := fmt.Sprintf("%s/watch?runId=%s", workflowURL, runId)
+	startURL := fmt.Sprintf("%s/start?runId=%s", workflowURL, runId)
+	ctx, cancel := context.WithCancel(context.Background())
 	return &WorkflowRun{
 		RunID:    runId,
 		WatchURL: watchURL,
 		StartURL: startURL,
 		Events:   make(chan WorkflowEvent, 10),
 		Done:     make(chan struct{}),
+		headers:  o.headers,
+		client:   o.client,
+		ctx:      ctx,
+		cancel:   cancel,
 	}, nil
 }
 
+// createRunAttempts is how many times create-run is tried before giving up,
+// and createRunRetryDelay the pause before the first retry (doubled after
+// each one). Retries cover the backend still starting up.
+var (
+	createRunAttempts   = 3
+	createRunRetryDelay = 500 * time.Millisecond
+)
+
+// bodySnippetLen caps how much of a response body is quoted in an error
+const bodySnippetLen = 200
+
+// createRun POSTs to create-run and returns the new run's ID, retrying with
+// backoff while the backend is unreachable or answers with a 5xx
+func createRun(o options, url string) (string, error) {
+	delay := createRunRetryDelay
+	var err error
+	for attempt := 1; ; attempt++ {
+		var runID string
+		var retry bool
+		runID, retry, err = tryCreateRun(o, url)
+		if err == nil {
+			return runID, nil
+		}
+		if !retry || attempt >= createRunAttempts {
+			break
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+	return "", err
+}
+
+// tryCreateRun makes one create-run request, reporting whether a failure is
+// worth retrying
+func tryCreateRun(o options, url string) (runID string, retry bool, err error) {
+	req, err := newRequest(context.Background(), http.MethodPost, url, bytes.NewBuffer([]byte("{}")), o.headers)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create run: %w", err)
+	}
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return "", true, fmt.Errorf("failed to create run (is the backend running at %s?): %w", o.baseURL, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", true, fmt.Errorf("failed to read create-run response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", resp.StatusCode >= 500, fmt.Errorf("failed to create run: %s: %s", resp.Status, bodySnippet(body))
+	}
+	var result struct {
+		RunID json.RawMessage `json:"runId"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", false, fmt.Errorf("failed to parse create-run response (%s): %s", resp.Status, bodySnippet(body))
+	}
+	if err := json.Unmarshal(result.RunID, &runID); err != nil || runID == "" {
+		return "", false, fmt.Errorf("create-run response (%s) has no runId: %s", resp.Status, bodySnippet(body))
+	}
+	return runID, false, nil
+}
+
+// bodySnippet trims a response body to a length that fits in an error message
+func bodySnippet(body []byte) string {
+	text := strings.TrimSpace(string(body))
+	if text == "" {
+		return "(empty body)"
+	}
+	if len(text) > bodySnippetLen {
+		return text[:bodySnippetLen] + "..."
+	}
+	return text
+}
+
+// httpClient returns the client the run was created with, or
+// http.DefaultClient for runs built by hand
+func (wr *WorkflowRun) httpClient() *http.Client {
+	if wr.client != nil {
+		return wr.client
+	}
+	return http.DefaultClient
+}
+
+// context returns the context the run's requests are made with
+func (wr *WorkflowRun) context() context.Context {
+	if wr.ctx != nil {
+		return wr.ctx
+	}
+	return context.Background()
+}
+
+// Cancel aborts the run's requests, ending Watch without setting Err
+func (wr *WorkflowRun) Cancel() {
+	if wr.cancel != nil {
+		wr.cancel()
+	}
+}
+
 func (wr *WorkflowRun) Watch() {
 	go func() {
-		resp, err := http.Get(wr.WatchURL)
-		if err != nil {
-			close(wr.Events)
-			return
+		defer close(wr.Events)
+		wr.Err = wr.watch()
+	}()
+}
+
+// watch streams events into wr.Events, returning an error if the stream didn't end cleanly
+func (wr *WorkflowRun) watch() error {
+	req, err := newRequest(wr.context(), http.MethodGet, wr.WatchURL, nil, wr.headers)
+	if err != nil {
+		return fmt.Errorf("failed to watch workflow: %w", err)
+	}
+	// The stream lasts as long as the run, so drop any overall request
+	// timeout; the transport's connect and header timeouts still apply
+	stream := *wr.httpClient()
+	stream.Timeout = 0
+	resp, err := stream.Do(req)
+	if err != nil {
+		if wr.context().Err() != nil {
+			return nil // stopped by Cancel
 		}
-		defer resp.Body.Close()
-		reader := bufio.NewReader(resp.Body)
-		for {
-			chunk, err := reader.ReadString('\x1e')
-			if err != nil {
-				break
-			}
-			chunk = strings.TrimSuffix(chunk, "\x1e")
-			chunk = strings.TrimSpace(chunk)
-			if chunk == "" {
-				continue
-			}
-			var evt WorkflowEvent
-			if err := json.Unmarshal([]byte(chunk), &evt); err == nil {
-				wr.Events <- evt
+		return fmt.Errorf("failed to watch workflow: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to watch workflow: %s", resp.Status)
+	}
+	reader := bufio.NewReader(resp.Body)
+	for {
+		chunk, err := reader.ReadString('\x1e')
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if wr.context().Err() != nil {
+				return nil // stopped by Cancel
 			}
+			return fmt.Errorf("workflow stream interrupted: %w", err)
 		}
-		close(wr.Events)
-	}()
+		chunk = strings.TrimSuffix(chunk, "\x1e")
+		chunk = strings.TrimSpace(chunk)
+		if chunk == "" {
+			continue
+		}
+		var evt WorkflowEvent
+		if err := json.Unmarshal([]byte(chunk), &evt); err == nil {
+			wr.Events <- evt
+		}
+	}
 }
 
 func (wr *WorkflowRun) StartWorkflow(cwd string) error {
@@ -89,7 +283,11 @@ func (wr *WorkflowRun) StartWorkflow(cwd string) error {
 		"runtimeContext": map[string]interface{}{},
 	}
 	jsonBody, _ := json.Marshal(body)
-	resp, err := http.Post(wr.StartURL, "application/json", bytes.NewBuffer(jsonBody))
+	req, err := newRequest(wr.context(), http.MethodPost, wr.StartURL, bytes.NewBuffer(jsonBody), wr.headers)
+	if err != nil {
+		return fmt.Errorf("failed to start workflow: %w", err)
+	}
+	resp, err := wr.httpClient().Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to start workflow: %w", err)
 	}
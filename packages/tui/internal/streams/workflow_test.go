@@ -9,6 +9,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 )
 
 type testEvent struct {
@@ -102,6 +103,9 @@ func TestWorkflowRun_StreamParsingAndLifecycle(t *testing.T) {
 
 	wg.Wait()
 
+	if wr.Err != nil {
+		t.Errorf("expected a clean end of stream, got %v", wr.Err)
+	}
 	if len(gotEvents) != len(events) {
 		t.Fatalf("expected %d events, got %d", len(events), len(gotEvents))
 	}
@@ -113,3 +117,170 @@ func TestWorkflowRun_StreamParsingAndLifecycle(t *testing.T) {
 		}
 	}
 }
+
+func TestNewWorkflowRun_UsesBaseURLAndHeaders(t *testing.T) {
+	var gotAuth string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/workflows/tddPlanning/create-run", func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		fmt.Fprintf(w, `{"runId":"run-1"}`)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	wr, err := NewWorkflowRun("/tmp", WithBaseURL(ts.URL+"/"), WithHeaders(map[string]string{"Authorization": "Bearer secret"}))
+	if err != nil {
+		t.Fatalf("failed to create workflow run: %v", err)
+	}
+	if gotAuth != "Bearer secret" {
+		t.Errorf("expected Authorization header on create-run, got %q", gotAuth)
+	}
+	if want := ts.URL + "/api/workflows/tddPlanning/watch?runId=run-1"; wr.WatchURL != want {
+		t.Errorf("expected watch URL %s, got %s", want, wr.WatchURL)
+	}
+	if want := ts.URL + "/api/workflows/tddPlanning/start?runId=run-1"; wr.StartURL != want {
+		t.Errorf("expected start URL %s, got %s", want, wr.StartURL)
+	}
+}
+
+func TestWorkflowRun_WatchReportsDroppedConnection(t *testing.T) {
+	watchHandler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		b, _ := json.Marshal(testEvent{Type: "watch", Payload: map[string]interface{}{"step": "thinking", "msg": "Thinking 1"}})
+		w.Write(append(b, '\x1e'))
+		w.(http.Flusher).Flush()
+		// Drop the connection mid-stream, before the chunked body is terminated
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("hijack failed: %v", err)
+			return
+		}
+		conn.Close()
+	}
+	ts := httptest.NewServer(http.HandlerFunc(watchHandler))
+	defer ts.Close()
+
+	wr := &WorkflowRun{
+		WatchURL: ts.URL + "/api/workflows/tddPlanning/watch?runId=test-run-id",
+		Events:   make(chan WorkflowEvent, 10),
+		Done:     make(chan struct{}),
+	}
+	wr.Watch()
+	var gotEvents []WorkflowEvent
+	for evt := range wr.Events {
+		gotEvents = append(gotEvents, evt)
+	}
+
+	if len(gotEvents) != 1 {
+		t.Errorf("expected the event sent before the drop, got %d events", len(gotEvents))
+	}
+	if wr.Err == nil {
+		t.Error("expected Err to report the dropped connection")
+	}
+}
+
+func TestWorkflowRun_CancelStopsWatch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/workflows/tddPlanning/create-run", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"runId":"run-1"}`)
+	})
+	mux.HandleFunc("/api/workflows/tddPlanning/watch", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	wr, err := NewWorkflowRun("/tmp", WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("failed to create workflow run: %v", err)
+	}
+	wr.Watch()
+	wr.Cancel()
+
+	done := make(chan struct{})
+	go func() {
+		for range wr.Events {
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected Cancel to end the watcher")
+	}
+	if wr.Err != nil {
+		t.Errorf("expected a cancelled run to end without an error, got %v", wr.Err)
+	}
+}
+
+func TestNewWorkflowRun_RetriesWhileBackendStarts(t *testing.T) {
+	defer func(delay time.Duration) { createRunRetryDelay = delay }(createRunRetryDelay)
+	createRunRetryDelay = time.Millisecond
+
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			http.Error(w, "starting up", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"runId":"run-3"}`)
+	}))
+	defer ts.Close()
+
+	wr, err := NewWorkflowRun("/tmp", WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("expected create-run to succeed after retries: %v", err)
+	}
+	if wr.RunID != "run-3" || calls != 3 {
+		t.Errorf("expected run-3 on the third attempt, got %q after %d calls", wr.RunID, calls)
+	}
+}
+
+func TestNewWorkflowRun_ExplainsBadResponses(t *testing.T) {
+	defer func(delay time.Duration) { createRunRetryDelay = delay }(createRunRetryDelay)
+	createRunRetryDelay = time.Millisecond
+
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		wantCalls int
+		want      []string
+	}{
+		{"missing runId", http.StatusOK, `{"id":"run-1"}`, 1, []string{"has no runId", "200 OK", `{"id":"run-1"}`}},
+		{"runId not a string", http.StatusOK, `{"runId":42}`, 1, []string{"has no runId"}},
+		{"not JSON", http.StatusOK, `<html>`, 1, []string{"failed to parse", "<html>"}},
+		{"client error", http.StatusNotFound, `no such workflow`, 1, []string{"404 Not Found", "no such workflow"}},
+		{"server keeps failing", http.StatusBadGateway, strings.Repeat("x", 500), createRunAttempts, []string{"502 Bad Gateway", "..."}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer ts.Close()
+
+			_, err := NewWorkflowRun("/tmp", WithBaseURL(ts.URL))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected %q in %q", want, err)
+				}
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("expected %d attempts, got %d", tt.wantCalls, calls)
+			}
+			if len(err.Error()) > 2*bodySnippetLen {
+				t.Errorf("expected the body to be trimmed, got %d chars", len(err.Error()))
+			}
+		})
+	}
+}
@@ -1,33 +1,325 @@
 package tui
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
+
+	"tddpro/internal/components"
+	"tddpro/internal/mcpclient"
+	"tddpro/internal/streams"
+	"tddpro/internal/util"
 
 	"gopkg.in/yaml.v3"
 )
 
 type config struct {
-	API string `yaml:"api"`
+	API         string            `yaml:"api"`
+	WorkflowAPI string            `yaml:"workflow_api"` // workflow API base URL when it isn't served from api
+	Theme       string            `yaml:"theme"`        // "dark" (default) or "light"
+	Colors      map[string]string `yaml:"colors"`       // per-color overrides for a custom theme
+	Headers     map[string]string `yaml:"headers"`      // extra headers for every backend request
+	// Minutes without sending before the SSE connection is closed; 0 (default) keeps it open
+	IdleTimeoutMinutes int `yaml:"idle_timeout_minutes"`
+	TabSpaces          int `yaml:"tab_spaces"` // spaces Tab inserts in chat input; 0 (default) does nothing
+	// PEM CA bundle for backends using an internal CA or self-signed certificates
+	CAFile             string `yaml:"ca_file"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"` // disables TLS verification; never use in production
+	// Anthropic API gateway for Claude calls; ANTHROPIC_BASE_URL overrides it
+	AnthropicBaseURL string `yaml:"anthropic_base_url"`
+	ReadOnly         bool   `yaml:"read_only"`     // browse without editing; --read-only sets it too
+	CheckUpdates     bool   `yaml:"check_updates"` // look for a newer GitHub release at startup
+	StatusLines      int    `yaml:"status_lines"`  // most lines the status bar shows; 0 (default) allows half the window
+	// Seconds between re-fetching the open features view; 0 (default) disables auto-refresh
+	FeaturesRefreshSeconds int `yaml:"features_refresh_seconds"`
+	// Where /auth keeps the Claude API key: "file" (default, auth.json) or "keychain"
+	CredentialStore string `yaml:"credential_store"`
+	// Command PRDs are edited with, arguments included (e.g. "code --wait"); overrides $EDITOR
+	Editor string `yaml:"editor"`
+	// Order of features within each sidebar group: "server" (default), "name", "updated" or "created"
+	FeatureSort string `yaml:"feature_sort"`
 }
 
-// LoadAPIURL reads ~/.config/tdd-pro/config.yml and returns the API URL, defaulting to localhost:800 if missing/empty.
-func LoadAPIURL() string {
+// defaultAPIURL is used when neither a flag nor config.yml sets the API URL.
+// The Mastra dev server serves both the MCP SSE endpoints and the workflow API.
+const defaultAPIURL = streams.DefaultBaseURL
+
+// configPath overrides the default config location when set via --config
+var configPath string
+
+// SetConfigPath makes later config lookups read path instead of ~/.config/tdd-pro/config.yml
+func SetConfigPath(path string) {
+	configPath = path
+}
+
+// ConfigPath returns the config file in use, or "" if the home directory can't be determined.
+func ConfigPath() string {
+	if configPath != "" {
+		return configPath
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
-		return "localhost:800"
+		return ""
 	}
-	path := filepath.Join(home, ".config", "tdd-pro", "config.yml")
-	data, err := os.ReadFile(path)
+	return filepath.Join(home, ".config", "tdd-pro", "config.yml")
+}
+
+// ProjectConfigPath returns .tdd-pro/config.yml of the project containing the
+// working directory, or "" if there is no project or it has no config file.
+// ~/.tdd-pro holds installed binaries, not a project, so it's never used.
+func ProjectConfigPath() string {
+	cwd, err := os.Getwd()
 	if err != nil {
-		return "localhost:800"
+		return ""
 	}
+	dir := util.FindTddProDirectoryDefault(cwd)
+	if dir == "" || dir == util.GetConfigDir() {
+		return ""
+	}
+	path := filepath.Join(dir, "config.yml")
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// loadConfig reads the global config file (~/.config/tdd-pro/config.yml by
+// default) and then the project's .tdd-pro/config.yml. Project values win
+// per key and the global file fills in the rest. Missing or invalid files are skipped.
+func loadConfig() config {
 	var cfg config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return "localhost:800"
+	readConfigFile(ConfigPath(), &cfg)
+	// Decoding the project file on top of the global config replaces only
+	// the keys it sets; map entries such as headers are merged
+	readConfigFile(ProjectConfigPath(), &cfg)
+	return cfg
+}
+
+// readConfigFile decodes path on top of cfg, leaving cfg untouched if the file can't be read or parsed
+func readConfigFile(path string, cfg *config) {
+	if path == "" {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	merged := *cfg
+	merged.Colors = copyMap(cfg.Colors)
+	merged.Headers = copyMap(cfg.Headers)
+	if err := yaml.Unmarshal(data, &merged); err != nil {
+		return
 	}
-	if cfg.API == "" {
-		return "localhost:800"
+	*cfg = merged
+}
+
+// CheckConfig parses the global and project config files strictly, which
+// loadConfig doesn't: an invalid file or an unknown key is an error naming
+// the file. It returns the files that were found.
+func CheckConfig() ([]string, error) {
+	var found []string
+	for _, path := range []string{ConfigPath(), ProjectConfigPath()} {
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return found, err
+		}
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		var cfg config
+		if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+			return found, fmt.Errorf("%s: %w", path, err)
+		}
+		found = append(found, path)
+	}
+	return found, nil
+}
+
+func copyMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// LoadAPIURL returns the API URL from the project or global config.yml, defaulting to http://localhost:4111 if missing/empty.
+// Environment references ($VAR or ${VAR}) are expanded; one that expands to nothing counts as missing.
+func LoadAPIURL() string {
+	cfg := loadConfig()
+	api := expandEnv(cfg.API)
+	if api == "" {
+		return defaultAPIURL
 	}
-	return cfg.API
+	return withScheme(api)
+}
+
+// expandEnv resolves $VAR and ${VAR} references in a config value, undefined
+// variables becoming empty. Values without references are returned as is.
+func expandEnv(value string) string {
+	if !strings.Contains(value, "$") {
+		return value
+	}
+	return strings.TrimSpace(os.ExpandEnv(value))
+}
+
+// ResolveAPIURL picks the API URL with precedence:
+// --api-url flag > project .tdd-pro/config.yml > global config.yml > default.
+func ResolveAPIURL(flagVal string) string {
+	url, _ := ResolveAPIURLSource(flagVal)
+	return url
+}
+
+// ResolveAPIURLSource is ResolveAPIURL that also says where the value came
+// from: "--api-url", the config file path, or "default".
+func ResolveAPIURLSource(flagVal string) (string, string) {
+	if flagVal = strings.TrimSpace(flagVal); flagVal != "" {
+		return withScheme(flagVal), "--api-url"
+	}
+	for _, path := range []string{ProjectConfigPath(), ConfigPath()} {
+		var cfg config
+		readConfigFile(path, &cfg)
+		if api := expandEnv(cfg.API); api != "" {
+			return withScheme(api), path
+		}
+	}
+	return defaultAPIURL, "default"
+}
+
+// ResolveWorkflowURL returns the workflow API base URL: workflow_api from
+// config.yml if set, otherwise apiURL since both usually share a server.
+func ResolveWorkflowURL(apiURL string) string {
+	cfg := loadConfig()
+	if workflowAPI := expandEnv(cfg.WorkflowAPI); workflowAPI != "" {
+		return withScheme(workflowAPI)
+	}
+	return apiURL
+}
+
+// withScheme prefixes host:port URLs with http:// so they can be requested
+func withScheme(url string) string {
+	url = strings.TrimRight(strings.TrimSpace(url), "/")
+	if url != "" && !strings.Contains(url, "://") {
+		return "http://" + url
+	}
+	return url
+}
+
+// LoadHeaders returns the extra backend request headers from config.yml.
+// Values may reference environment variables ($VAR or ${VAR}) so secrets needn't be stored in the file.
+func LoadHeaders() map[string]string {
+	cfg := loadConfig()
+	headers := make(map[string]string, len(cfg.Headers))
+	for key, value := range cfg.Headers {
+		headers[key] = os.ExpandEnv(value)
+	}
+	return headers
+}
+
+// LoadTLSOptions returns the backend TLS settings from config.yml. ca_file
+// may reference environment variables and a leading ~ is the home directory.
+func LoadTLSOptions() mcpclient.TLSOptions {
+	cfg := loadConfig()
+	caFile := os.ExpandEnv(cfg.CAFile)
+	if rest, ok := strings.CutPrefix(caFile, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			caFile = filepath.Join(home, rest)
+		}
+	}
+	return mcpclient.TLSOptions{CAFile: caFile, InsecureSkipVerify: cfg.InsecureSkipVerify}
+}
+
+// LoadAnthropicBaseURL returns anthropic_base_url from config.yml, "" if unset.
+// auth.ResolveBaseURL gives ANTHROPIC_BASE_URL precedence over it.
+func LoadAnthropicBaseURL() string {
+	return strings.TrimSpace(expandEnv(loadConfig().AnthropicBaseURL))
+}
+
+// LoadCredentialStore returns credential_store from config.yml, "" if unset.
+// auth.NewCredentialStore treats "" as the file store.
+func LoadCredentialStore() string {
+	return loadConfig().CredentialStore
+}
+
+// ResolveEditor returns the command PRDs are edited with: the --editor flag,
+// then editor from config.yml, then $EDITOR. "" means editing inline.
+func ResolveEditor(flagVal string) string {
+	if flagVal = strings.TrimSpace(flagVal); flagVal != "" {
+		return flagVal
+	}
+	if editor := strings.TrimSpace(expandEnv(loadConfig().Editor)); editor != "" {
+		return editor
+	}
+	return strings.TrimSpace(os.Getenv("EDITOR"))
+}
+
+// LoadReadOnly returns whether config.yml asks for read-only mode.
+func LoadReadOnly() bool {
+	return loadConfig().ReadOnly
+}
+
+// LoadCheckUpdates returns whether config.yml asks for a release check at startup.
+func LoadCheckUpdates() bool {
+	return loadConfig().CheckUpdates
+}
+
+// LoadIdleTimeout returns how long the SSE connection may sit idle before it's closed, 0 if disabled.
+func LoadIdleTimeout() time.Duration {
+	cfg := loadConfig()
+	if cfg.IdleTimeoutMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(cfg.IdleTimeoutMinutes) * time.Minute
+}
+
+// LoadTabSpaces returns how many spaces Tab inserts in non-command input.
+func LoadTabSpaces() int {
+	cfg := loadConfig()
+	if cfg.TabSpaces < 0 {
+		return 0
+	}
+	return cfg.TabSpaces
+}
+
+// LoadStatusLines returns the status bar line limit, 0 for half the window.
+func LoadStatusLines() int {
+	cfg := loadConfig()
+	if cfg.StatusLines < 0 {
+		return 0
+	}
+	return cfg.StatusLines
+}
+
+// LoadFeaturesRefresh returns how often the open features view is re-fetched, 0 if disabled.
+func LoadFeaturesRefresh() time.Duration {
+	cfg := loadConfig()
+	if cfg.FeaturesRefreshSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(cfg.FeaturesRefreshSeconds) * time.Second
+}
+
+// LoadFeatureSort returns feature_sort from config.yml, "" (the server's
+// order) if unset.
+func LoadFeatureSort() string {
+	return loadConfig().FeatureSort
+}
+
+// LoadTheme returns the theme selected in config.yml with any color overrides applied, defaulting to dark.
+func LoadTheme() components.Theme {
+	cfg := loadConfig()
+	return components.ThemeByName(cfg.Theme).WithColors(cfg.Colors)
 }
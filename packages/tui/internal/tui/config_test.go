@@ -0,0 +1,197 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tddpro/internal/streams"
+)
+
+func TestResolveAPIURL_Precedence(t *testing.T) {
+	defer SetConfigPath("")
+
+	// No config file: default
+	SetConfigPath(filepath.Join(t.TempDir(), "missing.yml"))
+	if got := ResolveAPIURL(""); got != defaultAPIURL {
+		t.Errorf("Expected default %s, got %s", defaultAPIURL, got)
+	}
+
+	// Config file beats default
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte("api: config.example:9000\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	SetConfigPath(path)
+	if got := ResolveAPIURL(""); got != "http://config.example:9000" {
+		t.Errorf("Expected config value, got %s", got)
+	}
+
+	// Flag beats config file
+	if got := ResolveAPIURL("http://flag.example:7000"); got != "http://flag.example:7000" {
+		t.Errorf("Expected flag value, got %s", got)
+	}
+	if got := ResolveAPIURL("   "); got != "http://config.example:9000" {
+		t.Errorf("Expected blank flag to fall back to config, got %s", got)
+	}
+}
+
+func TestLoadAPIURL_ExpandsEnv(t *testing.T) {
+	defer SetConfigPath("")
+	t.Setenv("TDDPRO_API", "https://api.example.com")
+	t.Setenv("TDDPRO_HOST", "backend.local")
+	t.Setenv("TDDPRO_UNSET", "")
+
+	cases := []struct {
+		api  string
+		want string
+	}{
+		{"${TDDPRO_API}", "https://api.example.com"},
+		{"$TDDPRO_HOST:4111", "http://backend.local:4111"},
+		{"http://literal.example:9000", "http://literal.example:9000"},
+		{"${TDDPRO_UNSET}", defaultAPIURL},
+	}
+	for _, c := range cases {
+		path := filepath.Join(t.TempDir(), "config.yml")
+		if err := os.WriteFile(path, []byte("api: "+c.api+"\n"), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		SetConfigPath(path)
+		if got := LoadAPIURL(); got != c.want {
+			t.Errorf("api: %s: expected %s, got %s", c.api, c.want, got)
+		}
+		if got, _ := ResolveAPIURLSource(""); got != c.want {
+			t.Errorf("api: %s: expected ResolveAPIURLSource %s, got %s", c.api, c.want, got)
+		}
+	}
+}
+
+func TestLoadAPIURL_DefaultMatchesWorkflowBackend(t *testing.T) {
+	defer SetConfigPath("")
+	SetConfigPath(filepath.Join(t.TempDir(), "missing.yml"))
+
+	if got := LoadAPIURL(); got != "http://localhost:4111" {
+		t.Errorf("Expected default API URL http://localhost:4111, got %s", got)
+	}
+	if got := ResolveWorkflowURL(LoadAPIURL()); got != streams.DefaultBaseURL {
+		t.Errorf("Expected workflow URL to default to %s, got %s", streams.DefaultBaseURL, got)
+	}
+}
+
+func TestResolveWorkflowURL_Configurable(t *testing.T) {
+	defer SetConfigPath("")
+	path := filepath.Join(t.TempDir(), "config.yml")
+	config := "api: localhost:8000\nworkflow_api: localhost:4111/\n"
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	SetConfigPath(path)
+
+	if got := LoadAPIURL(); got != "http://localhost:8000" {
+		t.Errorf("Expected scheme added to api, got %s", got)
+	}
+	if got := ResolveWorkflowURL(LoadAPIURL()); got != "http://localhost:4111" {
+		t.Errorf("Expected workflow_api to override, got %s", got)
+	}
+}
+
+func TestLoadConfig_ProjectOverridesGlobal(t *testing.T) {
+	defer SetConfigPath("")
+	global := filepath.Join(t.TempDir(), "config.yml")
+	globalConfig := "api: global.example:9000\ntheme: light\nheaders:\n  Authorization: Bearer global\n  X-Team: core\n"
+	if err := os.WriteFile(global, []byte(globalConfig), 0644); err != nil {
+		t.Fatalf("Failed to write global config: %v", err)
+	}
+	SetConfigPath(global)
+
+	project := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(project, ".tdd-pro"), 0755); err != nil {
+		t.Fatalf("Failed to create .tdd-pro: %v", err)
+	}
+	projectPath := filepath.Join(project, ".tdd-pro", "config.yml")
+	projectConfig := "api: project.example:7000\nheaders:\n  Authorization: Bearer project\n"
+	if err := os.WriteFile(projectPath, []byte(projectConfig), 0644); err != nil {
+		t.Fatalf("Failed to write project config: %v", err)
+	}
+	sub := filepath.Join(project, "src")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatalf("Failed to create subdir: %v", err)
+	}
+	t.Chdir(sub)
+
+	url, source := ResolveAPIURLSource("")
+	if url != "http://project.example:7000" {
+		t.Errorf("Expected project API URL, got %s", url)
+	}
+	if mustEvalSymlinks(t, source) != mustEvalSymlinks(t, projectPath) {
+		t.Errorf("Expected source %s, got %s", projectPath, source)
+	}
+
+	cfg := loadConfig()
+	if cfg.Theme != "light" {
+		t.Errorf("Expected theme from global config, got %q", cfg.Theme)
+	}
+	if cfg.Headers["Authorization"] != "Bearer project" || cfg.Headers["X-Team"] != "core" {
+		t.Errorf("Expected headers merged per key, got %v", cfg.Headers)
+	}
+
+	if _, source := ResolveAPIURLSource("flag.example"); source != "--api-url" {
+		t.Errorf("Expected flag source, got %s", source)
+	}
+}
+
+func mustEvalSymlinks(t *testing.T, path string) string {
+	t.Helper()
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		t.Fatalf("Failed to resolve %s: %v", path, err)
+	}
+	return resolved
+}
+
+func TestResolveEditor_Precedence(t *testing.T) {
+	defer SetConfigPath("")
+	t.Chdir(t.TempDir())
+	t.Setenv("EDITOR", "vim")
+
+	SetConfigPath(filepath.Join(t.TempDir(), "missing.yml"))
+	if got := ResolveEditor(""); got != "vim" {
+		t.Errorf("Expected $EDITOR without config, got %q", got)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte("editor: code --wait\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	SetConfigPath(path)
+	if got := ResolveEditor(""); got != "code --wait" {
+		t.Errorf("Expected the config editor to beat $EDITOR, got %q", got)
+	}
+	if got := ResolveEditor("subl -w"); got != "subl -w" {
+		t.Errorf("Expected the flag to beat the config, got %q", got)
+	}
+}
+
+func TestCheckConfig_RejectsUnknownKeys(t *testing.T) {
+	defer SetConfigPath("")
+	t.Chdir(t.TempDir())
+	path := filepath.Join(t.TempDir(), "config.yml")
+	SetConfigPath(path)
+
+	if files, err := CheckConfig(); err != nil || len(files) != 0 {
+		t.Errorf("Expected no files and no error without a config, got %v (err %v)", files, err)
+	}
+	if err := os.WriteFile(path, []byte("theme: light\neditor: code --wait\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if files, err := CheckConfig(); err != nil || len(files) != 1 || files[0] != path {
+		t.Errorf("Expected the valid config to be reported, got %v (err %v)", files, err)
+	}
+	if err := os.WriteFile(path, []byte("theme: light\ncolour: red\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := CheckConfig(); err == nil || !strings.Contains(err.Error(), "colour") || !strings.Contains(err.Error(), path) {
+		t.Errorf("Expected the unknown key to be reported with the file, got %v", err)
+	}
+}
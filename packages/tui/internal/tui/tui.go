@@ -1,9 +1,12 @@
 package tui
 
 import (
+	"tddpro/internal/auth"
 	"tddpro/internal/components"
+	"tddpro/internal/mcpclient"
 
 	"fmt"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -28,7 +31,7 @@ type model struct {
 }
 
 func (m model) Init() tea.Cmd {
-	return nil
+	return m.prompt.Init()
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -38,6 +41,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m model) View() string {
+	// The prompt explains the minimum size rather than drawing a clipped layout
+	if m.prompt.TooSmall() {
+		return m.prompt.View()
+	}
 	// If features view is active, only show the prompt (which contains the full features interface)
 	if m.prompt.FeaturesViewActive {
 		return m.prompt.View()
@@ -69,43 +76,121 @@ func (m model) View() string {
 	)
 }
 
-func Start(apiURL string, version string) error {
-	prompt := components.NewPromptWithAPI(apiURL, version)
+// debugTools enables the /tool debugging command, see SetDebugTools
+var debugTools bool
+
+// SetDebugTools enables /tool, which calls MCP tools directly. main turns it
+// on for --log-level debug or DEBUG=1.
+func SetDebugTools(enabled bool) {
+	debugTools = enabled
+}
+
+// editorFlag is the --editor flag, see SetEditor
+var editorFlag string
+
+// SetEditor records the --editor flag, which overrides editor in config.yml and $EDITOR
+func SetEditor(editor string) {
+	editorFlag = editor
+}
+
+// Start runs the TUI against the backend at apiURL. In demo mode the backend
+// is replaced by canned sample data and an echoing agent.
+func Start(apiURL string, version string, demo bool, readOnly bool) error {
+	var client mcpclient.Client
+	var mcp *mcpclient.MCPClient
+	var tlsOptions mcpclient.TLSOptions
+	auth.SetConfiguredBaseURL(LoadAnthropicBaseURL())
+	store, err := auth.NewCredentialStore(LoadCredentialStore())
+	if err != nil {
+		return err
+	}
+	auth.SetCredentialStore(store)
+	if demo {
+		client = mcpclient.NewDemoClient()
+	} else {
+		tlsOptions = LoadTLSOptions()
+		httpClient, err := mcpclient.NewHTTPClient(tlsOptions)
+		if err != nil {
+			return err
+		}
+		mcp = mcpclient.NewMCPClient(apiURL)
+		mcp.Headers = LoadHeaders()
+		mcp.HTTP = httpClient
+		client = mcp
+	}
+	prompt := components.NewPromptWithClient(client, apiURL, version)
+	prompt.WorkflowURL = ResolveWorkflowURL(apiURL)
+	prompt.SetTheme(LoadTheme())
+	prompt.SetIdleTimeout(LoadIdleTimeout())
+	prompt.SetTabSpaces(LoadTabSpaces())
+	prompt.SetStatusLines(LoadStatusLines())
+	prompt.SetFeaturesRefresh(LoadFeaturesRefresh())
+	prompt.SetFeatureSort(LoadFeatureSort())
+	readOnly = readOnly || LoadReadOnly()
+	prompt.SetReadOnly(readOnly)
+	prompt.SetUpdateCheck(LoadCheckUpdates())
+	prompt.SetDebugTools(debugTools)
+	prompt.SetEditor(ResolveEditor(editorFlag))
+	prompt.SetConfigCheck(CheckConfig)
+	if demo {
+		prompt.StatusBar = "Demo mode: sample data, no backend. Try /features"
+	} else if tlsOptions.InsecureSkipVerify {
+		prompt.StatusBar = "WARNING: TLS certificate verification is disabled (insecure_skip_verify in config.yml)"
+	} else if readOnly {
+		prompt.StatusBar = "Read-only mode: browse features and tasks; edits are disabled"
+	}
 	p := tea.NewProgram(
 		model{prompt: &prompt},
 		tea.WithAltScreen(),       // Use alternate screen buffer
 		tea.WithMouseCellMotion(), // Enable mouse support
 	)
-	_, err := p.Run()
+	if mcp != nil {
+		// State changes can fire from inside Update, where a blocking Send
+		// would deadlock, so deliver them asynchronously
+		mcp.OnStateChange = func(state mcpclient.ConnectionState) {
+			go p.Send(components.ConnectionStateMsg{State: state})
+		}
+	}
+	// Runs however the program exits (/quit, ctrl+c or an error) so the SSE
+	// connection, MCP stdio servers and workflow watchers aren't leaked
+	defer prompt.Shutdown()
+	_, err = p.Run()
 	return err
 }
 
 func GradientBanner(text string, colors []string) string {
-	styled := ""
-	for i, c := range text {
-		color := colors[i%len(colors)]
-		styled += lipgloss.NewStyle().
-			Foreground(lipgloss.Color(color)).
-			Bold(true).
-			Render(string(c))
-	}
-	return styled
+	return gradientLine(text, colors, 0)
 }
 
+// GradientBannerASCII colors a multi-line banner diagonally. Lines are kept
+// at their own width (no padding) so the banner renders exactly as written.
 func GradientBannerASCII(banner string, colors []string) string {
 	lines := []string{}
 	for lineIdx, line := range splitLines(banner) {
-		styledLine := ""
-		for i, c := range line {
-			color := colors[(i+lineIdx)%len(colors)]
-			styledLine += lipgloss.NewStyle().
-				Foreground(lipgloss.Color(color)).
-				Bold(true).
-				Render(string(c))
+		lines = append(lines, gradientLine(line, colors, lineIdx))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// gradientLine colors line one cell at a time starting at colors[offset]. The
+// gradient advances by display width, so box-drawing and wide runes don't
+// skew it, and zero-width runes (combining marks) stay with their base rune.
+func gradientLine(line string, colors []string, offset int) string {
+	var styled strings.Builder
+	runes := []rune(line)
+	col := 0
+	for i := 0; i < len(runes); {
+		cell := string(runes[i])
+		for i++; i < len(runes) && lipgloss.Width(string(runes[i])) == 0; i++ {
+			cell += string(runes[i])
 		}
-		lines = append(lines, styledLine)
+		styled.WriteString(lipgloss.NewStyle().
+			Foreground(lipgloss.Color(colors[(col+offset)%len(colors)])).
+			Bold(true).
+			Render(cell))
+		col += lipgloss.Width(cell)
 	}
-	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return styled.String()
 }
 
 func splitLines(s string) []string {
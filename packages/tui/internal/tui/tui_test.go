@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestGradientBannerASCII_PreservesLineWidths(t *testing.T) {
+	// Force colors so the widths are measured with ANSI sequences present
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	defer lipgloss.SetColorProfile(termenv.Ascii)
+
+	colors := []string{"196", "202", "208", "214"}
+	source := splitLines(banner)
+	rendered := strings.Split(GradientBannerASCII(banner, colors), "\n")
+	if len(rendered) != len(source) {
+		t.Fatalf("Expected %d lines, got %d", len(source), len(rendered))
+	}
+	for i := range source {
+		if got, want := lipgloss.Width(rendered[i]), lipgloss.Width(source[i]); got != want {
+			t.Errorf("line %d: rendered width %d, source width %d", i, got, want)
+		}
+	}
+}
+
+func TestGradientLine_AdvancesByCell(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	defer lipgloss.SetColorProfile(termenv.Ascii)
+
+	colors := []string{"1", "2", "3", "4"}
+	// The wide rune takes two cells, so the rune after it gets colors[3]
+	line := gradientLine("a界b", colors, 0)
+	want := lipgloss.NewStyle().Foreground(lipgloss.Color("4")).Bold(true).Render("b")
+	if !strings.HasSuffix(line, want) {
+		t.Errorf("Expected the rune after a wide rune to use the fourth color, got %q", line)
+	}
+
+	// A combining mark is rendered with its base rune, not as its own cell
+	combined := gradientLine("éx", colors, 0)
+	first := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true).Render("é")
+	if !strings.HasPrefix(combined, first) {
+		t.Errorf("Expected the combining mark to share its base rune's style, got %q", combined)
+	}
+}
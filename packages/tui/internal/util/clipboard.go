@@ -0,0 +1,22 @@
+package util
+
+import (
+	"errors"
+
+	"github.com/atotto/clipboard"
+)
+
+// ErrClipboardUnavailable is returned when no system clipboard can be reached,
+// e.g. over SSH or on a headless machine without xclip/xsel/wl-copy.
+var ErrClipboardUnavailable = errors.New("clipboard unavailable")
+
+// CopyToClipboard writes text to the system clipboard
+func CopyToClipboard(text string) error {
+	if clipboard.Unsupported {
+		return ErrClipboardUnavailable
+	}
+	if err := clipboard.WriteAll(text); err != nil {
+		return errors.Join(ErrClipboardUnavailable, err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package util
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FeatureStatuses are the status lists of features/index.yml, in display order
+var FeatureStatuses = []string{"approved", "planned", "refinement", "backlog"}
+
+// FeatureEntry is one feature listed in features/index.yml
+type FeatureEntry struct {
+	ID          string
+	Name        string
+	Description string
+}
+
+// FeaturesIndex is a validated .tdd-pro/features/index.yml
+type FeaturesIndex struct {
+	Features map[string][]FeatureEntry // keyed by status
+	Current  []string                  // current feature IDs, from current, current_feature or current_features
+}
+
+// LoadFeaturesIndex parses and validates a features index. Errors name the
+// file, line and key that are malformed so a hand-edited index can be fixed.
+// Legacy forms the MCP server migrates (plain ID strings, a nested features
+// map, a single current feature) are accepted.
+func LoadFeaturesIndex(path string) (*FeaturesIndex, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	index := &FeaturesIndex{Features: map[string][]FeatureEntry{}}
+	if len(doc.Content) == 0 {
+		return index, nil // empty file: no features yet
+	}
+	root := doc.Content[0]
+	if isNull(root) {
+		return index, nil
+	}
+	if root.Kind != yaml.MappingNode {
+		return nil, indexError(path, root, "top level must be a map of statuses, got %s", kindName(root))
+	}
+
+	statuses := root
+	if nested := mapValue(root, "features"); nested != nil && !isNull(nested) {
+		if nested.Kind != yaml.MappingNode {
+			return nil, indexError(path, nested, "features must be a map of statuses, got %s", kindName(nested))
+		}
+		statuses = nested
+	}
+	for _, status := range FeatureStatuses {
+		entries, err := parseStatusList(path, status, mapValue(statuses, status))
+		if err != nil {
+			return nil, err
+		}
+		index.Features[status] = entries
+	}
+
+	for _, key := range []string{"current", "current_feature", "current_features"} {
+		current, err := parseCurrent(path, key, mapValue(root, key))
+		if err != nil {
+			return nil, err
+		}
+		index.Current = append(index.Current, current...)
+	}
+	return index, nil
+}
+
+// parseStatusList validates one status list; each item is an ID (text or a bare number) or a map with an id
+func parseStatusList(path, status string, node *yaml.Node) ([]FeatureEntry, error) {
+	if node == nil || isNull(node) {
+		return nil, nil
+	}
+	if node.Kind != yaml.SequenceNode {
+		return nil, indexError(path, node, "%s must be a list, got %s", status, kindName(node))
+	}
+	var entries []FeatureEntry
+	for i, item := range node.Content {
+		switch {
+		case item.Kind == yaml.ScalarNode && (item.Tag == "!!str" || item.Tag == "!!int"):
+			entries = append(entries, FeatureEntry{ID: item.Value})
+		case item.Kind == yaml.MappingNode:
+			var entry FeatureEntry
+			for _, field := range []struct {
+				key string
+				dst *string
+			}{{"id", &entry.ID}, {"name", &entry.Name}, {"description", &entry.Description}} {
+				value := mapValue(item, field.key)
+				if value == nil || isNull(value) {
+					continue
+				}
+				if value.Kind != yaml.ScalarNode {
+					return nil, indexError(path, value, "%s[%d].%s must be text, got %s", status, i, field.key, kindName(value))
+				}
+				*field.dst = value.Value
+			}
+			if entry.ID == "" {
+				return nil, indexError(path, item, "%s[%d] has no id", status, i)
+			}
+			entries = append(entries, entry)
+		default:
+			return nil, indexError(path, item, "%s[%d] must be a feature ID or a map with an id, got %s", status, i, kindName(item))
+		}
+	}
+	return entries, nil
+}
+
+// parseCurrent validates an optional current feature: null, an ID or a list of IDs
+func parseCurrent(path, key string, node *yaml.Node) ([]string, error) {
+	if node == nil || isNull(node) {
+		return nil, nil
+	}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return []string{node.Value}, nil
+	case yaml.SequenceNode:
+		var ids []string
+		for i, item := range node.Content {
+			if item.Kind != yaml.ScalarNode || isNull(item) {
+				return nil, indexError(path, item, "%s[%d] must be a feature ID, got %s", key, i, kindName(item))
+			}
+			ids = append(ids, item.Value)
+		}
+		return ids, nil
+	}
+	return nil, indexError(path, node, "%s must be a feature ID or a list of IDs, got %s", key, kindName(node))
+}
+
+// mapValue returns the value for key in a mapping node, or nil if absent
+func mapValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
+
+// kindName describes a node for error messages
+func kindName(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a map"
+	case yaml.SequenceNode:
+		return "a list"
+	case yaml.AliasNode:
+		return "an alias"
+	}
+	if isNull(node) {
+		return "null"
+	}
+	return fmt.Sprintf("%q", node.Value)
+}
+
+func indexError(path string, node *yaml.Node, format string, args ...interface{}) error {
+	return fmt.Errorf("%s line %d: %s", path, node.Line, fmt.Sprintf(format, args...))
+}
@@ -1,8 +1,13 @@
 package util
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
+	"unicode/utf8"
 )
 
 // GetConfigDir returns the path to the user's config directory (~/.tdd-pro)
@@ -51,13 +56,18 @@ func FindTddProDirectoryDefault(start string) string {
 
 // IsAlreadyInitialized returns true if a project-local .tdd-pro exists (ignores ~/.tdd-pro)
 func IsAlreadyInitialized(startDir string) bool {
+	return IsAlreadyInitializedWithStat(startDir, os.Stat)
+}
+
+// IsAlreadyInitializedWithStat is IsAlreadyInitialized with an injectable stat function for testability.
+func IsAlreadyInitializedWithStat(startDir string, stat StatFunc) bool {
 	home, _ := os.UserHomeDir()
 	homeTddPro := filepath.Join(home, ".tdd-pro")
 	dir := startDir
 
 	for {
 		candidate := filepath.Join(dir, ".tdd-pro")
-		if _, err := os.Stat(candidate); err == nil {
+		if _, err := stat(candidate); err == nil {
 			// Ignore $HOME/.tdd-pro - it's just for binaries
 			if candidate != homeTddPro {
 				return true // Found a project-local .tdd-pro
@@ -69,6 +79,109 @@ func IsAlreadyInitialized(startDir string) bool {
 		}
 		dir = parent
 	}
-	
+
 	return false // No project-local .tdd-pro found
 }
+
+// FeaturesIndexPath returns where the project's features/index.yml lives, or
+// "" when startDir isn't inside a project-local .tdd-pro
+func FeaturesIndexPath(startDir string) string {
+	if !IsAlreadyInitialized(startDir) {
+		return ""
+	}
+	return filepath.Join(FindTddProDirectoryDefault(startDir), "features", "index.yml")
+}
+
+// MissingFeaturesIndex reports a project-local .tdd-pro that has no
+// features/index.yml (e.g. after a partial init), returning the path where
+// the index should be. Returns false when there's no project or the index exists.
+func MissingFeaturesIndex(startDir string) (string, bool) {
+	indexPath := FeaturesIndexPath(startDir)
+	if indexPath == "" {
+		return "", false
+	}
+	if _, err := os.Stat(indexPath); err == nil {
+		return "", false
+	}
+	return indexPath, true
+}
+
+// NormalizePath expands a leading ~ to the home directory, resolves relative
+// paths against the working directory and cleans the result
+func NormalizePath(path string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("expanding ~: %w", err)
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Clean(abs), nil
+}
+
+// ExistingDir normalizes path with NormalizePath and checks that it is a directory
+func ExistingDir(path string) (string, error) {
+	dir, err := NormalizePath(path)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("directory %s does not exist", dir)
+	}
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", dir)
+	}
+	return dir, nil
+}
+
+// DirUsage counts the regular files under dir and their total size in bytes
+func DirUsage(dir string) (files int, size int64, err error) {
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		files++
+		size += info.Size()
+		return nil
+	})
+	return files, size, err
+}
+
+// FormatBytes renders a byte count for people, e.g. "1.5 KB"
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// EstimateTokens roughly estimates the number of LLM tokens in text using the
+// common ~4 characters per token heuristic
+func EstimateTokens(text string) int {
+	chars := utf8.RuneCountInString(text)
+	if chars == 0 {
+		return 0
+	}
+	return (chars + 3) / 4
+}
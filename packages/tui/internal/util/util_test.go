@@ -3,6 +3,7 @@ package util
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -109,27 +110,164 @@ func TestIsAlreadyInitialized(t *testing.T) {
 	}
 }
 
-// IsAlreadyInitializedWithStat is a test helper for injecting a stat func
-func IsAlreadyInitializedWithStat(startDir string, stat StatFunc) bool {
-	// Use our updated logic that ignores $HOME/.tdd-pro
-	home, _ := os.UserHomeDir()
-	homeTddPro := filepath.Join(home, ".tdd-pro")
-	dir := startDir
-
-	for {
-		candidate := filepath.Join(dir, ".tdd-pro")
-		if _, err := stat(candidate); err == nil {
-			// Ignore $HOME/.tdd-pro - it's just for binaries
-			if candidate != homeTddPro {
-				return true // Found a project-local .tdd-pro
+func TestEstimateTokens(t *testing.T) {
+	cases := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"hi", 1},
+		{"abcd", 1},
+		{"abcde", 2},
+		{"héllo wörld", 3},
+	}
+	for _, c := range cases {
+		if got := EstimateTokens(c.text); got != c.want {
+			t.Errorf("EstimateTokens(%q) = %d, want %d", c.text, got, c.want)
+		}
+	}
+}
+
+func TestMissingFeaturesIndex(t *testing.T) {
+	project := t.TempDir()
+	if _, missing := MissingFeaturesIndex(project); missing {
+		t.Error("Expected no report without a .tdd-pro directory")
+	}
+
+	featuresDir := filepath.Join(project, ".tdd-pro", "features")
+	if err := os.MkdirAll(featuresDir, 0755); err != nil {
+		t.Fatalf("Failed to create features dir: %v", err)
+	}
+	path, missing := MissingFeaturesIndex(project)
+	if !missing || path != filepath.Join(featuresDir, "index.yml") {
+		t.Errorf("Expected missing index at %s, got %q (missing=%v)", filepath.Join(featuresDir, "index.yml"), path, missing)
+	}
+
+	if err := os.WriteFile(filepath.Join(featuresDir, "index.yml"), []byte("approved: []\n"), 0644); err != nil {
+		t.Fatalf("Failed to write index: %v", err)
+	}
+	if _, missing := MissingFeaturesIndex(project); missing {
+		t.Error("Expected no report once index.yml exists")
+	}
+}
+
+func TestLoadFeaturesIndex(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		wantErr string // substring of the error, "" for a valid index
+	}{
+		{"init template", "approved: []\nplanned: []\nrefinement: []\nbacklog: []\ncurrent: null\n", ""},
+		{"empty file", "", ""},
+		{"feature maps", "approved:\n  - id: login\n    name: Login\ncurrent_features: [login]\n", ""},
+		{"legacy ids", "features:\n  backlog: [dark-mode]\ncurrent_feature: dark-mode\n", ""},
+		{"numeric ids", "backlog: [42, 007]\ncurrent: 42\n", ""},
+		{"status not a list", "approved: []\nplanned: oops\n", "line 2: planned must be a list"},
+		{"entry without id", "backlog:\n  - name: Nameless\n", "backlog[0] has no id"},
+		{"nested entry", "refinement:\n  - [a, b]\n", "refinement[0] must be a feature ID or a map"},
+		{"bad current", "current:\n  id: x\n", "current must be a feature ID or a list of IDs"},
+		{"top level list", "- approved\n", "top level must be a map"},
+		{"not yaml", "approved: [\n", "index.yml"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "index.yml")
+			if err := os.WriteFile(path, []byte(tc.content), 0644); err != nil {
+				t.Fatalf("Failed to write index: %v", err)
 			}
+			_, err := LoadFeaturesIndex(path)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected valid index, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "index.yml")
+	os.WriteFile(path, []byte("approved:\n  - id: login\nbacklog: [dark-mode]\ncurrent: login\n"), 0644)
+	index, err := LoadFeaturesIndex(path)
+	if err != nil {
+		t.Fatalf("LoadFeaturesIndex failed: %v", err)
+	}
+	if len(index.Features["approved"]) != 1 || index.Features["backlog"][0].ID != "dark-mode" {
+		t.Errorf("Unexpected features: %+v", index.Features)
+	}
+	if len(index.Current) != 1 || index.Current[0] != "login" {
+		t.Errorf("Expected current [login], got %v", index.Current)
+	}
+
+	// Unquoted numeric IDs keep their text so they match the feature directories
+	os.WriteFile(path, []byte("backlog: [42, 007]\n"), 0644)
+	if index, err = LoadFeaturesIndex(path); err != nil {
+		t.Fatalf("LoadFeaturesIndex failed: %v", err)
+	}
+	if backlog := index.Features["backlog"]; len(backlog) != 2 || backlog[0].ID != "42" || backlog[1].ID != "007" {
+		t.Errorf("Expected numeric IDs as written, got %+v", backlog)
+	}
+}
+
+func TestDirUsage(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "features", "login"), 0755)
+	os.WriteFile(filepath.Join(dir, "features", "index.yml"), []byte("approved: []\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "features", "login", "prd.md"), make([]byte, 2048), 0644)
+
+	files, size, err := DirUsage(dir)
+	if err != nil {
+		t.Fatalf("DirUsage failed: %v", err)
+	}
+	if files != 2 || size != 2048+13 {
+		t.Errorf("Expected 2 files and %d bytes, got %d files and %d bytes", 2048+13, files, size)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	cases := map[int64]string{0: "0 B", 1023: "1023 B", 1536: "1.5 KB", 5 << 20: "5.0 MB"}
+	for n, want := range cases {
+		if got := FormatBytes(n); got != want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", n, got, want)
 		}
-		parent := filepath.Dir(dir)
-		if parent == dir {
-			break
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	cwd := t.TempDir()
+	t.Chdir(cwd)
+
+	cases := map[string]string{
+		"~":               home,
+		"~/projects/foo/": filepath.Join(home, "projects", "foo"),
+		"../bar":          filepath.Join(filepath.Dir(cwd), "bar"),
+		"./src//app/..":   filepath.Join(cwd, "src"),
+		"/abs/path/":      "/abs/path",
+		"~other":          filepath.Join(cwd, "~other"),
+	}
+	for path, want := range cases {
+		if got, err := NormalizePath(path); err != nil || got != want {
+			t.Errorf("NormalizePath(%q) = %q (err %v), want %q", path, got, err, want)
 		}
-		dir = parent
 	}
-	
-	return false // No project-local .tdd-pro found
+}
+
+func TestExistingDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	os.WriteFile(filepath.Join(dir, "file.txt"), nil, 0644)
+
+	if got, err := ExistingDir("."); err != nil || got != dir {
+		t.Errorf("ExistingDir(.) = %q (err %v), want %q", got, err, dir)
+	}
+	if _, err := ExistingDir("missing"); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("Expected a missing directory to be rejected, got %v", err)
+	}
+	if _, err := ExistingDir("file.txt"); err == nil || !strings.Contains(err.Error(), "is not a directory") {
+		t.Errorf("Expected a file to be rejected, got %v", err)
+	}
 }
@@ -1,30 +1,109 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"log/slog"
 	"os"
 
+	"tddpro/internal/cli"
+	"tddpro/internal/logging"
+	"tddpro/internal/mcpclient"
 	"tddpro/internal/tui"
+	"tddpro/internal/util"
 )
 
 // Version is injected at build time via -ldflags "-X main.version=..."
 var version = "dev"
 
 func main() {
+	os.Exit(run())
+}
+
+// run starts tdd-pro and returns its exit code. Exiting only once run has
+// returned lets its deferred cleanup, like closing the log, happen first.
+func run() int {
 	// Add --version and -v flag support
 	showVersion := false
 	flag.BoolVar(&showVersion, "version", false, "Print version and exit")
 	flag.BoolVar(&showVersion, "v", false, "Print version and exit (shorthand)")
+	apiURLFlag := flag.String("api-url", "", "Backend API URL (overrides config.yml)")
+	demoFlag := flag.Bool("demo", false, "Run with sample data and no backend")
+	readOnlyFlag := flag.Bool("read-only", false, "Browse without editing tasks, PRDs or features (also read_only in config.yml)")
+	logLevelFlag := flag.String("log-level", "info", "Log level: debug, info, warn or error (DEBUG=1 implies debug)")
+	configFlag := flag.String("config", "", "Path to config file (default ~/.config/tdd-pro/config.yml)")
+	editorFlag := flag.String("editor", "", `Command to edit PRDs with, e.g. "code --wait" (overrides editor in config.yml and $EDITOR)`)
 	flag.Parse()
 	if showVersion {
 		fmt.Println(version)
-		os.Exit(0)
+		return 0
+	}
+
+	if *configFlag != "" {
+		if _, err := os.Stat(*configFlag); err != nil {
+			fmt.Println("Error reading config:", err)
+			return 1
+		}
+		tui.SetConfigPath(*configFlag)
+	}
+
+	level, err := logging.ParseLevel(*logLevelFlag)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return 1
+	}
+	if os.Getenv("DEBUG") != "" {
+		level = slog.LevelDebug
+	}
+	tui.SetDebugTools(level == slog.LevelDebug)
+	tui.SetEditor(*editorFlag)
+	logPath, err := logging.DefaultPath()
+	if err == nil {
+		var closeLog func() error
+		closeLog, err = logging.Setup(logPath, level)
+		defer closeLog()
+	}
+	if err != nil {
+		logging.Discard()
+		fmt.Println("Warning: logging disabled:", err)
 	}
 
-	apiURL := tui.LoadAPIURL()
-	if err := tui.Start(apiURL, version); err != nil {
+	apiURL, apiSource := tui.ResolveAPIURLSource(*apiURLFlag)
+	slog.Info("starting", "version", version, "api", apiURL, "api_source", apiSource, "demo", *demoFlag)
+
+	// `tdd-pro features` lists features for scripts without starting the TUI
+	if flag.Arg(0) == "features" {
+		return runFeatures(apiURL, *demoFlag, flag.Args()[1:])
+	}
+	if err := tui.Start(apiURL, version, *demoFlag, *readOnlyFlag); err != nil {
+		slog.Error("program exited with error", "err", err)
 		fmt.Println("Error running program:", err)
-		os.Exit(1)
+		return 1
+	}
+	return 0
+}
+
+// runFeatures runs the features subcommand against the MCP stdio server (or demo data)
+func runFeatures(apiURL string, demo bool, args []string) int {
+	var client mcpclient.Client
+	if demo {
+		client = mcpclient.NewDemoClient()
+	} else {
+		if _, err := mcpclient.GetMCPServerPath(); err != nil {
+			fmt.Fprintln(os.Stderr, "Error: MCP server not found:", err)
+			return 1
+		}
+		if cwd, err := os.Getwd(); err == nil {
+			if indexPath := util.FeaturesIndexPath(cwd); indexPath != "" {
+				if _, err := util.LoadFeaturesIndex(indexPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+					fmt.Fprintln(os.Stderr, "Error: invalid features index:", err)
+					return 1
+				}
+			}
+		}
+		client = mcpclient.NewMCPClient(apiURL)
 	}
+	defer client.Shutdown()
+	return cli.Features(client, args, os.Stdout, os.Stderr)
 }